@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/config"
+	"github.com/fabio/go-magento-cron-monitor/internal/database"
+	"github.com/fabio/go-magento-cron-monitor/internal/logger"
+	"github.com/fabio/go-magento-cron-monitor/internal/monitor"
+	"github.com/spf13/cobra"
+)
+
+var checkJSON bool
+
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Run a single check and print the alerts",
+	Long: `Run the monitor's detection once over the lookback window, including the
+scheduler health check, print the alerts and exit. No notifications are sent
+and no state is written.
+
+Since there is no history, threshold_checks is treated as 1 and the scheduler
+warmup is skipped; checks that learn from earlier checks (dropouts, cadence,
+pending growth, schedule_id jumps) can't fire.
+
+Exit codes: 0 when no alert fired, 1 on errors, 2 when at least one alert fired.
+
+Examples:
+  go-magento-cron-monitor check
+  go-magento-cron-monitor check --json`,
+	Run: runCheckCommand,
+}
+
+func init() {
+	rootCmd.AddCommand(checkCmd)
+	checkCmd.Flags().BoolVar(&checkJSON, "json", false, "print the alerts as JSON")
+}
+
+func runCheckCommand(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+
+	oneShotConfig(cfg)
+
+	log, err := logger.New(cfg.Logging, verbose)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error initializing logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer log.Close()
+
+	db, err := database.NewClient(cfg.Database)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to database: %v\n", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	svc := monitor.NewService(cfg, db, log, verbose)
+	alerts, err := svc.Detect()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Check failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	if checkJSON {
+		if alerts == nil {
+			alerts = []*logger.StuckCronAlert{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(alerts); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to encode alerts: %v\n", err)
+			os.Exit(1)
+		}
+	} else {
+		printCheckAlerts(alerts)
+	}
+
+	if len(alerts) > 0 {
+		os.Exit(2)
+	}
+}
+
+// oneShotConfig adjusts the configuration for a single detection run without history
+func oneShotConfig(cfg *config.Config) {
+	// There are no earlier checks to confirm detections against
+	cfg.Monitor.Detection.ThresholdChecks = 1
+	for i := range cfg.Monitor.JobOverrides {
+		if cfg.Monitor.JobOverrides[i].ThresholdChecks != nil {
+			one := 1
+			cfg.Monitor.JobOverrides[i].ThresholdChecks = &one
+		}
+	}
+	noWarmup := time.Duration(0)
+	cfg.Monitor.Detection.SchedulerWarmup = &noWarmup
+	// Leave the state file to a running monitor
+	cfg.State.File = ""
+}
+
+// printCheckAlerts prints the alerts of a check as a table
+func printCheckAlerts(alerts []*logger.StuckCronAlert) {
+	if len(alerts) == 0 {
+		fmt.Println("✓ No alerts")
+		return
+	}
+
+	fmt.Printf("%d alerts:\n\n", len(alerts))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "JOB\tSTATUS\tSEVERITY\tREASON")
+	for _, alert := range alerts {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", alert.JobCode, alert.Status, alert.Severity, alert.Reason)
+	}
+	w.Flush()
+}
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// defaultScope labels the global detection settings in the diff output
+const defaultScope = "(default)"
+
+var configDiffCmd = &cobra.Command{
+	Use:   "config-diff <old-config> <new-config>",
+	Short: "Show effective detection changes between two config files",
+	Long: `Load two config files and compare the effective detection settings, after
+defaults and job overrides are applied, instead of their YAML text.
+
+The global settings are listed under (default). Groups, match patterns and
+jobs with overrides in either file are listed only where their effective values
+change differently from the defaults.
+
+Example:
+  go-magento-cron-monitor config-diff config.yaml config.new.yaml`,
+	Args: cobra.ExactArgs(2),
+	Run:  runConfigDiff,
+}
+
+func init() {
+	rootCmd.AddCommand(configDiffCmd)
+}
+
+func runConfigDiff(cmd *cobra.Command, args []string) {
+	oldCfg, err := config.Load(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading %s: %v\n", args[0], err)
+		os.Exit(1)
+	}
+	newCfg, err := config.Load(args[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading %s: %v\n", args[1], err)
+		os.Exit(1)
+	}
+
+	// Every group, pattern and job with an override in either file, in order of precedence
+	groups, patterns, jobs := overrideScopes(oldCfg, newCfg)
+
+	defaultChanges := diffSettings(effectiveSettings(oldCfg, ""), effectiveSettings(newCfg, ""))
+
+	fmt.Printf("Effective detection changes: %s → %s\n", args[0], args[1])
+	changed := printScope(defaultScope, defaultChanges, nil)
+	for _, group := range groups {
+		match := func(o config.JobOverrideConfig) bool { return o.Group == group }
+		groupChanges := diffSettings(scopeSettings(oldCfg, match), scopeSettings(newCfg, match))
+		if printScope("group "+group, groupChanges, defaultChanges) {
+			changed = true
+		}
+	}
+	for _, pattern := range patterns {
+		match := func(o config.JobOverrideConfig) bool { return o.Match == pattern }
+		patternChanges := diffSettings(scopeSettings(oldCfg, match), scopeSettings(newCfg, match))
+		if printScope("match "+pattern, patternChanges, defaultChanges) {
+			changed = true
+		}
+	}
+	for _, job := range jobs {
+		jobChanges := diffSettings(effectiveSettings(oldCfg, job), effectiveSettings(newCfg, job))
+		if printScope(job, jobChanges, defaultChanges) {
+			changed = true
+		}
+	}
+
+	if !changed {
+		fmt.Println("\nNo effective changes")
+	}
+}
+
+// overrideScopes returns the groups, patterns and job codes with an override in any of the configs, sorted
+func overrideScopes(cfgs ...*config.Config) (groups, patterns, jobs []string) {
+	groupSet, patternSet, jobSet := make(map[string]bool), make(map[string]bool), make(map[string]bool)
+	for _, cfg := range cfgs {
+		for _, override := range cfg.Monitor.JobOverrides {
+			switch {
+			case override.JobCode != "":
+				jobSet[override.JobCode] = true
+			case override.Group != "":
+				groupSet[override.Group] = true
+			case override.Match != "":
+				patternSet[override.Match] = true
+			}
+		}
+	}
+	return sortedKeys(groupSet), sortedKeys(patternSet), sortedKeys(jobSet)
+}
+
+// sortedKeys returns the keys of a set in sorted order
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// scopeSettings returns the effective settings of the group or pattern overrides selected by match,
+// as they apply on their own; a scope without overrides has the default settings
+func scopeSettings(cfg *config.Config, match func(config.JobOverrideConfig) bool) map[string]string {
+	var overrides []config.JobOverrideConfig
+	for _, override := range cfg.Monitor.JobOverrides {
+		if override.JobCode == "" && match(override) {
+			overrides = append(overrides, override)
+		}
+	}
+	return overrideSettings(cfg, overrides...)
+}
+
+// settingChange is an effective value before and after
+type settingChange struct {
+	Old string
+	New string
+}
+
+// effectiveSettings flattens the effective detection and cooldown settings of a job
+// (the global defaults for an empty job code) into setting name → value
+func effectiveSettings(cfg *config.Config, jobCode string) map[string]string {
+	settings := make(map[string]string)
+	flattenSettings(reflect.ValueOf(cfg.GetDetectionConfig(jobCode)), "", settings)
+
+	cooldowns := cfg.GetCooldownConfig(jobCode)
+	settings["alert_cooldown"] = cooldowns.AlertCooldown.String()
+	settings["recovery_cooldown"] = cooldowns.RecoveryCooldown.String()
+	return settings
+}
+
+// flattenSettings walks a config struct, keying values by their mapstructure path
+func flattenSettings(v reflect.Value, prefix string, out map[string]string) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name := strings.Split(field.Tag.Get("mapstructure"), ",")[0]
+		key := name
+		if prefix != "" && name != "" {
+			key = prefix + "." + name
+		} else if name == "" {
+			key = prefix
+		}
+
+		value := v.Field(i)
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				out[key] = "unset"
+				continue
+			}
+			value = value.Elem()
+		}
+
+		// Nested settings blocks (e.g. scoring.weights), but not values like durations
+		if value.Kind() == reflect.Struct {
+			flattenSettings(value, key, out)
+			continue
+		}
+		out[key] = fmt.Sprintf("%v", value.Interface())
+	}
+}
+
+// diffSettings returns the settings whose value differs
+func diffSettings(oldSettings, newSettings map[string]string) map[string]settingChange {
+	changes := make(map[string]settingChange)
+	for key, oldValue := range oldSettings {
+		if newValue := newSettings[key]; newValue != oldValue {
+			changes[key] = settingChange{Old: oldValue, New: newValue}
+		}
+	}
+	return changes
+}
+
+// printScope prints a scope's changes, skipping those identical to the default changes
+// It reports whether anything was printed
+func printScope(scope string, changes, defaultChanges map[string]settingChange) bool {
+	keys := make([]string, 0, len(changes))
+	for key, change := range changes {
+		if defaultChange, ok := defaultChanges[key]; ok && defaultChange == change {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return false
+	}
+	sort.Strings(keys)
+
+	fmt.Printf("\n[%s]\n", scope)
+	for _, key := range keys {
+		fmt.Printf("  %s: %s → %s\n", key, changes[key].Old, changes[key].New)
+	}
+	return true
+}
@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/config"
+	"github.com/fabio/go-magento-cron-monitor/internal/database"
+	"github.com/spf13/cobra"
+)
+
+// maxHistoryMessageLen is how much of a row's messages the history table shows
+const maxHistoryMessageLen = 60
+
+var (
+	historyLookback time.Duration
+	historyLimit    int
+	historyStatus   string
+	historyJSON     bool
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history <job_code>",
+	Short: "Show the recent cron_schedule rows of a job",
+	Long: `Print the recent cron_schedule rows of a job, newest first: schedule_id,
+status, created/scheduled/executed/finished times and the messages (truncated
+in the table, complete with --json).
+
+Renamed job codes are resolved through monitor.aliases, so the canonical and
+the old code show the same history.
+
+Examples:
+  go-magento-cron-monitor history indexer_reindex_all_invalid
+  go-magento-cron-monitor history indexer_reindex_all_invalid --status error --lookback 24h
+  go-magento-cron-monitor history indexer_reindex_all_invalid --limit 5 --json`,
+	Args: cobra.ExactArgs(1),
+	Run:  runHistory,
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	historyCmd.Flags().DurationVar(&historyLookback, "lookback", 0, "how far back to look (default: detection.lookback_window)")
+	historyCmd.Flags().IntVar(&historyLimit, "limit", 50, "maximum number of rows")
+	historyCmd.Flags().StringVar(&historyStatus, "status", "", "only rows with this status (pending, running, success, missed or error)")
+	historyCmd.Flags().BoolVar(&historyJSON, "json", false, "print the rows as JSON")
+}
+
+// historyRow is one cron_schedule row as printed by the history command
+type historyRow struct {
+	ScheduleID  int        `json:"schedule_id"`
+	JobCode     string     `json:"job_code"`
+	Status      string     `json:"status"`
+	CreatedAt   time.Time  `json:"created_at"`
+	ScheduledAt *time.Time `json:"scheduled_at"`
+	ExecutedAt  *time.Time `json:"executed_at"`
+	FinishedAt  *time.Time `json:"finished_at"`
+	Messages    string     `json:"messages,omitempty"`
+}
+
+func runHistory(cmd *cobra.Command, args []string) {
+	switch historyStatus {
+	case "", "pending", "running", "success", "missed", "error":
+	default:
+		fmt.Fprintf(os.Stderr, "Invalid --status %q: must be pending, running, success, missed or error\n", historyStatus)
+		os.Exit(1)
+	}
+	if historyLimit <= 0 {
+		fmt.Fprintln(os.Stderr, "--limit must be positive")
+		os.Exit(1)
+	}
+
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	lookback := historyLookback
+	if lookback <= 0 {
+		lookback = cfg.Monitor.Detection.LookbackWindow
+	}
+
+	db, err := database.NewClient(cfg.Database)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to connect to database: %v\n", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	// Rows of renamed jobs are stored under the old code as well
+	jobCode := cfg.CanonicalJobCode(args[0])
+	codes := []string{jobCode}
+	for alias, canonical := range cfg.Monitor.Aliases {
+		if canonical == jobCode && alias != jobCode {
+			codes = append(codes, alias)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Monitor.QueryTimeout)
+	defer cancel()
+
+	var rows []historyRow
+	for _, code := range codes {
+		schedules, err := db.GetJobHistory(ctx, code, lookback, historyLimit, historyStatus)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to read history: %v\n", err)
+			os.Exit(1)
+		}
+		for _, s := range schedules {
+			rows = append(rows, newHistoryRow(s))
+		}
+	}
+	if len(codes) > 1 {
+		// Merge the histories newest first, like a single query returns them
+		sort.SliceStable(rows, func(i, j int) bool { return rows[i].CreatedAt.After(rows[j].CreatedAt) })
+		if len(rows) > historyLimit {
+			rows = rows[:historyLimit]
+		}
+	}
+
+	if historyJSON {
+		if rows == nil {
+			rows = []historyRow{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(rows); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to encode history: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	printHistory(jobCode, lookback, rows)
+}
+
+// newHistoryRow converts a cron_schedule row for printing
+func newHistoryRow(s *database.CronSchedule) historyRow {
+	return historyRow{
+		ScheduleID:  s.ScheduleID,
+		JobCode:     s.JobCode,
+		Status:      s.Status,
+		CreatedAt:   s.CreatedAt,
+		ScheduledAt: nullTime(s.ScheduledAt),
+		ExecutedAt:  nullTime(s.ExecutedAt),
+		FinishedAt:  nullTime(s.FinishedAt),
+		Messages:    s.Messages.String,
+	}
+}
+
+// nullTime returns a pointer to the time, or nil for NULL
+func nullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+// printHistory prints the rows as a table
+func printHistory(jobCode string, lookback time.Duration, rows []historyRow) {
+	fmt.Printf("History of %s over the last %s: %d rows\n", jobCode, lookback, len(rows))
+	if len(rows) == 0 {
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "\nSCHEDULE ID\tSTATUS\tCREATED\tSCHEDULED\tEXECUTED\tFINISHED\tMESSAGES")
+	for _, row := range rows {
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			row.ScheduleID, row.Status, formatHistoryTime(&row.CreatedAt), formatHistoryTime(row.ScheduledAt),
+			formatHistoryTime(row.ExecutedAt), formatHistoryTime(row.FinishedAt), truncateMessage(row.Messages))
+	}
+	w.Flush()
+}
+
+// formatHistoryTime formats a row timestamp, "-" for NULL
+func formatHistoryTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return "-"
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
+
+// truncateMessage shortens a row's messages to their first line, at most maxHistoryMessageLen characters
+func truncateMessage(messages string) string {
+	messages = strings.TrimSpace(messages)
+	if i := strings.IndexByte(messages, '\n'); i >= 0 {
+		messages = messages[:i] + " …"
+	}
+	if runes := []rune(messages); len(runes) > maxHistoryMessageLen {
+		messages = string(runes[:maxHistoryMessageLen-1]) + "…"
+	}
+	return messages
+}
@@ -1,23 +1,29 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"os/signal"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/fabio/go-magento-cron-monitor/internal/config"
 	"github.com/fabio/go-magento-cron-monitor/internal/database"
 	"github.com/fabio/go-magento-cron-monitor/internal/logger"
 	"github.com/fabio/go-magento-cron-monitor/internal/monitor"
 	"github.com/fabio/go-magento-cron-monitor/internal/pidfile"
+	"github.com/fabio/go-magento-cron-monitor/internal/telemetry"
 	"github.com/spf13/cobra"
 )
 
 var daemon bool
 
+// detached is set for the background process started by --daemon
+var detached bool
+
 var monitorCmd = &cobra.Command{
 	Use:   "monitor",
 	Short: "Start monitoring Magento cron jobs",
@@ -29,6 +35,8 @@ table for stuck or problematic cron jobs and logs alerts.`,
 func init() {
 	rootCmd.AddCommand(monitorCmd)
 	monitorCmd.Flags().BoolVarP(&daemon, "daemon", "d", false, "run in daemon mode")
+	monitorCmd.Flags().BoolVar(&detached, "detached", false, "set by --daemon for the background process")
+	monitorCmd.Flags().MarkHidden("detached")
 }
 
 func runMonitor(cmd *cobra.Command, args []string) {
@@ -48,12 +56,7 @@ func runMonitor(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	// Adjust log level based on verbosity
-	if verbose >= 3 {
-		cfg.Logging.Level = "debug"
-	} else if verbose == 2 {
-		cfg.Logging.Level = "info"
-	}
+	applyMonitorFlags(cfg)
 
 	// Initialize logger
 	log, err := logger.New(cfg.Logging, verbose)
@@ -63,6 +66,10 @@ func runMonitor(cmd *cobra.Command, args []string) {
 	}
 	defer log.Close()
 
+	for _, warning := range cfg.Warnings() {
+		log.Warn(warning, nil)
+	}
+
 	// Create and check PID file
 	pidPath := pidfile.GetDefaultPath(cfgFile)
 	pid := pidfile.New(pidPath)
@@ -73,26 +80,52 @@ func runMonitor(cmd *cobra.Command, args []string) {
 	defer pid.Remove()
 
 	log.Info("Starting Magento Cron Monitor", map[string]interface{}{
+		"driver":   cfg.Database.Driver,
 		"host":     cfg.Database.Host,
+		"socket":   cfg.Database.Socket,
 		"database": cfg.Database.Name,
 		"interval": cfg.Monitor.Interval.String(),
 		"pidfile":  pidPath,
 	})
 
-	// Create database client
-	db, err := database.NewClient(cfg.Database)
+	// Create database client, waiting for a database that is still starting up
+	retryPolicy := database.RetryPolicy{
+		MaxAttempts:  *cfg.Database.ConnectRetries + 1,
+		InitialDelay: cfg.Database.ConnectRetryDelay,
+		MaxDelay:     30 * time.Second,
+		Multiplier:   2,
+	}
+	// A shutdown signal stops retrying instead of waiting for the attempts to run out
+	connectCtx, stopConnect := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	db, err := database.NewClientWithRetry(connectCtx, cfg.Database, retryPolicy, func(attempt int, delay time.Duration, err error) {
+		log.Warn("Database connection failed, retrying", map[string]interface{}{
+			"attempt":      attempt,
+			"max_attempts": retryPolicy.MaxAttempts,
+			"retry_in":     delay.String(),
+			"error":        err.Error(),
+		})
+	})
+	stopConnect()
 	if err != nil {
 		log.Error("Failed to connect to database", err, nil)
 		os.Exit(1)
 	}
 	defer db.Close()
 
+	// Setup tracing (no-op unless telemetry.otlp_endpoint is set)
+	shutdownTracing, err := telemetry.Setup(context.Background(), cfg.Telemetry)
+	if err != nil {
+		log.Error("Failed to initialize tracing", err, nil)
+		os.Exit(1)
+	}
+	defer shutdownTracing(context.Background())
+
 	// Create monitor service
 	svc := monitor.NewService(cfg, db, log, verbose)
 
-	// Setup signal handling for graceful shutdown
+	// Setup signal handling for graceful shutdown, on-demand checks and config reloads
 	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	signal.Notify(sigChan, monitorSignals...)
 
 	// Start monitoring in a goroutine
 	errChan := make(chan error, 1)
@@ -101,19 +134,75 @@ func runMonitor(cmd *cobra.Command, args []string) {
 	}()
 
 	// Wait for shutdown signal or error
-	select {
-	case sig := <-sigChan:
-		log.Info("Received shutdown signal", map[string]interface{}{"signal": sig.String()})
-		svc.Stop()
-		log.Info("Monitor stopped", nil)
-	case err := <-errChan:
-		if err != nil {
-			log.Error("Monitor error", err, nil)
-			os.Exit(1)
+	for {
+		select {
+		case sig := <-sigChan:
+			if sig == checkSignal {
+				log.Info("On-demand check triggered", map[string]interface{}{"signal": sig.String()})
+				go func() {
+					if err := svc.RunOnce(); err != nil {
+						log.Error("On-demand check failed", err, nil)
+					}
+				}()
+				continue
+			}
+			if sig == reloadSignal {
+				log.Info("Config reload triggered", map[string]interface{}{"signal": sig.String()})
+				reloaded, err := config.Load(cfgFile)
+				if err != nil {
+					if err := svc.ReloadFailed(cfgFile, err); err != nil {
+						log.Error("Failed to send config reload failure notification", err, nil)
+					}
+					continue
+				}
+				applyMonitorFlags(reloaded)
+				for _, warning := range reloaded.Warnings() {
+					log.Warn(warning, nil)
+				}
+				svc.Reload(reloaded)
+				continue
+			}
+			if sig == exportSignal {
+				log.Info("State export triggered", map[string]interface{}{"signal": sig.String()})
+				if err := svc.ExportState(); err != nil {
+					log.Error("State export failed", err, nil)
+				}
+				continue
+			}
+			log.Info("Received shutdown signal", map[string]interface{}{"signal": sig.String()})
+			svc.Stop()
+			log.Info("Monitor stopped", nil)
+			return
+		case err := <-errChan:
+			if err != nil {
+				log.Error("Monitor error", err, nil)
+				os.Exit(1)
+			}
+			return
 		}
 	}
 }
 
+// applyMonitorFlags applies the command-line overrides of the monitor command to a loaded configuration
+func applyMonitorFlags(cfg *config.Config) {
+	if observe {
+		cfg.Monitor.ObserveOnly = true
+	}
+
+	// Adjust log level based on verbosity
+	if verbose >= 3 {
+		cfg.Logging.Level = "debug"
+	} else if verbose == 2 {
+		cfg.Logging.Level = "info"
+	}
+
+	// Nobody reads a detached daemon's stdout, unless logging.console says otherwise
+	if detached && cfg.Logging.Console == nil {
+		console := false
+		cfg.Logging.Console = &console
+	}
+}
+
 func runAsDaemon() error {
 	// Build args without -d/--daemon flag
 	args := []string{os.Args[0]}
@@ -138,6 +227,7 @@ func runAsDaemon() error {
 		
 		args = append(args, arg)
 	}
+	args = append(args, "--detached")
 
 	cmd := exec.Command(args[0], args[1:]...)
 	cmd.Stdout = nil
@@ -149,7 +239,7 @@ func runAsDaemon() error {
 	}
 
 	fmt.Printf("Monitor started in background (PID: %d)\n", cmd.Process.Pid)
-	fmt.Printf("To stop: kill %d\n", cmd.Process.Pid)
+	fmt.Printf("To stop: %s stop --config %s (or kill %d)\n", os.Args[0], cfgFile, cmd.Process.Pid)
 	
 	cmd.Process.Release()
 	return nil
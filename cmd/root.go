@@ -10,6 +10,7 @@ import (
 var (
 	cfgFile string
 	verbose int
+	observe bool
 )
 
 var rootCmd = &cobra.Command{
@@ -37,4 +38,5 @@ func Execute() {
 func init() {
 	rootCmd.PersistentFlags().StringVarP(&cfgFile, "config", "c", "config.yaml", "config file path")
 	rootCmd.PersistentFlags().CountVarP(&verbose, "verbose", "v", "verbosity level (-v, -vv, -vvv)")
+	rootCmd.PersistentFlags().BoolVar(&observe, "observe", false, "observe only: detect and log would-be alerts without sending notifications")
 }
@@ -0,0 +1,17 @@
+//go:build !windows
+
+package cmd
+
+import (
+	"os"
+	"syscall"
+)
+
+// monitorSignals are the signals the monitor command handles; all but the ones below shut it down
+var monitorSignals = []os.Signal{syscall.SIGINT, syscall.SIGTERM, syscall.SIGUSR1, syscall.SIGUSR2, syscall.SIGHUP}
+
+var (
+	checkSignal  os.Signal = syscall.SIGUSR1 // Run an on-demand check
+	exportSignal os.Signal = syscall.SIGUSR2 // Write the state export
+	reloadSignal os.Signal = syscall.SIGHUP  // Reload the configuration
+)
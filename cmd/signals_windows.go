@@ -0,0 +1,15 @@
+//go:build windows
+
+package cmd
+
+import "os"
+
+// monitorSignals are the signals the monitor command handles
+// Windows only delivers Ctrl+C, so on-demand checks, state exports and reloads aren't available
+var monitorSignals = []os.Signal{os.Interrupt}
+
+var (
+	checkSignal  os.Signal
+	exportSignal os.Signal
+	reloadSignal os.Signal
+)
@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/analyzer"
+	"github.com/fabio/go-magento-cron-monitor/internal/config"
+	"github.com/fabio/go-magento-cron-monitor/internal/database"
+	"github.com/fabio/go-magento-cron-monitor/internal/logger"
+	"github.com/fabio/go-magento-cron-monitor/internal/monitor"
+	"github.com/fabio/go-magento-cron-monitor/internal/snooze"
+	"github.com/spf13/cobra"
+)
+
+var statusJSON bool
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show job states, maintenance windows and active snoozes",
+	Long: `Show what the monitor thinks of each job: its last status, consecutive
+stuck checks, error and missed streaks and whether it is alerting.
+
+Job states are read from a running monitor over monitor.http_addr. Without a
+reachable monitor a fresh check is run instead (like the check command), in
+which case a job counts as alerting when the check raised an alert for it.
+
+Also shows whether a maintenance window is suppressing alerts and the active
+snoozes of the snooze file.`,
+	Run: runStatus,
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+	statusCmd.Flags().BoolVar(&statusJSON, "json", false, "print the job states as JSON")
+}
+
+// jobStatus is one row of the job state table
+type jobStatus struct {
+	JobCode          string `json:"job_code"`
+	CronGroup        string `json:"cron_group,omitempty"`
+	LastStatus       string `json:"last_status"`
+	ConsecutiveStuck int    `json:"consecutive_stuck"`
+	ErrorStreak      int    `json:"error_streak"`
+	MissedStreak     int    `json:"missed_streak"`
+	Alerting         bool   `json:"alerting"`
+}
+
+func runStatus(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+
+	if statusJSON {
+		jobs, source, err := jobStatuses(cfg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading job states: %v\n", err)
+			os.Exit(1)
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(map[string]interface{}{"source": source, "jobs": jobs}); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to encode job states: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	now := time.Now()
+	if window, ok := cfg.ActiveMaintenanceWindow(now); ok {
+		fmt.Printf("Maintenance: active (%s), alerts are suppressed\n", window.Label())
+	} else {
+		fmt.Printf("Maintenance: inactive (%d windows configured)\n", len(cfg.Monitor.Detection.MaintenanceWindows))
+	}
+
+	printSnoozes(cfg, now)
+
+	jobs, source, err := jobStatuses(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading job states: %v\n", err)
+		os.Exit(1)
+	}
+	printJobStatuses(jobs, source)
+}
+
+// jobStatuses reads the job states of a running monitor, falling back to a fresh check
+// It returns the rows sorted by job code and where they came from
+func jobStatuses(cfg *config.Config) ([]jobStatus, string, error) {
+	if cfg.Monitor.HTTPAddr != "" {
+		url := "http://" + statusHost(cfg.Monitor.HTTPAddr) + "/state"
+		jobs, err := remoteJobStatuses(url)
+		if err == nil {
+			return jobs, url, nil
+		}
+		fmt.Fprintf(os.Stderr, "Running monitor not reachable (%v), running a fresh check\n", err)
+	}
+
+	jobs, err := localJobStatuses(cfg)
+	return jobs, "fresh check", err
+}
+
+// statusHost turns a listen address into an address to connect to, e.g. ":8080" into "localhost:8080"
+func statusHost(addr string) string {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	if host == "" || host == "0.0.0.0" || host == "::" {
+		host = "localhost"
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// remoteJobStatuses fetches the job states from the /state endpoint of a running monitor
+func remoteJobStatuses(url string) ([]jobStatus, error) {
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var snapshot monitor.StateExport
+	if err := json.NewDecoder(resp.Body).Decode(&snapshot); err != nil {
+		return nil, fmt.Errorf("failed to decode state: %w", err)
+	}
+
+	jobs := make([]jobStatus, 0, len(snapshot.JobStates))
+	for jobCode, state := range snapshot.JobStates {
+		jobs = append(jobs, newJobStatus(jobCode, state, state.LastKnownState == "alerting"))
+	}
+	sortJobStatuses(jobs)
+	return jobs, nil
+}
+
+// localJobStatuses runs a fresh check and returns the resulting job states
+func localJobStatuses(cfg *config.Config) ([]jobStatus, error) {
+	oneShotConfig(cfg)
+
+	log, err := logger.New(cfg.Logging, verbose)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize logger: %w", err)
+	}
+	defer log.Close()
+
+	db, err := database.NewClient(cfg.Database)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	svc := monitor.NewService(cfg, db, log, verbose)
+	alerts, err := svc.Detect()
+	if err != nil {
+		return nil, err
+	}
+
+	alerting := make(map[string]bool)
+	for _, alert := range alerts {
+		alerting[alert.JobCode] = true
+	}
+
+	states := svc.Snapshot().JobStates
+	jobs := make([]jobStatus, 0, len(states))
+	for jobCode, state := range states {
+		jobs = append(jobs, newJobStatus(jobCode, state, alerting[jobCode]))
+	}
+	sortJobStatuses(jobs)
+	return jobs, nil
+}
+
+// newJobStatus builds a table row from a job state
+func newJobStatus(jobCode string, state *analyzer.JobState, alerting bool) jobStatus {
+	return jobStatus{
+		JobCode:          jobCode,
+		CronGroup:        state.CronGroup,
+		LastStatus:       state.LastStatus,
+		ConsecutiveStuck: state.ConsecutiveStuck,
+		ErrorStreak:      state.ErrorStreak,
+		MissedStreak:     state.MissedStreak,
+		Alerting:         alerting,
+	}
+}
+
+// sortJobStatuses orders rows by job code
+func sortJobStatuses(jobs []jobStatus) {
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].JobCode < jobs[j].JobCode })
+}
+
+// printJobStatuses prints the job state table
+func printJobStatuses(jobs []jobStatus, source string) {
+	fmt.Printf("\nJob states (%s): %d jobs\n", source, len(jobs))
+	if len(jobs) == 0 {
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "\nJOB\tLAST STATUS\tSTUCK\tERROR STREAK\tMISSED STREAK\tALERTING")
+	for _, job := range jobs {
+		lastStatus := job.LastStatus
+		if lastStatus == "" {
+			lastStatus = "-"
+		}
+		alerting := "no"
+		if job.Alerting {
+			alerting = "yes"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%s\n", job.JobCode, lastStatus, job.ConsecutiveStuck, job.ErrorStreak, job.MissedStreak, alerting)
+	}
+	w.Flush()
+}
+
+// printSnoozes prints the active snoozes of the snooze file
+func printSnoozes(cfg *config.Config, now time.Time) {
+	if cfg.Snooze.File == "" {
+		fmt.Println("Snoozing is not configured (snooze.file is empty)")
+		return
+	}
+
+	list := snooze.NewList(cfg.Snooze.File)
+	if err := list.Reload(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading %s: %v\n", cfg.Snooze.File, err)
+		os.Exit(1)
+	}
+
+	active := list.Active(now)
+	fmt.Printf("Snooze file: %s (%d active)\n", cfg.Snooze.File, len(active))
+	if len(active) == 0 {
+		return
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "\nJOB\tUNTIL\tREMAINING\tREASON")
+	for _, s := range active {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", s.Job, s.Until.Local().Format(time.RFC3339), s.Until.Sub(now).Round(time.Second), s.Reason)
+	}
+	w.Flush()
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/pidfile"
+	"github.com/spf13/cobra"
+)
+
+var stopTimeout time.Duration
+
+var stopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop a running monitor",
+	Long: `Stop the monitor started with the same --config, e.g. in daemon mode.
+
+The PID file is located the same way the monitor chooses it. The process gets
+SIGTERM, so it shuts down gracefully (flushing pending notifications and
+persisting state), and the PID file is removed once it has exited.
+
+Fails if there is no PID file, the process is no longer running (the stale PID
+file is removed) or it does not exit within --timeout.
+
+Windows can't signal another process, so there the monitor is killed instead.`,
+	Run: runStop,
+}
+
+func init() {
+	rootCmd.AddCommand(stopCmd)
+	stopCmd.Flags().DurationVar(&stopTimeout, "timeout", 30*time.Second, "how long to wait for the monitor to exit")
+}
+
+func runStop(cmd *cobra.Command, args []string) {
+	pidPath := pidfile.GetDefaultPath(cfgFile)
+	// The monitor falls back to /tmp when it can't write the default location
+	if _, err := os.Stat(pidPath); os.IsNotExist(err) {
+		if _, err := os.Stat(pidfile.FallbackPath(pidPath)); err == nil {
+			pidPath = pidfile.FallbackPath(pidPath)
+		}
+	}
+
+	pid, err := pidfile.New(pidPath).Stop(stopTimeout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to stop monitor: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("✓ Monitor stopped (PID: %d)\n", pid)
+}
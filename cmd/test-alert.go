@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/analyzer"
+	"github.com/fabio/go-magento-cron-monitor/internal/config"
+	"github.com/fabio/go-magento-cron-monitor/internal/logger"
+	"github.com/fabio/go-magento-cron-monitor/internal/monitor"
+	"github.com/spf13/cobra"
+)
+
+var (
+	testAlertJob      string
+	testAlertRule     string
+	testAlertRecovery bool
+)
+
+var testAlertCmd = &cobra.Command{
+	Use:   "test-alert",
+	Short: "Send a synthetic alert through the full notification pipeline",
+	Long: `Build a synthetic alert for a job and detection rule and run it through the
+same notification dispatch the monitor uses, with the real configuration.
+Unlike test-slack, this exercises every enabled notifier, routing, cooldowns
+and formatting. The database is not queried.
+
+Rules: long_running, pending, errors, missed, pending_growth
+
+Examples:
+  go-magento-cron-monitor test-alert --job indexer_reindex_all_invalid --rule long_running
+  go-magento-cron-monitor test-alert --job indexer_reindex_all_invalid --recovery`,
+	Run: runTestAlert,
+}
+
+func init() {
+	rootCmd.AddCommand(testAlertCmd)
+	testAlertCmd.Flags().StringVar(&testAlertJob, "job", "", "job code to alert for (required)")
+	testAlertCmd.Flags().StringVar(&testAlertRule, "rule", "long_running", "detection rule to simulate")
+	testAlertCmd.Flags().BoolVar(&testAlertRecovery, "recovery", false, "send a recovery notification instead of alerting")
+	testAlertCmd.MarkFlagRequired("job")
+}
+
+func runTestAlert(cmd *cobra.Command, args []string) {
+	// Load configuration
+	cfg, err := config.Load(cfgFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading config: %v\n", err)
+		os.Exit(1)
+	}
+	if observe {
+		cfg.Monitor.ObserveOnly = true
+	}
+
+	// Initialize logger
+	log, err := logger.New(cfg.Logging, verbose)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error initializing logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer log.Close()
+
+	transition, alert, err := syntheticTransition(cfg, testAlertJob, testAlertRule, testAlertRecovery)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	// The database is not needed to dispatch notifications
+	svc := monitor.NewService(cfg, nil, log, verbose)
+
+	fmt.Printf("Dispatching %s test notification for %s (rule: %s)...\n", transition.ToState, testAlertJob, testAlertRule)
+	if err := svc.SendTestAlert(transition, alert); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to send test alert: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("✓ Test alert dispatched")
+}
+
+// syntheticTransition builds a transition and alert mimicking what the analyzer emits for a rule
+func syntheticTransition(cfg *config.Config, jobCode, rule string, recovery bool) (analyzer.StateTransition, *logger.StuckCronAlert, error) {
+	now := time.Now()
+	scheduledAt := now.Add(-time.Minute)
+	detectionCfg := cfg.GetDetectionConfig(jobCode)
+
+	if recovery {
+		completed := 2 * time.Minute
+		return analyzer.StateTransition{
+			CronCode:       jobCode,
+			FromState:      "alerting",
+			ToState:        "not_alerting",
+			Timestamp:      now,
+			StuckDuration:  10 * time.Minute,
+			Status:         "success",
+			LastExecution:  now.Add(-completed),
+			ScheduledAt:    &scheduledAt,
+			CompletionTime: &completed,
+		}, nil, nil
+	}
+
+	alert := &logger.StuckCronAlert{
+		JobCode:          jobCode,
+		ScheduledAt:      &scheduledAt,
+		ConsecutiveStuck: detectionCfg.ThresholdChecks,
+	}
+
+	switch rule {
+	case "long_running":
+		runningTime := detectionCfg.MaxRunningTime + 5*time.Minute
+		executedAt := now.Add(-runningTime)
+		alert.Status = "running"
+		alert.RunningTime = &runningTime
+		alert.ExecutedAt = &executedAt
+		alert.Reason = fmt.Sprintf("job running longer than max_running_time threshold (%s)", detectionCfg.MaxRunningTime)
+	case "pending":
+		alert.Status = "pending"
+		alert.PendingCount = detectionCfg.MaxPendingCount + 1
+		alert.Reason = fmt.Sprintf("too many pending jobs (%d exceeds threshold of %d)", alert.PendingCount, detectionCfg.MaxPendingCount)
+	case "errors":
+		alert.Status = "error"
+		alert.ErrorCount = detectionCfg.ConsecutiveErrors
+		alert.ErrorMessage = "Synthetic error generated by test-alert"
+		alert.Reason = fmt.Sprintf("consecutive errors detected (%d meets threshold of %d)", alert.ErrorCount, detectionCfg.ConsecutiveErrors)
+	case "missed":
+		alert.Status = "missed"
+		alert.MissedCount = detectionCfg.MaxMissedCount
+		alert.Reason = fmt.Sprintf("too many missed executions (%d exceeds threshold of %d)", alert.MissedCount, detectionCfg.MaxMissedCount)
+	case "pending_growth":
+		alert.Status = "pending"
+		alert.PendingCount = 60
+		alert.Reason = "pending backlog growing for 3 consecutive checks (15→30→45→60)"
+	default:
+		return analyzer.StateTransition{}, nil, fmt.Errorf("unknown rule %q (expected long_running, pending, errors, missed or pending_growth)", rule)
+	}
+
+	return analyzer.StateTransition{
+		CronCode:         jobCode,
+		FromState:        "not_alerting",
+		ToState:          "alerting",
+		Timestamp:        now,
+		Status:           alert.Status,
+		LastExecution:    now.Add(-time.Minute),
+		ScheduledAt:      &scheduledAt,
+		Reason:           alert.Reason,
+		ConsecutiveStuck: alert.ConsecutiveStuck,
+	}, alert, nil
+}
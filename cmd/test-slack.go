@@ -1,11 +1,13 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/fabio/go-magento-cron-monitor/internal/cronalert"
 	"github.com/fabio/go-magento-cron-monitor/internal/slack"
 )
 
@@ -35,21 +37,52 @@ Examples:
 	RunE: runTestSlack,
 }
 
-var recoveryFlag bool
+var (
+	recoveryFlag     bool
+	testSlackTimeout time.Duration
+)
 
 func init() {
 	rootCmd.AddCommand(testSlackCmd)
 	testSlackCmd.Flags().BoolVar(&recoveryFlag, "recovery", false, "Send a recovery notification instead of alerting")
+	testSlackCmd.Flags().DurationVar(&testSlackTimeout, "timeout", 10*time.Second, "HTTP timeout for the webhook request")
 }
 
 func runTestSlack(cmd *cobra.Command, args []string) error {
 	webhookURL := args[0]
-	alertJSON := args[1]
 
+	alert, err := buildTestAlert(args[1], recoveryFlag)
+	if err != nil {
+		return err
+	}
+	alertType := alert.Type
+
+	// Create Slack client and send alert
+	config := slack.Config{
+		Enabled:     true,
+		WebhookURLs: []string{webhookURL},
+		Timeout:     testSlackTimeout,
+	}
+	client := slack.New(config)
+	
+	fmt.Printf("Sending %s notification to Slack...\n", alertType)
+	fmt.Printf("Webhook URL: %s\n", webhookURL)
+	fmt.Printf("Alert data: %+v\n\n", alert)
+	
+	if err := client.SendAlert(context.Background(), alert); err != nil {
+		return fmt.Errorf("failed to send Slack alert: %w", err)
+	}
+
+	fmt.Printf("✅ Successfully sent %s notification to Slack!\n", alertType)
+	return nil
+}
+
+// buildTestAlert parses the alert JSON of the test commands into a cronalert.Alert
+func buildTestAlert(alertJSON string, recovery bool) (cronalert.Alert, error) {
 	// Parse the JSON input
 	var testData TestSlackData
 	if err := json.Unmarshal([]byte(alertJSON), &testData); err != nil {
-		return fmt.Errorf("failed to parse alert JSON: %w", err)
+		return cronalert.Alert{}, fmt.Errorf("failed to parse alert JSON: %w", err)
 	}
 
 	// Parse timestamps
@@ -76,11 +109,11 @@ func runTestSlack(cmd *cobra.Command, args []string) error {
 	}
 
 	// Create the alert
-	alertType := slack.AlertTypeAlerting
+	alertType := cronalert.Alerting
 	stuckDuration := time.Duration(0)
 	
-	if recoveryFlag {
-		alertType = slack.AlertTypeNotAlerting
+	if recovery {
+		alertType = cronalert.NotAlerting
 		// For recovery, calculate how long it was stuck (use running time as proxy)
 		if runningTime != nil {
 			stuckDuration = *runningTime
@@ -89,7 +122,7 @@ func runTestSlack(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	alert := slack.CronAlert{
+	alert := cronalert.Alert{
 		Type:             alertType,
 		CronCode:         testData.JobCode,
 		Status:           testData.Status,
@@ -102,22 +135,5 @@ func runTestSlack(cmd *cobra.Command, args []string) error {
 		ConsecutiveStuck: testData.ConsecutiveStuck,
 	}
 
-	// Create Slack client and send alert
-	config := slack.Config{
-		Enabled:     true,
-		WebhookURLs: []string{webhookURL},
-		Timeout:     10 * time.Second,
-	}
-	client := slack.New(config)
-	
-	fmt.Printf("Sending %s notification to Slack...\n", alertType)
-	fmt.Printf("Webhook URL: %s\n", webhookURL)
-	fmt.Printf("Alert data: %+v\n\n", alert)
-	
-	if err := client.SendAlert(alert); err != nil {
-		return fmt.Errorf("failed to send Slack alert: %w", err)
-	}
-
-	fmt.Printf("✅ Successfully sent %s notification to Slack!\n", alertType)
-	return nil
-}
\ No newline at end of file
+	return alert, nil
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/teams"
+	"github.com/spf13/cobra"
+)
+
+var testTeamsCmd = &cobra.Command{
+	Use:   "test-teams <webhook-url> <alert-json>",
+	Short: "Test Microsoft Teams notifications with custom alert data",
+	Long: `Test Microsoft Teams notifications by sending a sample alert or recovery card.
+The alert JSON has the same fields as for test-slack.
+
+Examples:
+  # Test alerting notification
+  go-magento-cron-monitor test-teams "https://example.webhook.office.com/..." '{"consecutive_stuck":6,"executed_at":"2025-10-31T09:21:21Z","job_code":"image_binder_run","reason":"job running longer than max_running_time threshold (1h0m0s)","running_time":"1h9m11.666374962s","scheduled_at":"2025-10-31T09:20:00Z","status":"running"}'
+
+  # Test recovery notification
+  go-magento-cron-monitor test-teams "https://example.webhook.office.com/..." '{"consecutive_stuck":0,"executed_at":"2025-10-31T09:21:21Z","job_code":"image_binder_run","reason":"Issues resolved - cron job returned to normal operation","scheduled_at":"2025-10-31T09:20:00Z","status":"success"}' --recovery`,
+	Args: cobra.ExactArgs(2),
+	RunE: runTestTeams,
+}
+
+var (
+	teamsRecoveryFlag bool
+	testTeamsTimeout  time.Duration
+)
+
+func init() {
+	rootCmd.AddCommand(testTeamsCmd)
+	testTeamsCmd.Flags().BoolVar(&teamsRecoveryFlag, "recovery", false, "Send a recovery notification instead of alerting")
+	testTeamsCmd.Flags().DurationVar(&testTeamsTimeout, "timeout", 10*time.Second, "HTTP timeout for the webhook request")
+}
+
+func runTestTeams(cmd *cobra.Command, args []string) error {
+	webhookURL := args[0]
+
+	alert, err := buildTestAlert(args[1], teamsRecoveryFlag)
+	if err != nil {
+		return err
+	}
+
+	client := teams.New(teams.Config{
+		Enabled:     true,
+		WebhookURLs: []string{webhookURL},
+		Timeout:     testTeamsTimeout,
+	})
+
+	fmt.Printf("Sending %s notification to Teams...\n", alert.Type)
+	fmt.Printf("Webhook URL: %s\n", webhookURL)
+	fmt.Printf("Alert data: %+v\n\n", alert)
+
+	if err := client.SendAlert(context.Background(), alert); err != nil {
+		return fmt.Errorf("failed to send Teams alert: %w", err)
+	}
+
+	fmt.Printf("✅ Successfully sent %s notification to Teams!\n", alert.Type)
+	return nil
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 
@@ -28,8 +29,16 @@ func runTest(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	fmt.Printf("Testing database connection to %s:%d/%s...\n", 
-		cfg.Database.Host, cfg.Database.Port, cfg.Database.Name)
+	for _, warning := range cfg.Warnings() {
+		fmt.Printf("Warning: %s\n", warning)
+	}
+
+	if cfg.Database.Socket != "" {
+		fmt.Printf("Testing database connection to %s (database %s)...\n", cfg.Database.Socket, cfg.Database.Name)
+	} else {
+		fmt.Printf("Testing database connection to %s:%d/%s...\n", 
+			cfg.Database.Host, cfg.Database.Port, cfg.Database.Name)
+	}
 
 	// Create database client
 	db, err := database.NewClient(cfg.Database)
@@ -40,7 +49,7 @@ func runTest(cmd *cobra.Command, args []string) {
 	defer db.Close()
 
 	// Test the connection
-	if err := db.Ping(); err != nil {
+	if err := db.Ping(context.Background()); err != nil {
 		fmt.Fprintf(os.Stderr, "Connection test failed: %v\n", err)
 		os.Exit(1)
 	}
@@ -48,7 +57,7 @@ func runTest(cmd *cobra.Command, args []string) {
 	fmt.Println("✓ Database connection successful!")
 
 	// Try to query cron_schedule table
-	count, err := db.GetCronScheduleCount()
+	count, err := db.GetCronScheduleCount(context.Background())
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: Could not query cron_schedule table: %v\n", err)
 		os.Exit(1)
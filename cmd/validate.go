@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var validateStrict bool
+
+var validateCmd = &cobra.Command{
+	Use:   "validate [config-file]",
+	Short: "Check a config file without connecting to anything",
+	Long: `Load and validate a config file (the positional path, or --config) and print
+the effective settings after defaults are applied: the monitor settings, the
+default detection settings and, for each job override, the settings it
+changes once group, pattern and job overrides are resolved.
+
+Neither the database nor any notification service is contacted. Exits with 1
+if the file can't be loaded or fails validation.
+
+With --strict, valid but suspicious values are reported as well (e.g. a
+lookback window shorter than the check interval) and any warning makes the
+command exit with 1.
+
+Examples:
+  go-magento-cron-monitor validate config.new.yaml
+  go-magento-cron-monitor validate --config /etc/magento-cron-monitor/config.yaml --strict`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runValidate,
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+	validateCmd.Flags().BoolVar(&validateStrict, "strict", false, "also warn about suspicious values and fail on any warning")
+}
+
+func runValidate(cmd *cobra.Command, args []string) {
+	path := cfgFile
+	if len(args) == 1 {
+		path = args[0]
+	}
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "✗ %s is invalid: %v\n", path, err)
+		os.Exit(1)
+	}
+	fmt.Printf("✓ %s is valid\n", path)
+
+	warnings := cfg.Warnings()
+	if validateStrict {
+		warnings = append(warnings, cfg.StrictWarnings()...)
+	}
+	for _, warning := range warnings {
+		fmt.Printf("⚠ %s\n", warning)
+	}
+
+	printValidatedSettings(cfg)
+
+	if validateStrict && len(warnings) > 0 {
+		os.Exit(1)
+	}
+}
+
+// printValidatedSettings prints the effective settings of a loaded config
+// Credentials are left out
+func printValidatedSettings(cfg *config.Config) {
+	address := cfg.Database.Socket
+	if address == "" {
+		address = fmt.Sprintf("%s:%d", cfg.Database.Host, cfg.Database.Port)
+	}
+	printSettings("database", map[string]string{
+		"driver":  cfg.Database.Driver,
+		"address": address,
+		"name":    cfg.Database.Name,
+		"user":    cfg.Database.User,
+	})
+
+	// Detection settings and overrides are listed on their own below
+	monitorSettings := make(map[string]string)
+	flattenSettings(reflect.ValueOf(cfg.Monitor), "", monitorSettings)
+	for key := range monitorSettings {
+		if key == "job_overrides" || strings.HasPrefix(key, "detection") {
+			delete(monitorSettings, key)
+		}
+	}
+	printSettings("monitor", monitorSettings)
+
+	defaults := effectiveSettings(cfg, "")
+	defaults["severities"] = fmt.Sprintf("%v", cfg.AlertSeverities())
+	printSettings("detection "+defaultScope, defaults)
+
+	notifiers := enabledNotifiers(cfg)
+	if len(notifiers) == 0 {
+		notifiers = []string{"none"}
+	}
+	printSettings("notifications", map[string]string{
+		"enabled":     strings.Join(notifiers, ", "),
+		"async":       fmt.Sprintf("%v", cfg.Notifications.Async),
+		"escalations": fmt.Sprintf("%d", len(cfg.Notifications.Escalation)),
+	})
+
+	// Each override lists only what it changes from the defaults
+	for _, override := range cfg.Monitor.JobOverrides {
+		var scope string
+		var settings map[string]string
+		switch {
+		case override.JobCode != "":
+			scope = "job " + override.JobCode
+			settings = effectiveSettings(cfg, override.JobCode)
+		case override.Group != "":
+			scope = "group " + override.Group
+			settings = overrideSettings(cfg, override)
+		default:
+			scope = "match " + override.Match
+			settings = overrideSettings(cfg, override)
+		}
+
+		changes := diffSettings(defaults, settings)
+		delete(changes, "severities")
+		printed := make(map[string]string, len(changes))
+		for key, change := range changes {
+			printed[key] = fmt.Sprintf("%s (default: %s)", change.New, change.Old)
+		}
+		if len(printed) == 0 {
+			fmt.Printf("\n[%s]\n  no changes from the defaults\n", scope)
+			continue
+		}
+		printSettings(scope, printed)
+	}
+}
+
+// overrideSettings returns the effective settings of group or pattern overrides on their own,
+// i.e. for a job matched by nothing else; later overrides win, without any the defaults apply
+func overrideSettings(cfg *config.Config, overrides ...config.JobOverrideConfig) map[string]string {
+	const scopeJob = "\x00override"
+	scoped := *cfg
+	scoped.Monitor.JobOverrides = make([]config.JobOverrideConfig, len(overrides))
+	for i, override := range overrides {
+		override.JobCode, override.Group, override.Match = scopeJob, "", ""
+		scoped.Monitor.JobOverrides[i] = override
+	}
+	return effectiveSettings(&scoped, scopeJob)
+}
+
+// enabledNotifiers lists the notification channels that are enabled
+func enabledNotifiers(cfg *config.Config) []string {
+	var names []string
+	n := cfg.Notifications
+	for _, notifier := range []struct {
+		name    string
+		enabled bool
+	}{
+		{"slack", n.Slack.Enabled},
+		{"email", n.Email.Enabled},
+		{"teams", n.Teams.Enabled},
+		{"webhook", n.Webhook.Enabled},
+		{"pagerduty", n.PagerDuty.Enabled},
+	} {
+		if notifier.enabled {
+			names = append(names, notifier.name)
+		}
+	}
+	return names
+}
+
+// printSettings prints a block of settings sorted by name
+func printSettings(scope string, settings map[string]string) {
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fmt.Printf("\n[%s]\n", scope)
+	for _, key := range keys {
+		value := settings[key]
+		if value == "" {
+			value = `""`
+		}
+		fmt.Printf("  %s: %s\n", key, value)
+	}
+}
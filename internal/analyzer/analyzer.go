@@ -1,7 +1,13 @@
 package analyzer
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -14,30 +20,85 @@ import (
 type Analyzer struct {
 	config *config.Config
 	// Track state across checks
-	jobStates        map[string]*JobState
-	schedulerState   *SchedulerState
-	mu               sync.RWMutex
+	jobStates         map[string]*JobState
+	schedulerState    *SchedulerState
+	mu                sync.RWMutex
+	startedAt         time.Time // When the analyzer was created, for the scheduler check warmup
+	snoozed           func(jobCode string) bool
+	clock             Clock            // Source of the current time, replaceable in tests
+	schedulerRecovery *StateTransition // Scheduler recovery found by the last CheckSchedulerHealth, nil if none
 }
 
 // JobState tracks the state of a cron job across multiple checks
 type JobState struct {
 	JobCode          string
-	ConsecutiveStuck int
-	LastStatus       string
+	CronGroup        string // Group from monitor.job_groups, empty if the job belongs to none
+	ConsecutiveStuck int    // Highest of the per-condition counters below
+	LastStatus       string // Status of the most recently detected condition, empty while none holds
 	LastChecked      time.Time
 	LastAlertTime    time.Time
 	ErrorStreak      int
 	MissedStreak     int
-	// Slack notification tracking
-	LastSlackAlert time.Time // Track last Slack notification time
-	LastKnownState string    // "not_alerting" or "alerting"
-	StuckSince     time.Time // When cron became stuck
+	// Consecutive detections per condition, so unrelated conditions don't reset each other
+	LongRunningChecks int
+	OrphanedChecks    int
+	PendingChecks     int
+	ErrorChecks       int
+	SuccessRateChecks int
+	MissedChecks      int
+	LatencyChecks     int
+	AbsentChecks      int // Checks an expected job had no recent rows (see CheckExpectedJobs)
+	// Pending backlog trend tracking
+	PendingHistory      []int // Pending counts of recent checks, oldest first
+	PendingGrowthStreak int   // Consecutive checks where the pending count increased
+	// Short completion tracking
+	LastShortRunID int // Schedule ID of the last run flagged as suspiciously short
+	// Missing job tracking, for jobs that alert without rows in the check
+	MissingStatus string // Status of the missing job alert (e.g. absent) while the job is missing, empty otherwise
+	MissingReason string // Reason of the missing job alert, reported by notifications
+	// Schedule creation cadence tracking
+	CreationInterval time.Duration // Learned typical gap between created_at of successive schedules
+	LastCreatedAt    time.Time     // Newest created_at seen for this job
+	// Execution cadence tracking
+	ExpectedCadence time.Duration // Learned typical gap between scheduled_at of successive schedules
+	ObservedCadence time.Duration // Median gap between executed_at of recent runs
+	// Health score tracking (detection.mode: score)
+	LastScore   float64
+	ScoreSignal map[string]float64 // Normalized signal values of the last score
+	ScoreStreak int                // Consecutive checks with the score at or above threshold
+	// Notification tracking
+	LastNotified   map[string]time.Time // Last notification time per notifier cooldown key
+	LastKnownState string               // "not_alerting" or "alerting"
+	StuckSince     time.Time            // When cron became stuck
+	LastRecovery   time.Time            // When cron last went from alerting to not_alerting
+	AlertSeverity  string               // Severity of the current or last incident's alert notification
+	// Alert lead time tracking
+	IssueSince       time.Time     // When the current issue was first detected, before threshold checks
+	IncidentNotified bool          // Whether a stuck notification was sent for the current incident
+	LastLeadTime     time.Duration // Delay between IssueSince and the first notification of the last incident
+	// Escalation ladder tracking
+	EscalationLevel int // Number of escalation steps fired for the current incident
 }
 
 // SchedulerState tracks the cron scheduler health across checks
 type SchedulerState struct {
 	ConsecutiveInactive int
 	LastAlertTime       time.Time
+	InactiveSince       time.Time // When the scheduler was first detected inactive, zero while healthy
+	EscalationLevel     int       // Number of scheduler escalation steps fired for the current outage
+	Alerting            bool      // Whether an alert was raised for the current outage, so its end is notified
+	// Empty lookback result tracking
+	ConsecutiveEmpty   int
+	LastEmptyAlertTime time.Time
+	// Future executed_at tracking
+	LastSuspiciousAlertTime time.Time
+	// NULL scheduled_at tracking
+	LastNullScheduledAlertTime time.Time
+	// Inconsistent timing field tracking
+	LastInconsistentAlertTime time.Time
+	// schedule_id sequence tracking
+	MaxScheduleID     int       // Highest schedule_id seen so far
+	MaxScheduleIDSeen time.Time // When MaxScheduleID was last updated
 }
 
 // StateTransition represents a cron state change
@@ -58,17 +119,39 @@ type StateTransition struct {
 	PendingCount     int
 	ErrorCount       int
 	MissedCount      int
+
+	// CompletionTime is how long the recovering run took (finished_at - executed_at)
+	CompletionTime *time.Duration
 }
 
 // NewAnalyzer creates a new analyzer
 func NewAnalyzer(cfg *config.Config) *Analyzer {
+	clock := realClock{}
 	return &Analyzer{
 		config:         cfg,
 		jobStates:      make(map[string]*JobState),
 		schedulerState: &SchedulerState{},
+		startedAt:      clock.Now(),
+		clock:          clock,
 	}
 }
 
+// SetConfig replaces the configuration, e.g. after a reload
+// Job states are kept; the new thresholds apply from the next check
+func (a *Analyzer) SetConfig(cfg *config.Config) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.config = cfg
+}
+
+// SetSnoozeFunc sets the check for jobs whose stuck notifications are snoozed
+// A snoozed job stays not_alerting, so it is notified once the snooze expires if still stuck
+func (a *Analyzer) SetSnoozeFunc(snoozed func(jobCode string) bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.snoozed = snoozed
+}
+
 // Analyze examines recent cron schedules and detects stuck jobs
 func (a *Analyzer) Analyze(schedules []*database.CronSchedule) []*logger.StuckCronAlert {
 	a.mu.Lock()
@@ -76,6 +159,11 @@ func (a *Analyzer) Analyze(schedules []*database.CronSchedule) []*logger.StuckCr
 	
 	var alerts []*logger.StuckCronAlert
 
+	schedules = a.detectionSchedules(schedules)
+
+	// During maintenance state is still tracked, but alerts are held back until the window ends
+	maintenance := a.inMaintenance()
+
 	// Group schedules by job_code
 	jobSchedules := make(map[string][]*database.CronSchedule)
 	for _, s := range schedules {
@@ -94,33 +182,49 @@ func (a *Analyzer) Analyze(schedules []*database.CronSchedule) []*logger.StuckCr
 			}
 			a.jobStates[jobCode] = state
 		}
-		state.LastChecked = time.Now()
+		state.LastChecked = a.clock.Now()
+		state.CronGroup = schedList[0].CronGroup
 
-		// Check for various stuck conditions
-		if alert := a.checkLongRunning(schedList, detectionCfg, state); alert != nil {
-			// Suppress duplicate alerts within 5 minutes
-			if time.Since(state.LastAlertTime) >= 5*time.Minute {
-				alerts = append(alerts, alert)
-				state.LastAlertTime = time.Now()
+		// emit reports an alert, unless maintenance holds it back or the job already alerted within
+		// the suppression window
+		emit := func(alert *logger.StuckCronAlert) {
+			if alert == nil || maintenance || a.since(state.LastAlertTime) < detectionCfg.AlertSuppressionWindow {
+				return
 			}
+			alerts = append(alerts, alert)
+			state.LastAlertTime = a.clock.Now()
 		}
-		if alert := a.checkPendingAccumulation(schedList, detectionCfg, state); alert != nil {
-			if time.Since(state.LastAlertTime) >= 5*time.Minute {
-				alerts = append(alerts, alert)
-				state.LastAlertTime = time.Now()
-			}
+
+		if detectionCfg.Mode == "score" {
+			// Weighted health score replaces the independent rules
+			a.updateScore(schedList, detectionCfg, state)
+			emit(a.checkScore(detectionCfg, state))
+		} else {
+			// Check for various stuck conditions, a crashed worker before a slow job
+			emit(a.checkOrphanedRunning(schedList, detectionCfg, state))
+			emit(a.checkLongRunning(schedList, detectionCfg, state))
+			emit(a.checkPendingAccumulation(schedList, detectionCfg, state))
+			emit(a.checkConsecutiveErrors(schedList, detectionCfg, state))
+			emit(a.checkSuccessRate(schedList, detectionCfg, state))
+			emit(a.checkMissedExecutions(schedList, detectionCfg, state))
+			emit(a.checkSchedulingLatency(schedList, detectionCfg, state))
+			a.updatePendingTrend(schedList, detectionCfg, state)
+			emit(a.checkPendingGrowth(detectionCfg, state))
 		}
-		if alert := a.checkConsecutiveErrors(schedList, detectionCfg, state); alert != nil {
-			if time.Since(state.LastAlertTime) >= 5*time.Minute {
-				alerts = append(alerts, alert)
-				state.LastAlertTime = time.Now()
-			}
+		// Remember when the current issue was first detected, for alert lead time
+		issue := state.ConsecutiveStuck > 0 || state.PendingGrowthStreak > 0
+		if detectionCfg.Mode == "score" {
+			issue = state.ScoreStreak > 0
 		}
-		if alert := a.checkMissedExecutions(schedList, detectionCfg, state); alert != nil {
-			if time.Since(state.LastAlertTime) >= 5*time.Minute {
-				alerts = append(alerts, alert)
-				state.LastAlertTime = time.Now()
-			}
+		if issue && state.IssueSince.IsZero() {
+			state.IssueSince = a.clock.Now()
+		} else if !issue && state.LastKnownState != "alerting" {
+			state.IssueSince = time.Time{}
+		}
+
+		// Informational only: logged once per run, does not affect the alerting state
+		if alert := a.checkShortCompletion(schedList, detectionCfg, state); alert != nil && !maintenance {
+			alerts = append(alerts, alert)
 		}
 	}
 
@@ -130,31 +234,77 @@ func (a *Analyzer) Analyze(schedules []*database.CronSchedule) []*logger.StuckCr
 	return alerts
 }
 
+// detectionSchedules drops schedules of jobs excluded by ignore_jobs / only_jobs and those older than
+// detection.ignore_older_than, so excluded jobs never get a job state
+// Age is measured on the lookback_field column so the detection window narrows the fetch window
+func (a *Analyzer) detectionSchedules(schedules []*database.CronSchedule) []*database.CronSchedule {
+	cfg := a.config.Monitor.Detection
+	if cfg.IgnoreOlderThan <= 0 && len(cfg.IgnoreJobs) == 0 && len(cfg.OnlyJobs) == 0 {
+		return schedules
+	}
+
+	cutoff := a.clock.Now().Add(-cfg.IgnoreOlderThan)
+	monitored := make(map[string]bool)
+	filtered := make([]*database.CronSchedule, 0, len(schedules))
+	for _, s := range schedules {
+		ok, known := monitored[s.JobCode]
+		if !known {
+			ok = a.config.IsMonitoredJob(s.JobCode)
+			monitored[s.JobCode] = ok
+		}
+		if !ok {
+			continue
+		}
+
+		ts := s.CreatedAt
+		if cfg.LookbackField == "scheduled_at" && s.ScheduledAt.Valid {
+			ts = s.ScheduledAt.Time
+		}
+		if cfg.IgnoreOlderThan <= 0 || !ts.Before(cutoff) {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
+
 // checkLongRunning detects jobs that have been running too long
 func (a *Analyzer) checkLongRunning(schedules []*database.CronSchedule, cfg config.DetectionConfig, state *JobState) *logger.StuckCronAlert {
+	if !config.Enabled(cfg.DetectLongRunning) {
+		return nil
+	}
+
 	for _, s := range schedules {
 		if s.Status != "running" {
 			continue
 		}
 
-		if !s.ExecutedAt.Valid {
+		// Rows executed "in the future" would give a negative running time
+		if !s.ExecutedAt.Valid || hasFutureExecution(s, a.clock.Now()) {
+			continue
+		}
+
+		// Orphaned runs are reported by checkOrphanedRunning instead
+		if a.isOrphaned(schedules, s, cfg) {
 			continue
 		}
 
-		runningTime := time.Since(s.ExecutedAt.Time)
+		runningTime := a.since(s.ExecutedAt.Time)
 		if runningTime > cfg.MaxRunningTime {
-			state.ConsecutiveStuck++
+			state.LongRunningChecks++
+			state.detected(s.Status)
 
 			// Only alert after threshold consecutive detections
-			if state.ConsecutiveStuck >= cfg.ThresholdChecks {
+			if state.LongRunningChecks >= cfg.ThresholdChecks {
 				return &logger.StuckCronAlert{
 					JobCode:          s.JobCode,
 					Status:           s.Status,
+					Detection:        config.DetectionLongRunning,
+					Severity:         a.config.AlertSeverity(config.DetectionLongRunning),
 					RunningTime:      &runningTime,
-					ScheduledAt:      &s.ScheduledAt,
+					ScheduledAt:      scheduledAtPtr(s),
 					ExecutedAt:       &s.ExecutedAt.Time,
 					Reason:           fmt.Sprintf("job running longer than max_running_time threshold (%s)", cfg.MaxRunningTime),
-					ConsecutiveStuck: state.ConsecutiveStuck,
+					ConsecutiveStuck: state.LongRunningChecks,
 				}
 			}
 			return nil
@@ -162,12 +312,80 @@ func (a *Analyzer) checkLongRunning(schedules []*database.CronSchedule, cfg conf
 	}
 
 	// Reset streak if not stuck
-	state.ConsecutiveStuck = 0
+	state.LongRunningChecks = 0
+	state.detected("")
+	return nil
+}
+
+// checkOrphanedRunning detects runs whose worker most likely crashed: still running past
+// orphan_running_time while a newer pending run of the same job is already queued behind them
+func (a *Analyzer) checkOrphanedRunning(schedules []*database.CronSchedule, cfg config.DetectionConfig, state *JobState) *logger.StuckCronAlert {
+	if cfg.OrphanRunningTime <= 0 {
+		return nil
+	}
+
+	for _, s := range schedules {
+		if s.Status != "running" || !s.ExecutedAt.Valid || hasFutureExecution(s, a.clock.Now()) {
+			continue
+		}
+
+		if !a.isOrphaned(schedules, s, cfg) {
+			continue
+		}
+		runningTime := a.since(s.ExecutedAt.Time)
+
+		state.OrphanedChecks++
+		state.detected(s.Status)
+
+		if state.OrphanedChecks >= cfg.ThresholdChecks {
+			return &logger.StuckCronAlert{
+				JobCode:          s.JobCode,
+				Status:           s.Status,
+				Detection:        config.DetectionOrphanedRunning,
+				Severity:         a.config.AlertSeverity(config.DetectionOrphanedRunning),
+				RunningTime:      &runningTime,
+				ScheduledAt:      scheduledAtPtr(s),
+				ExecutedAt:       &s.ExecutedAt.Time,
+				Reason:           fmt.Sprintf("orphaned run: running longer than orphan_running_time (%s) with a newer run pending; the worker likely crashed", cfg.OrphanRunningTime),
+				ConsecutiveStuck: state.OrphanedChecks,
+			}
+		}
+		return nil
+	}
+
+	state.OrphanedChecks = 0
+	state.detected("")
 	return nil
 }
 
+// isOrphaned reports whether a run counts as orphaned: running past orphan_running_time with a newer pending run queued
+func (a *Analyzer) isOrphaned(schedules []*database.CronSchedule, run *database.CronSchedule, cfg config.DetectionConfig) bool {
+	return cfg.OrphanRunningTime > 0 && a.since(run.ExecutedAt.Time) > cfg.OrphanRunningTime && hasNewerPending(schedules, run)
+}
+
+// hasNewerPending reports whether a pending schedule of the job was scheduled after the given run
+func hasNewerPending(schedules []*database.CronSchedule, run *database.CronSchedule) bool {
+	for _, s := range schedules {
+		if s.Status != "pending" {
+			continue
+		}
+		if s.ScheduledAt.Valid && run.ScheduledAt.Valid {
+			if s.ScheduledAt.Time.After(run.ScheduledAt.Time) {
+				return true
+			}
+		} else if s.CreatedAt.After(run.CreatedAt) {
+			return true
+		}
+	}
+	return false
+}
+
 // checkPendingAccumulation detects too many pending jobs
 func (a *Analyzer) checkPendingAccumulation(schedules []*database.CronSchedule, cfg config.DetectionConfig, state *JobState) *logger.StuckCronAlert {
+	if !config.Enabled(cfg.DetectPending) {
+		return nil
+	}
+
 	pendingCount := 0
 	for _, s := range schedules {
 		if s.Status == "pending" {
@@ -176,65 +394,95 @@ func (a *Analyzer) checkPendingAccumulation(schedules []*database.CronSchedule,
 	}
 
 	if pendingCount > cfg.MaxPendingCount {
-		state.ConsecutiveStuck++
-		state.LastStatus = "pending_accumulation"
+		state.PendingChecks++
+		state.detected("pending")
 
-		if state.ConsecutiveStuck >= cfg.ThresholdChecks {
+		if state.PendingChecks >= cfg.ThresholdChecks {
 			return &logger.StuckCronAlert{
 				JobCode:          state.JobCode,
 				Status:           "pending",
+				Detection:        config.DetectionPendingAccumulation,
+				Severity:         a.config.AlertSeverity(config.DetectionPendingAccumulation),
 				PendingCount:     pendingCount,
 				Reason:           fmt.Sprintf("too many pending jobs (%d exceeds threshold of %d)", pendingCount, cfg.MaxPendingCount),
-				ConsecutiveStuck: state.ConsecutiveStuck,
+				ConsecutiveStuck: state.PendingChecks,
 			}
 		}
 		return nil
 	}
 
 	// Reset if under threshold
-	if state.LastStatus == "pending_accumulation" {
-		state.ConsecutiveStuck = 0
-		state.LastStatus = ""
-	}
+	state.PendingChecks = 0
+	state.detected("")
 	return nil
 }
 
 // checkConsecutiveErrors detects jobs repeatedly failing
 func (a *Analyzer) checkConsecutiveErrors(schedules []*database.CronSchedule, cfg config.DetectionConfig, state *JobState) *logger.StuckCronAlert {
+	if !config.Enabled(cfg.DetectErrors) {
+		return nil
+	}
+
 	// Count consecutive errors from most recent schedules
 	errorCount := 0
 	var lastError *database.CronSchedule
+	var reason string
 
-	for i := 0; i < len(schedules) && i < cfg.ConsecutiveErrors*2; i++ {
-		s := schedules[i]
-		if s.Status == "error" {
-			errorCount++
-			if lastError == nil {
-				lastError = s
+	if cfg.ErrorCounting == "windowed" {
+		// Tally every error in the window, intervening successes don't reset the count
+		successCount := 0
+		for _, s := range schedules {
+			switch s.Status {
+			case "error":
+				errorCount++
+				if lastError == nil {
+					lastError = s
+				}
+			case "success":
+				successCount++
+			}
+		}
+		errorRatio := ratio(float64(errorCount), float64(errorCount+successCount))
+		if cfg.ErrorRatio > 0 && errorRatio < cfg.ErrorRatio {
+			// Below the failure rate threshold, don't count towards an alert
+			errorCount = 0
+		}
+		reason = fmt.Sprintf("errors in window (%d meets threshold of %d, %.0f%% of finished runs)", errorCount, cfg.ConsecutiveErrors, errorRatio*100)
+	} else {
+		for i := 0; i < len(schedules) && i < cfg.ConsecutiveErrors*2; i++ {
+			s := schedules[i]
+			if s.Status == "error" {
+				errorCount++
+				if lastError == nil {
+					lastError = s
+				}
+			} else if s.Status == "success" {
+				// Break streak if we hit a success
+				break
 			}
-		} else if s.Status == "success" {
-			// Break streak if we hit a success
-			break
 		}
+		reason = fmt.Sprintf("consecutive errors detected (%d meets threshold of %d)", errorCount, cfg.ConsecutiveErrors)
 	}
 
 	if errorCount >= cfg.ConsecutiveErrors {
 		state.ErrorStreak = errorCount
-		state.ConsecutiveStuck++
-		state.LastStatus = "consecutive_errors"
+		state.ErrorChecks++
+		state.detected("error")
 
-		if state.ConsecutiveStuck >= cfg.ThresholdChecks {
+		if state.ErrorChecks >= cfg.ThresholdChecks {
 			alert := &logger.StuckCronAlert{
 				JobCode:          state.JobCode,
 				Status:           "error",
+				Detection:        config.DetectionConsecutiveErrors,
+				Severity:         a.config.AlertSeverity(config.DetectionConsecutiveErrors),
 				ErrorCount:       errorCount,
-				Reason:           fmt.Sprintf("consecutive errors detected (%d meets threshold of %d)", errorCount, cfg.ConsecutiveErrors),
-				ConsecutiveStuck: state.ConsecutiveStuck,
+				Reason:           reason,
+				ConsecutiveStuck: state.ErrorChecks,
 			}
 
 			if lastError != nil && lastError.Messages.Valid {
 				alert.ErrorMessage = lastError.Messages.String
-				alert.ScheduledAt = &lastError.ScheduledAt
+				alert.ScheduledAt = scheduledAtPtr(lastError)
 			}
 
 			return alert
@@ -244,15 +492,59 @@ func (a *Analyzer) checkConsecutiveErrors(schedules []*database.CronSchedule, cf
 
 	// Reset if no error streak
 	state.ErrorStreak = 0
-	if state.LastStatus == "consecutive_errors" {
-		state.ConsecutiveStuck = 0
-		state.LastStatus = ""
+	state.ErrorChecks = 0
+	state.detected("")
+	return nil
+}
+
+// checkSuccessRate detects intermittently failing jobs whose successes keep breaking the error streak
+// The rate is the share of finished runs (success or error) in the lookback window that succeeded
+func (a *Analyzer) checkSuccessRate(schedules []*database.CronSchedule, cfg config.DetectionConfig, state *JobState) *logger.StuckCronAlert {
+	if cfg.MinSuccessRate <= 0 {
+		return nil
+	}
+
+	successCount, finishedCount := 0, 0
+	for _, s := range schedules {
+		switch s.Status {
+		case "success":
+			successCount++
+			finishedCount++
+		case "error":
+			finishedCount++
+		}
+	}
+
+	rate := ratio(float64(successCount), float64(finishedCount))
+	if finishedCount >= cfg.MinSamples && finishedCount > 0 && rate < cfg.MinSuccessRate {
+		state.SuccessRateChecks++
+		state.detected("error")
+
+		if state.SuccessRateChecks >= cfg.ThresholdChecks {
+			return &logger.StuckCronAlert{
+				JobCode:          state.JobCode,
+				Status:           "error",
+				Detection:        config.DetectionSuccessRate,
+				Severity:         a.config.AlertSeverity(config.DetectionSuccessRate),
+				ErrorCount:       finishedCount - successCount,
+				Reason:           fmt.Sprintf("success rate %.0f%% below min_success_rate of %.0f%% (%d of %d finished runs succeeded)", rate*100, cfg.MinSuccessRate*100, successCount, finishedCount),
+				ConsecutiveStuck: state.SuccessRateChecks,
+			}
+		}
+		return nil
 	}
+
+	state.SuccessRateChecks = 0
+	state.detected("")
 	return nil
 }
 
 // checkMissedExecutions detects jobs frequently being missed
 func (a *Analyzer) checkMissedExecutions(schedules []*database.CronSchedule, cfg config.DetectionConfig, state *JobState) *logger.StuckCronAlert {
+	if !config.Enabled(cfg.DetectMissed) {
+		return nil
+	}
+
 	missedCount := 0
 	for _, s := range schedules {
 		if s.Status == "missed" {
@@ -262,16 +554,18 @@ func (a *Analyzer) checkMissedExecutions(schedules []*database.CronSchedule, cfg
 
 	if missedCount >= cfg.MaxMissedCount {
 		state.MissedStreak = missedCount
-		state.ConsecutiveStuck++
-		state.LastStatus = "missed_executions"
+		state.MissedChecks++
+		state.detected("missed")
 
-		if state.ConsecutiveStuck >= cfg.ThresholdChecks {
+		if state.MissedChecks >= cfg.ThresholdChecks {
 			return &logger.StuckCronAlert{
 				JobCode:          state.JobCode,
 				Status:           "missed",
+				Detection:        config.DetectionMissedExecutions,
+				Severity:         a.config.AlertSeverity(config.DetectionMissedExecutions),
 				MissedCount:      missedCount,
 				Reason:           fmt.Sprintf("too many missed executions (%d exceeds threshold of %d)", missedCount, cfg.MaxMissedCount),
-				ConsecutiveStuck: state.ConsecutiveStuck,
+				ConsecutiveStuck: state.MissedChecks,
 			}
 		}
 		return nil
@@ -279,146 +573,1268 @@ func (a *Analyzer) checkMissedExecutions(schedules []*database.CronSchedule, cfg
 
 	// Reset if under threshold
 	state.MissedStreak = 0
-	if state.LastStatus == "missed_executions" {
-		state.ConsecutiveStuck = 0
-		state.LastStatus = ""
-	}
+	state.MissedChecks = 0
+	state.detected("")
 	return nil
 }
 
-
-// cleanupOldStates removes job states that haven't been checked recently
-func (a *Analyzer) cleanupOldStates() {
-	cutoff := time.Now().Add(-24 * time.Hour)
-	for jobCode, state := range a.jobStates {
-		if state.LastChecked.Before(cutoff) {
-			delete(a.jobStates, jobCode)
+// probe returns a copy of the state with the counters of conditions detected in this check taken back,
+// so re-evaluating the detections after Analyze doesn't count the check twice
+func (s *JobState) probe() *JobState {
+	probe := *s
+	for _, checks := range []*int{&probe.LongRunningChecks, &probe.OrphanedChecks, &probe.PendingChecks, &probe.ErrorChecks, &probe.SuccessRateChecks, &probe.MissedChecks, &probe.LatencyChecks} {
+		if *checks > 0 {
+			*checks--
 		}
 	}
+	return &probe
 }
 
-// GetJobStates returns current job states (for debugging)
-func (a *Analyzer) GetJobStates() map[string]*JobState {
-	a.mu.RLock()
-	defer a.mu.RUnlock()
-	
-	// Return a copy to avoid race conditions
-	states := make(map[string]*JobState)
-	for k, v := range a.jobStates {
-		stateCopy := *v
-		states[k] = &stateCopy
+// detected refreshes ConsecutiveStuck from the per-condition counters after one of them changed
+// status is the status of a condition that was just detected, or empty after a reset
+func (s *JobState) detected(status string) {
+	s.ConsecutiveStuck = max(s.LongRunningChecks, s.OrphanedChecks, s.PendingChecks, s.ErrorChecks, s.SuccessRateChecks, s.MissedChecks, s.LatencyChecks, s.AbsentChecks)
+	if status != "" {
+		s.LastStatus = status
+	} else if s.ConsecutiveStuck == 0 {
+		s.LastStatus = ""
 	}
-	return states
 }
 
-// CheckSchedulerHealth checks if the Magento cron scheduler is running
-func (a *Analyzer) CheckSchedulerHealth(dbClient *database.Client) *logger.StuckCronAlert {
-	a.mu.Lock()
-	defer a.mu.Unlock()
-	
-	cfg := a.config.Monitor.Detection
-	
-	// Use defaults if not configured
-	inactivityMinutes := cfg.SchedulerInactivityMinutes
-	if inactivityMinutes == 0 {
-		inactivityMinutes = 10 // Default: no new jobs in 10 minutes
+// checkSchedulingLatency detects a cron runner falling behind: the job still runs, but starts late
+// The lag is measured on the most recently started run, so the alert clears once runs start on time again
+func (a *Analyzer) checkSchedulingLatency(schedules []*database.CronSchedule, cfg config.DetectionConfig, state *JobState) *logger.StuckCronAlert {
+	if cfg.MaxScheduleLag <= 0 {
+		return nil
 	}
-	
-	lookaheadMinutes := cfg.SchedulerLookaheadMinutes
-	if lookaheadMinutes == 0 {
-		lookaheadMinutes = 15 // Default: no pending jobs scheduled for next 15 minutes
+
+	now := a.clock.Now()
+	var latest *database.CronSchedule
+	for _, s := range schedules {
+		if !s.ExecutedAt.Valid || !s.ScheduledAt.Valid || hasFutureExecution(s, now) {
+			continue
+		}
+		if latest == nil || s.ExecutedAt.Time.After(latest.ExecutedAt.Time) {
+			latest = s
+		}
 	}
-	
-	thresholdChecks := cfg.ThresholdChecks
-	if thresholdChecks == 0 {
-		thresholdChecks = 2
+
+	if latest != nil {
+		lag := latest.ExecutedAt.Time.Sub(latest.ScheduledAt.Time)
+		if lag > cfg.MaxScheduleLag {
+			state.LatencyChecks++
+			state.detected(latest.Status)
+
+			if state.LatencyChecks >= cfg.ThresholdChecks {
+				return &logger.StuckCronAlert{
+					JobCode:          state.JobCode,
+					Status:           latest.Status,
+					Detection:        config.DetectionSchedulingLatency,
+					Severity:         a.config.AlertSeverity(config.DetectionSchedulingLatency),
+					ScheduledAt:      scheduledAtPtr(latest),
+					ExecutedAt:       &latest.ExecutedAt.Time,
+					Reason:           fmt.Sprintf("runs start late: latest run started %s after scheduled_at (exceeds max_schedule_lag of %s)", lag.Round(time.Second), cfg.MaxScheduleLag),
+					ConsecutiveStuck: state.LatencyChecks,
+				}
+			}
+			return nil
+		}
 	}
-	
-	// Check 1: Any jobs created recently?
-	recentCount, err := dbClient.GetRecentlyCreatedJobCount(inactivityMinutes)
-	if err != nil {
-		// Don't alert on query errors
-		return nil
+
+	state.LatencyChecks = 0
+	state.detected("")
+	return nil
+}
+
+// updatePendingTrend records this check's pending count and updates the growth streak
+func (a *Analyzer) updatePendingTrend(schedules []*database.CronSchedule, cfg config.DetectionConfig, state *JobState) {
+	pendingCount := 0
+	for _, s := range schedules {
+		if s.Status == "pending" {
+			pendingCount++
+		}
 	}
-	
-	// Check 2: Any pending jobs scheduled for near future?
-	upcomingCount, err := dbClient.GetUpcomingPendingJobCount(lookaheadMinutes)
-	if err != nil {
-		// Don't alert on query errors
-		return nil
+
+	if n := len(state.PendingHistory); n > 0 && pendingCount > state.PendingHistory[n-1] {
+		state.PendingGrowthStreak++
+	} else {
+		state.PendingGrowthStreak = 0
 	}
-	
-	// Scheduler is healthy if either check passes
-	if recentCount > 0 || upcomingCount > 0 {
-		// Reset consecutive counter
-		a.schedulerState.ConsecutiveInactive = 0
-		return nil
+
+	// Keep enough history to show the full growth trend in the reason
+	state.PendingHistory = append(state.PendingHistory, pendingCount)
+	maxHistory := cfg.PendingGrowthChecks + 1
+	if maxHistory < 2 {
+		maxHistory = 2
 	}
-	
-	// Scheduler appears inactive
-	a.schedulerState.ConsecutiveInactive++
-	
-	// Only alert after threshold consecutive detections
-	if a.schedulerState.ConsecutiveInactive < thresholdChecks {
-		return nil
+	if len(state.PendingHistory) > maxHistory {
+		state.PendingHistory = state.PendingHistory[len(state.PendingHistory)-maxHistory:]
 	}
-	
-	// Suppress duplicate alerts within 5 minutes
-	if time.Since(a.schedulerState.LastAlertTime) < 5*time.Minute {
+}
+
+// checkPendingGrowth detects a pending backlog that keeps growing check-over-check
+func (a *Analyzer) checkPendingGrowth(cfg config.DetectionConfig, state *JobState) *logger.StuckCronAlert {
+	if cfg.PendingGrowthChecks <= 0 || state.PendingGrowthStreak < cfg.PendingGrowthChecks {
 		return nil
 	}
-	
-	a.schedulerState.LastAlertTime = time.Now()
-	
+
+	// Show the trend over the growth streak, e.g. 15→30→60
+	trend := state.PendingHistory
+	if len(trend) > state.PendingGrowthStreak+1 {
+		trend = trend[len(trend)-state.PendingGrowthStreak-1:]
+	}
+	steps := make([]string, len(trend))
+	for i, count := range trend {
+		steps[i] = strconv.Itoa(count)
+	}
+
 	return &logger.StuckCronAlert{
-		JobCode:          "SCHEDULER",
-		Status:           "inactive",
-		Reason:           fmt.Sprintf("no jobs created in last %d minutes and no pending jobs scheduled for next %d minutes", inactivityMinutes, lookaheadMinutes),
-		ConsecutiveStuck: a.schedulerState.ConsecutiveInactive,
+		JobCode:          state.JobCode,
+		Status:           "pending",
+		Detection:        config.DetectionPendingGrowth,
+		Severity:         a.config.AlertSeverity(config.DetectionPendingGrowth),
+		PendingCount:     trend[len(trend)-1],
+		Reason:           fmt.Sprintf("pending backlog growing for %d consecutive checks (%s)", state.PendingGrowthStreak, strings.Join(steps, "→")),
+		ConsecutiveStuck: state.PendingGrowthStreak,
 	}
 }
 
-// GetCronState returns the state for a specific cron job
-func (a *Analyzer) GetCronState(cronCode string) *JobState {
-	a.mu.RLock()
-	defer a.mu.RUnlock()
-	return a.jobStates[cronCode]
+// scoreSignals lists the health score signals in display order
+var scoreSignals = []string{"running_time", "pending", "errors", "missed"}
+
+// updateScore computes the job's weighted health score and updates the score streak
+// Each signal is normalized against its rule threshold so 1.0 means "at threshold"
+func (a *Analyzer) updateScore(schedules []*database.CronSchedule, cfg config.DetectionConfig, state *JobState) {
+	var longestRunning time.Duration
+	pendingCount, missedCount, errorCount := 0, 0, 0
+	errorStreakOpen := true
+
+	for _, s := range schedules {
+		switch s.Status {
+		case "running":
+			if s.ExecutedAt.Valid && !hasFutureExecution(s, a.clock.Now()) {
+				if runtime := a.since(s.ExecutedAt.Time); runtime > longestRunning {
+					longestRunning = runtime
+				}
+			}
+		case "pending":
+			pendingCount++
+		case "missed":
+			missedCount++
+		case "error":
+			// Count the most recent error streak, as the consecutive errors rule does
+			if errorStreakOpen {
+				errorCount++
+			}
+		case "success":
+			errorStreakOpen = false
+		}
+	}
+
+	signals := map[string]float64{
+		"running_time": ratio(longestRunning.Seconds(), cfg.MaxRunningTime.Seconds()),
+		"pending":      ratio(float64(pendingCount), float64(cfg.MaxPendingCount)),
+		"errors":       ratio(float64(errorCount), float64(cfg.ConsecutiveErrors)),
+		"missed":       ratio(float64(missedCount), float64(cfg.MaxMissedCount)),
+	}
+
+	// Disabled rules don't contribute to the score
+	for name, flag := range map[string]*bool{
+		"running_time": cfg.DetectLongRunning,
+		"pending":      cfg.DetectPending,
+		"errors":       cfg.DetectErrors,
+		"missed":       cfg.DetectMissed,
+	} {
+		if !config.Enabled(flag) {
+			signals[name] = 0
+		}
+	}
+	weights := map[string]*float64{
+		"running_time": cfg.Scoring.Weights.RunningTime,
+		"pending":      cfg.Scoring.Weights.Pending,
+		"errors":       cfg.Scoring.Weights.Errors,
+		"missed":       cfg.Scoring.Weights.Missed,
+	}
+
+	score := 0.0
+	for name, value := range signals {
+		if weight := weights[name]; weight != nil {
+			score += *weight * value
+		}
+	}
+
+	state.LastScore = score
+	state.ScoreSignal = signals
+	if score >= cfg.Scoring.Threshold {
+		state.ScoreStreak++
+	} else {
+		state.ScoreStreak = 0
+	}
 }
 
-// DetectStateTransitions detects state transitions for Slack notifications
-// This should be called after Analyze() to detect healthy/stuck transitions
-func (a *Analyzer) DetectStateTransitions(schedules []*database.CronSchedule) []StateTransition {
-	a.mu.Lock()
-	defer a.mu.Unlock()
+// checkScore alerts when the health score stayed at or above threshold for threshold_checks checks
+func (a *Analyzer) checkScore(cfg config.DetectionConfig, state *JobState) *logger.StuckCronAlert {
+	if state.ScoreStreak == 0 || state.ScoreStreak < cfg.ThresholdChecks {
+		return nil
+	}
 
-	transitions := make([]StateTransition, 0)
+	parts := make([]string, 0, len(scoreSignals))
+	for _, name := range scoreSignals {
+		parts = append(parts, fmt.Sprintf("%s=%.2f", name, state.ScoreSignal[name]))
+	}
 
-	// Group schedules by job_code
-	jobSchedules := make(map[string][]*database.CronSchedule)
-	for _, s := range schedules {
-		jobSchedules[s.JobCode] = append(jobSchedules[s.JobCode], s)
+	return &logger.StuckCronAlert{
+		JobCode:          state.JobCode,
+		Status:           "unhealthy",
+		Detection:        config.DetectionHealthScore,
+		Severity:         a.config.AlertSeverity(config.DetectionHealthScore),
+		Reason:           fmt.Sprintf("health score %.2f reached threshold of %.2f (%s)", state.LastScore, cfg.Scoring.Threshold, strings.Join(parts, ", ")),
+		ConsecutiveStuck: state.ScoreStreak,
+	}
+}
+
+// ratio returns value/limit, or 0 when no limit is configured
+func ratio(value, limit float64) float64 {
+	if limit <= 0 {
+		return 0
 	}
+	return value / limit
+}
 
-	// Check each job for state transitions
-	for jobCode, schedList := range jobSchedules {
-		state := a.jobStates[jobCode]
-		if state == nil {
+// minBaselineSamples is the minimum number of completed runs needed to trust the runtime baseline
+const minBaselineSamples = 5
+
+// checkShortCompletion detects the latest successful run finishing suspiciously fast
+func (a *Analyzer) checkShortCompletion(schedules []*database.CronSchedule, cfg config.DetectionConfig, state *JobState) *logger.StuckCronAlert {
+	if cfg.MinCompletionTime <= 0 && cfg.ShortRunStddev <= 0 {
+		return nil
+	}
+
+	// Collect completed run durations, most recent first (schedules are ordered by created_at DESC)
+	var latest *database.CronSchedule
+	var durations []float64
+	for _, s := range schedules {
+		if s.Status != "success" || !s.ExecutedAt.Valid || !s.FinishedAt.Valid || hasFutureExecution(s, a.clock.Now()) {
 			continue
 		}
+		if latest == nil {
+			latest = s
+			continue
+		}
+		durations = append(durations, s.FinishedAt.Time.Sub(s.ExecutedAt.Time).Seconds())
+	}
 
-		detectionCfg := a.config.GetDetectionConfig(jobCode)
+	if latest == nil || latest.ScheduleID == state.LastShortRunID {
+		return nil
+	}
 
-		// Determine if currently not alerting or alerting
-		isNotAlerting := a.isJobHealthy(schedList, detectionCfg, state)
+	runtime := latest.FinishedAt.Time.Sub(latest.ExecutedAt.Time)
+	var reason string
 
-		// Initialize state if empty
-		if state.LastKnownState == "" {
-			state.LastKnownState = "not_alerting"
+	if cfg.MinCompletionTime > 0 && runtime < cfg.MinCompletionTime {
+		reason = fmt.Sprintf("job succeeded suspiciously fast (%s, below min_completion_time of %s)", runtime, cfg.MinCompletionTime)
+	} else if cfg.ShortRunStddev > 0 && len(durations) >= minBaselineSamples {
+		mean, stddev := meanStddev(durations)
+		floor := mean - cfg.ShortRunStddev*stddev
+		if runtime.Seconds() < floor {
+			reason = fmt.Sprintf("job succeeded suspiciously fast (%s, baseline mean %s ± %s over %d runs)",
+				runtime, secondsToDuration(mean), secondsToDuration(stddev), len(durations))
 		}
+	}
 
-		// Detect not_alerting → alerting transition
+	if reason == "" {
+		return nil
+	}
+
+	state.LastShortRunID = latest.ScheduleID
+	return &logger.StuckCronAlert{
+		JobCode:     state.JobCode,
+		Status:      latest.Status,
+		Detection:   config.DetectionShortCompletion,
+		Severity:    a.config.AlertSeverity(config.DetectionShortCompletion),
+		RunningTime: &runtime,
+		ScheduledAt: scheduledAtPtr(latest),
+		ExecutedAt:  &latest.ExecutedAt.Time,
+		Reason:      reason,
+	}
+}
+
+// meanStddev returns the mean and population standard deviation of the values
+func meanStddev(values []float64) (float64, float64) {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	variance /= float64(len(values))
+
+	return mean, math.Sqrt(variance)
+}
+
+// secondsToDuration converts seconds to a duration rounded to the second
+func secondsToDuration(seconds float64) time.Duration {
+	return (time.Duration(seconds * float64(time.Second))).Round(time.Second)
+}
+
+// hasFutureExecution reports whether a schedule's executed_at lies in the future (clock skew or bad data)
+func hasFutureExecution(s *database.CronSchedule, now time.Time) bool {
+	return s.ExecutedAt.Valid && s.ExecutedAt.Time.After(now)
+}
+
+// scheduledAtPtr returns a schedule's scheduled_at, or nil when it is NULL
+func scheduledAtPtr(s *database.CronSchedule) *time.Time {
+	if !s.ScheduledAt.Valid {
+		return nil
+	}
+	return &s.ScheduledAt.Time
+}
+
+// CountNullScheduledAt returns the number of schedules with a NULL scheduled_at
+func CountNullScheduledAt(schedules []*database.CronSchedule) int {
+	count := 0
+	for _, s := range schedules {
+		if !s.ScheduledAt.Valid {
+			count++
+		}
+	}
+	return count
+}
+
+// CountSuspiciousRows returns the number of schedules with executed_at in the future
+func (a *Analyzer) CountSuspiciousRows(schedules []*database.CronSchedule) int {
+	return countSuspiciousRows(schedules, a.clock.Now())
+}
+
+// countSuspiciousRows returns the number of schedules with executed_at after now
+func countSuspiciousRows(schedules []*database.CronSchedule, now time.Time) int {
+	count := 0
+	for _, s := range schedules {
+		if hasFutureExecution(s, now) {
+			count++
+		}
+	}
+	return count
+}
+
+// TimingInconsistencies groups the schedule_ids of rows whose timing fields contradict their status
+// Successful rows need executed_at and finished_at, running rows only executed_at, pending rows neither
+// Error rows are not checked: Magento records failures without finished_at, and without executed_at
+// when the job fails before starting (e.g. a missing callback)
+func TimingInconsistencies(schedules []*database.CronSchedule) map[string][]int {
+	found := make(map[string][]int)
+	add := func(kind string, s *database.CronSchedule) {
+		found[kind] = append(found[kind], s.ScheduleID)
+	}
+
+	for _, s := range schedules {
+		switch s.Status {
+		case "success":
+			if !s.ExecutedAt.Valid {
+				add("success without executed_at", s)
+			}
+			if !s.FinishedAt.Valid {
+				add("success without finished_at", s)
+			}
+		case "running":
+			if !s.ExecutedAt.Valid {
+				add("running without executed_at", s)
+			}
+			if s.FinishedAt.Valid {
+				add("running with finished_at", s)
+			}
+		case "pending":
+			if s.ExecutedAt.Valid {
+				add("pending with executed_at", s)
+			}
+			if s.FinishedAt.Valid {
+				add("pending with finished_at", s)
+			}
+		}
+	}
+	return found
+}
+
+// CountInconsistentRows returns the number of schedules with at least one timing inconsistency
+func CountInconsistentRows(inconsistencies map[string][]int) int {
+	rows := make(map[int]bool)
+	for _, ids := range inconsistencies {
+		for _, id := range ids {
+			rows[id] = true
+		}
+	}
+	return len(rows)
+}
+
+// cleanupOldStates removes job states that haven't been checked recently
+func (a *Analyzer) cleanupOldStates() {
+	cutoff := a.clock.Now().Add(-24 * time.Hour)
+	for jobCode, state := range a.jobStates {
+		if state.LastChecked.Before(cutoff) {
+			delete(a.jobStates, jobCode)
+		}
+	}
+}
+
+// GetJobStates returns current job states (for debugging)
+func (a *Analyzer) GetJobStates() map[string]*JobState {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	
+	// Return a copy to avoid race conditions
+	states := make(map[string]*JobState)
+	for k, v := range a.jobStates {
+		states[k] = v.clone()
+	}
+	return states
+}
+
+// clone returns a deep copy of the state, which callers can read while checks keep updating the original
+func (s *JobState) clone() *JobState {
+	clone := *s
+	clone.PendingHistory = append([]int(nil), s.PendingHistory...)
+	if s.ScoreSignal != nil {
+		clone.ScoreSignal = make(map[string]float64, len(s.ScoreSignal))
+		for k, v := range s.ScoreSignal {
+			clone.ScoreSignal[k] = v
+		}
+	}
+	if s.LastNotified != nil {
+		clone.LastNotified = make(map[string]time.Time, len(s.LastNotified))
+		for k, v := range s.LastNotified {
+			clone.LastNotified[k] = v
+		}
+	}
+	return &clone
+}
+
+// MarkNotified records a notification of a job under a notifier's cooldown key
+// It holds the analyzer lock, so GetJobStates never copies LastNotified in the middle of a write
+func (a *Analyzer) MarkNotified(state *JobState, key string, at time.Time) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if state.LastNotified == nil {
+		state.LastNotified = make(map[string]time.Time)
+	}
+	state.LastNotified[key] = at
+}
+
+// GetSchedulerState returns a copy of the scheduler state
+func (a *Analyzer) GetSchedulerState() SchedulerState {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return *a.schedulerState
+}
+
+// persistedState is the serialized form of the analyzer's state across restarts
+type persistedState struct {
+	JobStates      map[string]*JobState `json:"job_states"`
+	SchedulerState *SchedulerState      `json:"scheduler_state"`
+}
+
+// MarshalState serializes the job and scheduler states for persistence
+func (a *Analyzer) MarshalState() ([]byte, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	return json.Marshal(persistedState{
+		JobStates:      a.jobStates,
+		SchedulerState: a.schedulerState,
+	})
+}
+
+// RestoreState replaces the job and scheduler states with previously persisted ones
+func (a *Analyzer) RestoreState(data []byte) error {
+	var restored persistedState
+	if err := json.Unmarshal(data, &restored); err != nil {
+		return fmt.Errorf("failed to decode state: %w", err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if restored.JobStates != nil {
+		a.jobStates = restored.JobStates
+	}
+	if restored.SchedulerState != nil {
+		a.schedulerState = restored.SchedulerState
+	}
+	return nil
+}
+
+// CheckSchedulerHealth checks if the Magento cron scheduler is running
+func (a *Analyzer) CheckSchedulerHealth(ctx context.Context, dbClient *database.Client) *logger.StuckCronAlert {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	
+	a.schedulerRecovery = nil
+	cfg := a.config.Monitor.Detection
+	if !config.Enabled(cfg.DetectScheduler) {
+		return nil
+	}
+	
+	// Use defaults if not configured (time windows may override the inactivity threshold)
+	inactivityMinutes := a.config.GetSchedulerInactivityMinutes(a.clock.Now())
+	if inactivityMinutes == 0 {
+		inactivityMinutes = 10 // Default: no new jobs in 10 minutes
+	}
+	
+	// Warmup: right after startup "no recent rows" can't be trusted yet
+	warmup := time.Duration(inactivityMinutes) * time.Minute
+	if cfg.SchedulerWarmup != nil {
+		warmup = *cfg.SchedulerWarmup
+	}
+	if a.since(a.startedAt) < warmup {
+		return nil
+	}
+	
+	lookaheadMinutes := cfg.SchedulerLookaheadMinutes
+	if lookaheadMinutes == 0 {
+		lookaheadMinutes = 15 // Default: no pending jobs scheduled for next 15 minutes
+	}
+	
+	thresholdChecks := cfg.ThresholdChecks
+	if thresholdChecks == 0 {
+		thresholdChecks = 2
+	}
+	
+	// Jobs created recently and pending jobs scheduled for the near future, fetched in one round trip
+	activity, err := dbClient.GetSchedulerActivity(ctx, inactivityMinutes, lookaheadMinutes)
+	if err != nil {
+		// Don't alert on query errors
+		return nil
+	}
+	recentCount, upcomingCount := activity.Created, activity.Upcoming
+	
+	// "any": healthy if either check passes; "all": both checks must pass
+	var healthy bool
+	var reason string
+	if cfg.SchedulerHealthMode == "all" {
+		healthy = recentCount > 0 && upcomingCount > 0
+		reason = fmt.Sprintf("no jobs created in last %d minutes or no pending jobs scheduled for next %d minutes (created: %d, upcoming: %d)", inactivityMinutes, lookaheadMinutes, recentCount, upcomingCount)
+	} else {
+		healthy = recentCount > 0 || upcomingCount > 0
+		reason = fmt.Sprintf("no jobs created in last %d minutes and no pending jobs scheduled for next %d minutes", inactivityMinutes, lookaheadMinutes)
+	}
+
+	// A single job that keeps scheduling itself must not mask a scheduler that stopped for everything else
+	if healthy && cfg.SchedulerMinDistinctUpcoming > 0 && activity.DistinctUpcoming < cfg.SchedulerMinDistinctUpcoming {
+		healthy = false
+		reason = fmt.Sprintf("only %d distinct jobs pending for next %d minutes (minimum %d); the scheduler may have stopped for most jobs", activity.DistinctUpcoming, lookaheadMinutes, cfg.SchedulerMinDistinctUpcoming)
+	}
+
+	if healthy {
+		// An outage that was alerted on is over
+		if a.schedulerState.Alerting {
+			now := a.clock.Now()
+			a.schedulerRecovery = &StateTransition{
+				CronCode:      "SCHEDULER",
+				FromState:     "alerting",
+				ToState:       "not_alerting",
+				Timestamp:     now,
+				StuckDuration: now.Sub(a.schedulerState.InactiveSince),
+				Status:        "active",
+			}
+		}
+
+		// Reset consecutive counter
+		a.schedulerState.Alerting = false
+		a.schedulerState.ConsecutiveInactive = 0
+		a.schedulerState.InactiveSince = time.Time{}
+		a.schedulerState.EscalationLevel = 0
+		return nil
+	}
+	
+	// Scheduler appears inactive
+	a.schedulerState.ConsecutiveInactive++
+	if a.schedulerState.InactiveSince.IsZero() {
+		a.schedulerState.InactiveSince = a.clock.Now()
+	}
+	
+	// Only alert after threshold consecutive detections
+	if a.schedulerState.ConsecutiveInactive < thresholdChecks {
+		return nil
+	}
+
+	// Cron is expected to be stopped during maintenance
+	if a.inMaintenance() {
+		return nil
+	}
+	
+	// Repeat the alert at most every scheduler_alert_cooldown
+	if a.since(a.schedulerState.LastAlertTime) < cfg.SchedulerAlertCooldown {
+		return nil
+	}
+	
+	a.schedulerState.LastAlertTime = a.clock.Now()
+	a.schedulerState.Alerting = true
+	
+	return &logger.StuckCronAlert{
+		JobCode:          "SCHEDULER",
+		Status:           "inactive",
+		Detection:        config.DetectionScheduler,
+		Severity:         a.config.AlertSeverity(config.DetectionScheduler),
+		Reason:           reason,
+		ConsecutiveStuck: a.schedulerState.ConsecutiveInactive,
+	}
+}
+
+// SchedulerRecovery returns the recovery of an alerted scheduler outage found by the last CheckSchedulerHealth
+// It is nil unless that check saw the scheduler active again
+func (a *Analyzer) SchedulerRecovery() *StateTransition {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.schedulerRecovery
+}
+
+// inMaintenance reports whether a maintenance window is active; the caller holds a.mu
+func (a *Analyzer) inMaintenance() bool {
+	_, ok := a.config.ActiveMaintenanceWindow(a.clock.Now())
+	return ok
+}
+
+// SchedulerEscalation returns how long the scheduler has been inactive past the alert threshold
+// and the number of escalation steps already fired; ok is false while it is not alerting or during maintenance
+func (a *Analyzer) SchedulerEscalation(now time.Time) (inactiveFor time.Duration, level int, ok bool) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	thresholdChecks := a.config.Monitor.Detection.ThresholdChecks
+	if thresholdChecks <= 0 {
+		thresholdChecks = 2
+	}
+	if a.schedulerState.InactiveSince.IsZero() || a.schedulerState.ConsecutiveInactive < thresholdChecks || a.inMaintenance() {
+		return 0, 0, false
+	}
+	return now.Sub(a.schedulerState.InactiveSince), a.schedulerState.EscalationLevel, true
+}
+
+// SetSchedulerEscalationLevel records the scheduler escalation steps fired for the current outage
+func (a *Analyzer) SetSchedulerEscalationLevel(level int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.schedulerState.EscalationLevel = level
+}
+
+// CheckSuspiciousRows detects a high number of schedules with executed_at in the future
+// Such rows are always ignored for running-time math; many of them point to a systemic clock issue
+func (a *Analyzer) CheckSuspiciousRows(schedules []*database.CronSchedule) *logger.StuckCronAlert {
+	cfg := a.config.Monitor.Detection
+	if cfg.MaxSuspiciousRows <= 0 {
+		return nil
+	}
+
+	count := countSuspiciousRows(schedules, a.clock.Now())
+	if count < cfg.MaxSuspiciousRows {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Suppress duplicate alerts within the suppression window
+	if a.since(a.schedulerState.LastSuspiciousAlertTime) < cfg.AlertSuppressionWindow {
+		return nil
+	}
+	a.schedulerState.LastSuspiciousAlertTime = a.clock.Now()
+
+	return &logger.StuckCronAlert{
+		JobCode:   "CRON_SCHEDULE",
+		Status:    "future_executed_at",
+		Detection: config.DetectionDataQuality,
+		Severity:  a.config.AlertSeverity(config.DetectionDataQuality),
+		Reason:    fmt.Sprintf("%d schedules have executed_at in the future (threshold %d); check clock synchronization between the database and Magento hosts", count, cfg.MaxSuspiciousRows),
+	}
+}
+
+// minScheduleIDJump is the smallest unexplained schedule_id advance worth alerting on,
+// so ordinary cleanup of a few rows between checks doesn't trip the ratio
+const minScheduleIDJump = 1000
+
+// CheckScheduleIDSequence detects resets and huge jumps of the schedule_id auto-increment sequence
+// A drop of the highest id points to a truncated table; a jump far beyond the rows that appeared to mass deletions
+func (a *Analyzer) CheckScheduleIDSequence(schedules []*database.CronSchedule) *logger.StuckCronAlert {
+	ratio := a.config.Monitor.Detection.ScheduleIDJumpRatio
+	if ratio <= 0 || len(schedules) == 0 {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	previousMax := a.schedulerState.MaxScheduleID
+	previousSeen := a.schedulerState.MaxScheduleIDSeen
+	currentMax, newRows := 0, 0
+	for _, s := range schedules {
+		if s.ScheduleID > currentMax {
+			currentMax = s.ScheduleID
+		}
+		if s.ScheduleID > previousMax {
+			newRows++
+		}
+	}
+	a.schedulerState.MaxScheduleID = currentMax
+	a.schedulerState.MaxScheduleIDSeen = a.clock.Now()
+
+	// Nothing to compare against on the first check
+	if previousMax == 0 {
+		return nil
+	}
+
+	if currentMax < previousMax {
+		return &logger.StuckCronAlert{
+			JobCode:   "CRON_SCHEDULE",
+			Status:    "schedule_id_reset",
+			Detection: config.DetectionDataQuality,
+			Severity:  a.config.AlertSeverity(config.DetectionDataQuality),
+			Reason:    fmt.Sprintf("highest schedule_id dropped from %d to %d; cron_schedule may have been truncated or restored", previousMax, currentMax),
+		}
+	}
+
+	// After a pause longer than the lookback window (e.g. a restored state) the new rows can't all be seen
+	if a.since(previousSeen) > a.config.Monitor.Detection.LookbackWindow {
+		return nil
+	}
+
+	jump := currentMax - previousMax
+	if jump-newRows < minScheduleIDJump || float64(jump) <= ratio*float64(newRows) {
+		return nil
+	}
+
+	return &logger.StuckCronAlert{
+		JobCode:   "CRON_SCHEDULE",
+		Status:    "schedule_id_jump",
+		Detection: config.DetectionDataQuality,
+		Severity:  a.config.AlertSeverity(config.DetectionDataQuality),
+		Reason: fmt.Sprintf("schedule_id advanced by %d (from %d to %d) while only %d new rows are present (threshold %.1fx); rows may have been mass-deleted",
+			jump, previousMax, currentMax, newRows, ratio),
+	}
+}
+
+// CheckTimingConsistency raises a data-quality alert when many rows have timing fields that contradict their status
+// This usually points to a Magento or third-party module bug rather than a stuck job
+func (a *Analyzer) CheckTimingConsistency(schedules []*database.CronSchedule) *logger.StuckCronAlert {
+	threshold := a.config.Monitor.Detection.MaxInconsistentRows
+	if threshold <= 0 {
+		return nil
+	}
+
+	inconsistencies := TimingInconsistencies(schedules)
+	count := CountInconsistentRows(inconsistencies)
+	if count < threshold {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Suppress duplicate alerts within the suppression window
+	if a.since(a.schedulerState.LastInconsistentAlertTime) < a.config.Monitor.Detection.AlertSuppressionWindow {
+		return nil
+	}
+	a.schedulerState.LastInconsistentAlertTime = a.clock.Now()
+
+	kinds := make([]string, 0, len(inconsistencies))
+	for kind := range inconsistencies {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	summary := make([]string, len(kinds))
+	for i, kind := range kinds {
+		summary[i] = fmt.Sprintf("%s: %d", kind, len(inconsistencies[kind]))
+	}
+
+	return &logger.StuckCronAlert{
+		JobCode:   "CRON_SCHEDULE",
+		Status:    "inconsistent_timing",
+		Detection: config.DetectionDataQuality,
+		Severity:  a.config.AlertSeverity(config.DetectionDataQuality),
+		Reason:    fmt.Sprintf("%d schedules have timing fields inconsistent with their status (threshold %d; %s); a module may be writing cron_schedule incorrectly", count, threshold, strings.Join(summary, ", ")),
+	}
+}
+
+// CheckNullScheduledAt detects cron_schedule rows without a scheduled_at
+// Magento always sets scheduled_at, so such rows point to a broken producer writing to the table
+func (a *Analyzer) CheckNullScheduledAt(schedules []*database.CronSchedule) *logger.StuckCronAlert {
+	count := CountNullScheduledAt(schedules)
+	if count == 0 {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Suppress duplicate alerts within the suppression window
+	if a.since(a.schedulerState.LastNullScheduledAlertTime) < a.config.Monitor.Detection.AlertSuppressionWindow {
+		return nil
+	}
+	a.schedulerState.LastNullScheduledAlertTime = a.clock.Now()
+
+	return &logger.StuckCronAlert{
+		JobCode:   "CRON_SCHEDULE",
+		Status:    "null_scheduled_at",
+		Detection: config.DetectionDataQuality,
+		Severity:  a.config.AlertSeverity(config.DetectionDataQuality),
+		Reason:    fmt.Sprintf("%d schedules have a NULL scheduled_at; something other than the Magento scheduler may be writing malformed rows", count),
+	}
+}
+
+// CheckExpectedJobs detects expected job codes that are absent from cron_schedule
+// A job with no rows at all is reported as never_scheduled (a misconfiguration), a job whose rows
+// stopped appearing within its interval as absent (it silently vanished from the schedule)
+// Jobs that reach threshold_checks are marked missing, so DetectStateTransitions notifies about them
+func (a *Analyzer) CheckExpectedJobs(ctx context.Context, schedules []*database.CronSchedule, dbClient *database.Client) []*logger.StuckCronAlert {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Jobs no longer expected, monitored or missing stop being marked missing
+	missing := make(map[string]bool)
+	defer func() {
+		for jobCode, state := range a.jobStates {
+			if !missing[jobCode] && (state.MissingStatus == "absent" || state.MissingStatus == "never_scheduled") {
+				state.MissingStatus, state.MissingReason = "", ""
+				// The status is kept, so DetectStateTransitions still visits and recovers a job without rows
+				state.AbsentChecks = 0
+				state.detected(state.LastStatus)
+			}
+		}
+	}()
+
+	if len(a.config.Monitor.ExpectedJobs) == 0 {
+		return nil
+	}
+
+	newest := make(map[string]time.Time)
+	for _, s := range schedules {
+		if s.CreatedAt.After(newest[s.JobCode]) {
+			newest[s.JobCode] = s.CreatedAt
+		}
+	}
+
+	var alerts []*logger.StuckCronAlert
+	for _, expected := range a.config.Monitor.ExpectedJobs {
+		if !a.config.IsMonitoredJob(expected.JobCode) {
+			continue
+		}
+
+		interval := expected.Interval
+		if interval == 0 {
+			interval = a.config.Monitor.Detection.LookbackWindow
+		}
+
+		// With an interval, rows in the window but none that recent mean the job has stopped being scheduled
+		last, seen := newest[expected.JobCode]
+		if seen && (expected.Interval == 0 || a.since(last) <= interval) {
+			if state := a.jobStates[expected.JobCode]; state != nil && state.AbsentChecks > 0 {
+				state.AbsentChecks = 0
+				state.detected("")
+			}
+			continue
+		}
+
+		total := 1
+		if !seen {
+			var err error
+			total, err = dbClient.GetJobScheduleCount(ctx, expected.JobCode)
+			if err != nil {
+				// Don't alert on query errors, nor recover a job that was missing
+				missing[expected.JobCode] = true
+				continue
+			}
+		}
+
+		state, exists := a.jobStates[expected.JobCode]
+		if !exists {
+			state = &JobState{
+				JobCode: expected.JobCode,
+			}
+			a.jobStates[expected.JobCode] = state
+		}
+		state.LastChecked = a.clock.Now()
+		state.CronGroup = a.config.JobGroup(expected.JobCode)
+
+		status := "absent"
+		reason := fmt.Sprintf("expected job has no schedules created in the last %s; it may have silently stopped being scheduled", interval)
+		if total == 0 {
+			status = "never_scheduled"
+			reason = "expected job has never been scheduled (no rows in cron_schedule); check its crontab.xml / cron group configuration"
+		}
+
+		state.AbsentChecks++
+		state.detected(status)
+		detectionCfg := a.config.GetDetectionConfig(expected.JobCode)
+		if state.AbsentChecks < detectionCfg.ThresholdChecks {
+			continue
+		}
+		state.MissingStatus, state.MissingReason = status, reason
+		missing[expected.JobCode] = true
+
+		// Suppress duplicate alerts within the suppression window
+		if a.since(state.LastAlertTime) < detectionCfg.AlertSuppressionWindow {
+			continue
+		}
+		state.LastAlertTime = a.clock.Now()
+
+		alerts = append(alerts, &logger.StuckCronAlert{
+			JobCode:          expected.JobCode,
+			Status:           status,
+			Detection:        config.DetectionMissingJob,
+			Severity:         a.config.AlertSeverity(config.DetectionMissingJob),
+			Reason:           reason,
+			ConsecutiveStuck: state.AbsentChecks,
+		})
+	}
+
+	return alerts
+}
+
+// checkMissing reports a job marked missing by the last main check
+// It only reads the state: the detections over all rows count the checks and apply the thresholds
+func (a *Analyzer) checkMissing(state *JobState) *logger.StuckCronAlert {
+	if state.MissingStatus == "" {
+		return nil
+	}
+	detection := config.DetectionMissingJob
+	if state.MissingStatus == "not_scheduled" {
+		detection = config.DetectionDropout
+	}
+	return &logger.StuckCronAlert{
+		JobCode:   state.JobCode,
+		Status:    state.MissingStatus,
+		Detection: detection,
+		Severity:  a.config.AlertSeverity(detection),
+		Reason:    state.MissingReason,
+	}
+}
+
+// isMissingStatus reports whether a job status is set by the missing job or dropout detections
+func isMissingStatus(status string) bool {
+	return status == "absent" || status == "never_scheduled" || status == "not_scheduled"
+}
+
+// clearDropout ends a dropout marked by CheckJobDropouts, keeping LastStatus so a job without rows can recover
+func (s *JobState) clearDropout() {
+	if s.MissingStatus == "not_scheduled" {
+		s.MissingStatus, s.MissingReason = "", ""
+	}
+}
+
+// CheckEmptyResult detects a lookback query that unexpectedly returned no schedules
+// On a busy store this usually means the monitor points at the wrong or an empty database
+func (a *Analyzer) CheckEmptyResult(schedules []*database.CronSchedule) *logger.StuckCronAlert {
+	cfg := a.config.Monitor.Detection
+	if !cfg.AlertOnEmptyResult {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if len(schedules) > 0 {
+		a.schedulerState.ConsecutiveEmpty = 0
+		return nil
+	}
+
+	a.schedulerState.ConsecutiveEmpty++
+
+	// Only alert after threshold consecutive detections
+	if a.schedulerState.ConsecutiveEmpty < cfg.ThresholdChecks {
+		return nil
+	}
+
+	// Suppress duplicate alerts within the suppression window
+	if a.since(a.schedulerState.LastEmptyAlertTime) < cfg.AlertSuppressionWindow {
+		return nil
+	}
+	a.schedulerState.LastEmptyAlertTime = a.clock.Now()
+
+	return &logger.StuckCronAlert{
+		JobCode:          "CRON_SCHEDULE",
+		Status:           "empty",
+		Detection:        config.DetectionEmptySchedule,
+		Severity:         a.config.AlertSeverity(config.DetectionEmptySchedule),
+		Reason:           fmt.Sprintf("cron_schedule query returned no rows in the last %s; check the monitor is connected to the right Magento database", cfg.LookbackWindow),
+		ConsecutiveStuck: a.schedulerState.ConsecutiveEmpty,
+	}
+}
+
+// minCadenceSamples is the minimum number of creation gaps needed to learn a job's cadence
+const minCadenceSamples = 3
+
+// CheckJobDropouts detects individual jobs whose schedules stopped being created while the scheduler is healthy
+// Each job's normal creation interval is learned from the spacing of created_at values
+// Dropped out jobs are marked missing, so DetectStateTransitions notifies about them
+func (a *Analyzer) CheckJobDropouts(schedules []*database.CronSchedule) []*logger.StuckCronAlert {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	multiplier := a.config.Monitor.Detection.DropoutMultiplier
+	if multiplier <= 0 {
+		for _, state := range a.jobStates {
+			state.clearDropout()
+		}
+		return nil
+	}
+
+	// Learn the creation cadence of every job present in this check
+	createdTimes := make(map[string][]time.Time)
+	for _, s := range schedules {
+		createdTimes[s.JobCode] = append(createdTimes[s.JobCode], s.CreatedAt)
+	}
+	for jobCode, times := range createdTimes {
+		state := a.jobStates[jobCode]
+		if state == nil {
+			continue
+		}
+		for _, t := range times {
+			if t.After(state.LastCreatedAt) {
+				state.LastCreatedAt = t
+			}
+		}
+		if interval, ok := medianGap(times); ok {
+			state.CreationInterval = interval
+		}
+	}
+
+	// A stopped scheduler affects every job and is reported by CheckSchedulerHealth
+	if a.schedulerState.ConsecutiveInactive > 0 {
+		return nil
+	}
+
+	var alerts []*logger.StuckCronAlert
+	now := a.clock.Now()
+	for jobCode, state := range a.jobStates {
+		if state.CreationInterval == 0 || state.LastCreatedAt.IsZero() {
+			state.clearDropout()
+			continue
+		}
+		// States of jobs ignored since they were created, or restored from a snapshot, are kept but not alerted on
+		if !a.config.IsMonitoredJob(jobCode) {
+			state.clearDropout()
+			continue
+		}
+
+		gap := now.Sub(state.LastCreatedAt)
+		if gap <= time.Duration(multiplier*float64(state.CreationInterval)) {
+			state.clearDropout()
+			continue
+		}
+		reason := fmt.Sprintf("no new schedules created for %s (usually every %s, threshold %.1fx) while the scheduler is running",
+			gap.Round(time.Second), state.CreationInterval, multiplier)
+		state.MissingStatus, state.MissingReason = "not_scheduled", reason
+		state.LastStatus = "not_scheduled"
+
+		// Suppress duplicate alerts within the suppression window
+		if a.since(state.LastAlertTime) < a.config.GetDetectionConfig(jobCode).AlertSuppressionWindow {
+			continue
+		}
+		state.LastAlertTime = now
+
+		alerts = append(alerts, &logger.StuckCronAlert{
+			JobCode:   jobCode,
+			Status:    "not_scheduled",
+			Detection: config.DetectionDropout,
+			Severity:  a.config.AlertSeverity(config.DetectionDropout),
+			Reason:    reason,
+		})
+	}
+
+	return alerts
+}
+
+// medianGap returns the median gap between distinct timestamps
+func medianGap(times []time.Time) (time.Duration, bool) {
+	unique := make(map[int64]time.Time)
+	for _, t := range times {
+		unique[t.Unix()] = t
+	}
+	sorted := make([]time.Time, 0, len(unique))
+	for _, t := range unique {
+		sorted = append(sorted, t)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+
+	if len(sorted) < minCadenceSamples+1 {
+		return 0, false
+	}
+
+	gaps := make([]time.Duration, 0, len(sorted)-1)
+	for i := 1; i < len(sorted); i++ {
+		gaps = append(gaps, sorted[i].Sub(sorted[i-1]))
+	}
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i] < gaps[j] })
+
+	return gaps[len(gaps)/2], true
+}
+
+// CheckCadence detects jobs that run, but not at the cadence they are scheduled at
+// The expected interval is learned from scheduled_at spacing and compared to the largest executed_at gap
+func (a *Analyzer) CheckCadence(schedules []*database.CronSchedule) []*logger.StuckCronAlert {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.clock.Now()
+	scheduledTimes := make(map[string][]time.Time)
+	executedTimes := make(map[string][]time.Time)
+	for _, s := range schedules {
+		if s.ScheduledAt.Valid {
+			scheduledTimes[s.JobCode] = append(scheduledTimes[s.JobCode], s.ScheduledAt.Time)
+		}
+		if s.ExecutedAt.Valid && !hasFutureExecution(s, now) {
+			executedTimes[s.JobCode] = append(executedTimes[s.JobCode], s.ExecutedAt.Time)
+		}
+	}
+
+	// A stopped scheduler affects every job and is reported by CheckSchedulerHealth
+	schedulerInactive := a.schedulerState.ConsecutiveInactive > 0
+
+	var alerts []*logger.StuckCronAlert
+	for jobCode, executed := range executedTimes {
+		state := a.jobStates[jobCode]
+		if state == nil || !a.config.IsMonitoredJob(jobCode) {
+			continue
+		}
+
+		// Keep the learned interval when too few schedules are in the window
+		if expected, ok := medianGap(scheduledTimes[jobCode]); ok {
+			state.ExpectedCadence = expected
+		}
+		observed, ok := medianGap(executed)
+		if !ok {
+			continue
+		}
+		state.ObservedCadence = observed
+
+		tolerance := a.config.GetDetectionConfig(jobCode).CadenceTolerance
+		if tolerance <= 0 || state.ExpectedCadence == 0 || schedulerInactive {
+			continue
+		}
+
+		largest := largestGap(executed)
+		if largest <= time.Duration(tolerance*float64(state.ExpectedCadence)) {
+			continue
+		}
+
+		// Suppress duplicate alerts within the suppression window
+		if a.since(state.LastAlertTime) < a.config.GetDetectionConfig(jobCode).AlertSuppressionWindow {
+			continue
+		}
+		state.LastAlertTime = now
+
+		alerts = append(alerts, &logger.StuckCronAlert{
+			JobCode:   jobCode,
+			Status:    "irregular_cadence",
+			Detection: config.DetectionDataQuality,
+			Severity:  a.config.AlertSeverity(config.DetectionDataQuality),
+			Reason: fmt.Sprintf("runs irregularly: expected every %s, observed every %s with a largest gap of %s (threshold %.1fx)",
+				state.ExpectedCadence, observed.Round(time.Second), largest.Round(time.Second), tolerance),
+		})
+	}
+
+	return alerts
+}
+
+// largestGap returns the largest gap between successive timestamps
+func largestGap(times []time.Time) time.Duration {
+	sorted := append([]time.Time(nil), times...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+
+	var largest time.Duration
+	for i := 1; i < len(sorted); i++ {
+		if gap := sorted[i].Sub(sorted[i-1]); gap > largest {
+			largest = gap
+		}
+	}
+	return largest
+}
+
+// InitJobState returns the state for a cron job, creating it if needed
+func (a *Analyzer) InitJobState(cronCode string) *JobState {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	state, exists := a.jobStates[cronCode]
+	if !exists {
+		state = &JobState{
+			JobCode:     cronCode,
+			LastChecked: a.clock.Now(),
+		}
+		a.jobStates[cronCode] = state
+	}
+	return state
+}
+
+// GetCronState returns the state for a specific cron job
+func (a *Analyzer) GetCronState(cronCode string) *JobState {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.jobStates[cronCode]
+}
+
+// DetectStateTransitions detects state transitions for Slack notifications
+// This should be called after Analyze() to detect healthy/stuck transitions
+func (a *Analyzer) DetectStateTransitions(schedules []*database.CronSchedule) []StateTransition {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	transitions := make([]StateTransition, 0)
+
+	schedules = a.detectionSchedules(schedules)
+
+	// Group schedules by job_code
+	jobSchedules := make(map[string][]*database.CronSchedule)
+	for _, s := range schedules {
+		jobSchedules[s.JobCode] = append(jobSchedules[s.JobCode], s)
+	}
+
+	// Missing and dropped out jobs may have no rows, they are added so they can alert and recover like the others
+	for jobCode, state := range a.jobStates {
+		if _, ok := jobSchedules[jobCode]; ok {
+			continue
+		}
+		if state.MissingStatus != "" || (state.LastKnownState == "alerting" && isMissingStatus(state.LastStatus)) {
+			jobSchedules[jobCode] = nil
+		}
+	}
+
+	// Check each job for state transitions
+	for jobCode, schedList := range jobSchedules {
+		state := a.jobStates[jobCode]
+		if state == nil {
+			continue
+		}
+
+		detectionCfg := a.config.GetDetectionConfig(jobCode)
+
+		// Determine if currently not alerting or alerting
+		// Analyze already counted this check, so the detections are re-evaluated on a probe
+		isNotAlerting := a.isJobHealthy(schedList, detectionCfg, state.probe())
+
+		// Initialize state if empty
+		if state.LastKnownState == "" {
+			state.LastKnownState = "not_alerting"
+		}
+
+		// Detect not_alerting → alerting transition
 		if !isNotAlerting && state.LastKnownState == "not_alerting" {
-			state.StuckSince = time.Now()
+			// Hold back re-alerting right after a recovery so jobs oscillating around a threshold don't churn
+			if detectionCfg.RecoveryHold > 0 && !state.LastRecovery.IsZero() && a.since(state.LastRecovery) < detectionCfg.RecoveryHold {
+				continue
+			}
+			if a.snoozed != nil && a.snoozed(jobCode) {
+				continue
+			}
+			// Still stuck once maintenance ends, the job alerts as a fresh incident
+			if a.inMaintenance() {
+				continue
+			}
+
+			state.StuckSince = a.clock.Now()
 
 			// Get last execution time and enhanced data from schedules
 			var lastExec time.Time
@@ -427,15 +1843,15 @@ func (a *Analyzer) DetectStateTransitions(schedules []*database.CronSchedule) []
 			var currentStatus string
 			
 			for _, s := range schedList {
-				if s.ExecutedAt.Valid && (lastExec.IsZero() || s.ExecutedAt.Time.After(lastExec)) {
+				if s.ExecutedAt.Valid && !hasFutureExecution(s, a.clock.Now()) && (lastExec.IsZero() || s.ExecutedAt.Time.After(lastExec)) {
 					lastExec = s.ExecutedAt.Time
 				}
-				if scheduledAt == nil || s.ScheduledAt.After(*scheduledAt) {
-					scheduledAt = &s.ScheduledAt
+				if s.ScheduledAt.Valid && (scheduledAt == nil || s.ScheduledAt.Time.After(*scheduledAt)) {
+					scheduledAt = &s.ScheduledAt.Time
 				}
 				// Calculate running time for running jobs
-				if s.Status == "running" && s.ExecutedAt.Valid {
-					runtime := time.Since(s.ExecutedAt.Time)
+				if s.Status == "running" && s.ExecutedAt.Valid && !hasFutureExecution(s, a.clock.Now()) {
+					runtime := a.since(s.ExecutedAt.Time)
 					runningTime = &runtime
 					currentStatus = s.Status
 				}
@@ -443,15 +1859,18 @@ func (a *Analyzer) DetectStateTransitions(schedules []*database.CronSchedule) []
 					currentStatus = s.Status
 				}
 			}
+			if currentStatus == "" {
+				currentStatus = state.MissingStatus
+			}
 
 			// Get the actual reason from the alert detection methods
-			reason := a.getActualAlertReason(schedList, detectionCfg, state)
+			reason := a.getActualAlertReason(schedList, detectionCfg, state.probe())
 
 			transitions = append(transitions, StateTransition{
 				CronCode:         jobCode,
 				FromState:        "not_alerting",
 				ToState:          "alerting",
-				Timestamp:        time.Now(),
+				Timestamp:        a.clock.Now(),
 				Status:           currentStatus,
 				LastExecution:    lastExec,
 				RunningTime:      runningTime,
@@ -464,39 +1883,52 @@ func (a *Analyzer) DetectStateTransitions(schedules []*database.CronSchedule) []
 
 		// Detect alerting → not_alerting transition
 		if isNotAlerting && state.LastKnownState == "alerting" {
-			duration := time.Since(state.StuckSince)
+			duration := a.since(state.StuckSince)
 
 			// Get last execution time and enhanced data from schedules
 			var lastExec time.Time
 			var scheduledAt *time.Time
 			var currentStatus string
+			var completionTime *time.Duration
+			var lastFinished time.Time
 			
 			for _, s := range schedList {
-				if s.ExecutedAt.Valid && (lastExec.IsZero() || s.ExecutedAt.Time.After(lastExec)) {
+				if s.ExecutedAt.Valid && !hasFutureExecution(s, a.clock.Now()) && (lastExec.IsZero() || s.ExecutedAt.Time.After(lastExec)) {
 					lastExec = s.ExecutedAt.Time
 				}
-				if scheduledAt == nil || s.ScheduledAt.After(*scheduledAt) {
-					scheduledAt = &s.ScheduledAt
+				if s.ScheduledAt.Valid && (scheduledAt == nil || s.ScheduledAt.Time.After(*scheduledAt)) {
+					scheduledAt = &s.ScheduledAt.Time
 				}
 				if currentStatus == "" {
 					currentStatus = s.Status
 				}
+				// Duration of the most recent completed run
+				if s.Status == "success" && s.ExecutedAt.Valid && s.FinishedAt.Valid && s.FinishedAt.Time.After(lastFinished) {
+					lastFinished = s.FinishedAt.Time
+					completed := s.FinishedAt.Time.Sub(s.ExecutedAt.Time)
+					completionTime = &completed
+				}
 			}
 
 			transitions = append(transitions, StateTransition{
 				CronCode:         jobCode,
 				FromState:        "alerting",
 				ToState:          "not_alerting",
-				Timestamp:        time.Now(),
+				Timestamp:        a.clock.Now(),
 				StuckDuration:    duration,
 				Status:           currentStatus,
 				LastExecution:    lastExec,
 				ScheduledAt:      scheduledAt,
 				Reason:           "", // No specific reason needed for recovery
 				ConsecutiveStuck: 0, // Reset since it's no longer alerting
+				CompletionTime:   completionTime,
 			})
 			state.LastKnownState = "not_alerting"
 			state.StuckSince = time.Time{}
+			state.LastRecovery = a.clock.Now()
+			state.IssueSince = time.Time{}
+			state.IncidentNotified = false
+			state.EscalationLevel = 0
 		}
 	}
 
@@ -505,7 +1937,16 @@ func (a *Analyzer) DetectStateTransitions(schedules []*database.CronSchedule) []
 
 // isJobHealthy determines if a job is currently healthy (not stuck)
 func (a *Analyzer) isJobHealthy(schedules []*database.CronSchedule, cfg config.DetectionConfig, state *JobState) bool {
+	if a.checkMissing(state) != nil {
+		return false
+	}
+	if cfg.Mode == "score" {
+		return a.checkScore(cfg, state) == nil
+	}
 	// Check if any stuck condition is met
+	if a.checkOrphanedRunning(schedules, cfg, state) != nil {
+		return false
+	}
 	if a.checkLongRunning(schedules, cfg, state) != nil {
 		return false
 	}
@@ -515,15 +1956,32 @@ func (a *Analyzer) isJobHealthy(schedules []*database.CronSchedule, cfg config.D
 	if a.checkConsecutiveErrors(schedules, cfg, state) != nil {
 		return false
 	}
+	if a.checkSuccessRate(schedules, cfg, state) != nil {
+		return false
+	}
 	if a.checkMissedExecutions(schedules, cfg, state) != nil {
 		return false
 	}
+	if a.checkSchedulingLatency(schedules, cfg, state) != nil {
+		return false
+	}
+	if a.checkPendingGrowth(cfg, state) != nil {
+		return false
+	}
 	return true
 }
 
 // getActualAlertReason determines the specific reason for an alert by checking which condition is triggered
 func (a *Analyzer) getActualAlertReason(schedules []*database.CronSchedule, cfg config.DetectionConfig, state *JobState) string {
-	// Check each condition and return the specific reason
+	if cfg.Mode == "score" {
+		if alert := a.checkScore(cfg, state); alert != nil {
+			return alert.Reason
+		}
+	}
+	// Check each condition and return the specific reason, a crashed worker before a slow job
+	if alert := a.checkOrphanedRunning(schedules, cfg, state); alert != nil {
+		return alert.Reason
+	}
 	if alert := a.checkLongRunning(schedules, cfg, state); alert != nil {
 		return alert.Reason
 	}
@@ -533,9 +1991,21 @@ func (a *Analyzer) getActualAlertReason(schedules []*database.CronSchedule, cfg
 	if alert := a.checkConsecutiveErrors(schedules, cfg, state); alert != nil {
 		return alert.Reason
 	}
+	if alert := a.checkSuccessRate(schedules, cfg, state); alert != nil {
+		return alert.Reason
+	}
 	if alert := a.checkMissedExecutions(schedules, cfg, state); alert != nil {
 		return alert.Reason
 	}
+	if alert := a.checkSchedulingLatency(schedules, cfg, state); alert != nil {
+		return alert.Reason
+	}
+	if alert := a.checkPendingGrowth(cfg, state); alert != nil {
+		return alert.Reason
+	}
+	if alert := a.checkMissing(state); alert != nil {
+		return alert.Reason
+	}
 	
 	// Fallback if no specific condition is met
 	return "Multiple issues detected requiring attention"
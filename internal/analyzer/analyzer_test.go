@@ -0,0 +1,477 @@
+package analyzer
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/config"
+	"github.com/fabio/go-magento-cron-monitor/internal/database"
+)
+
+// fakeClock is a Clock that only moves when told to
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+// newTestAnalyzer loads a config with the given monitor section and returns an analyzer on a fake clock
+func newTestAnalyzer(t *testing.T, monitor string) (*Analyzer, *fakeClock) {
+	t.Helper()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	content := "database:\n  host: localhost\n  user: test\n  name: magento\n" +
+		"logging:\n  file: " + filepath.Join(dir, "monitor.log") + "\n" +
+		"monitor:\n" + monitor
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	clock := &fakeClock{now: time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)}
+	a := NewAnalyzer(cfg)
+	a.SetClock(clock)
+	return a, clock
+}
+
+// runningRow returns a running schedule that started at executedAt
+func runningRow(id int, jobCode string, executedAt time.Time) *database.CronSchedule {
+	return &database.CronSchedule{
+		ScheduleID:  id,
+		JobCode:     jobCode,
+		Status:      "running",
+		CreatedAt:   executedAt.Add(-time.Minute),
+		ScheduledAt: sql.NullTime{Time: executedAt, Valid: true},
+		ExecutedAt:  sql.NullTime{Time: executedAt, Valid: true},
+	}
+}
+
+// successRow returns a successful schedule that ran from executedAt to finishedAt
+func successRow(id int, jobCode string, executedAt, finishedAt time.Time) *database.CronSchedule {
+	return &database.CronSchedule{
+		ScheduleID:  id,
+		JobCode:     jobCode,
+		Status:      "success",
+		CreatedAt:   executedAt.Add(-time.Minute),
+		ScheduledAt: sql.NullTime{Time: executedAt, Valid: true},
+		ExecutedAt:  sql.NullTime{Time: executedAt, Valid: true},
+		FinishedAt:  sql.NullTime{Time: finishedAt, Valid: true},
+	}
+}
+
+func TestAnalyzeThresholdChecks(t *testing.T) {
+	tests := []struct {
+		name            string
+		thresholdChecks int
+		alertOnCheck    int
+	}{
+		{"first check", 1, 1},
+		{"default of two", 2, 2},
+		{"three checks", 3, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, clock := newTestAnalyzer(t, "  detection:\n    max_running_time: 30m\n    threshold_checks: "+strconv.Itoa(tt.thresholdChecks)+"\n")
+			started := clock.Now().Add(-40 * time.Minute)
+
+			for check := 1; check <= tt.alertOnCheck; check++ {
+				alerts := a.Analyze([]*database.CronSchedule{runningRow(1, "sales_export", started)})
+				if check < tt.alertOnCheck && len(alerts) != 0 {
+					t.Fatalf("check %d: expected no alert before threshold_checks, got %d", check, len(alerts))
+				}
+				if check == tt.alertOnCheck {
+					if len(alerts) != 1 {
+						t.Fatalf("check %d: expected 1 alert, got %d", check, len(alerts))
+					}
+					if alerts[0].Detection != config.DetectionLongRunning {
+						t.Errorf("expected %s detection, got %s", config.DetectionLongRunning, alerts[0].Detection)
+					}
+				}
+				clock.Advance(2 * time.Minute)
+			}
+		})
+	}
+}
+
+func TestAnalyzeResetsStreakWhenHealthy(t *testing.T) {
+	a, clock := newTestAnalyzer(t, "  detection:\n    max_running_time: 30m\n    threshold_checks: 2\n")
+	started := clock.Now().Add(-40 * time.Minute)
+
+	if alerts := a.Analyze([]*database.CronSchedule{runningRow(1, "sales_export", started)}); len(alerts) != 0 {
+		t.Fatalf("expected no alert on the first check, got %d", len(alerts))
+	}
+
+	// A healthy check in between restarts the count
+	clock.Advance(2 * time.Minute)
+	a.Analyze([]*database.CronSchedule{successRow(2, "sales_export", clock.Now().Add(-time.Minute), clock.Now())})
+
+	clock.Advance(2 * time.Minute)
+	if alerts := a.Analyze([]*database.CronSchedule{runningRow(3, "sales_export", clock.Now().Add(-40*time.Minute))}); len(alerts) != 0 {
+		t.Fatalf("expected the streak to restart after a healthy check, got %d alerts", len(alerts))
+	}
+}
+
+func TestDetectStateTransitions(t *testing.T) {
+	a, clock := newTestAnalyzer(t, "  detection:\n    max_running_time: 30m\n    threshold_checks: 2\n")
+	started := clock.Now().Add(-40 * time.Minute)
+	stuck := []*database.CronSchedule{runningRow(1, "sales_export", started)}
+
+	// Below threshold_checks the job stays not_alerting
+	a.Analyze(stuck)
+	if transitions := a.DetectStateTransitions(stuck); len(transitions) != 0 {
+		t.Fatalf("expected no transition below threshold_checks, got %+v", transitions)
+	}
+
+	clock.Advance(2 * time.Minute)
+	a.Analyze(stuck)
+	transitions := a.DetectStateTransitions(stuck)
+	if len(transitions) != 1 || transitions[0].FromState != "not_alerting" || transitions[0].ToState != "alerting" {
+		t.Fatalf("expected a not_alerting → alerting transition, got %+v", transitions)
+	}
+	if !transitions[0].Timestamp.Equal(clock.Now()) {
+		t.Errorf("expected the transition at the fake clock's time %s, got %s", clock.Now(), transitions[0].Timestamp)
+	}
+
+	// Still stuck: no repeated transition
+	clock.Advance(2 * time.Minute)
+	a.Analyze(stuck)
+	if transitions := a.DetectStateTransitions(stuck); len(transitions) != 0 {
+		t.Fatalf("expected no transition while still alerting, got %+v", transitions)
+	}
+
+	// The run finishes: the job recovers after being stuck for the elapsed fake time
+	clock.Advance(6 * time.Minute)
+	recovered := []*database.CronSchedule{successRow(1, "sales_export", started, clock.Now().Add(-time.Minute))}
+	a.Analyze(recovered)
+	transitions = a.DetectStateTransitions(recovered)
+	if len(transitions) != 1 || transitions[0].ToState != "not_alerting" {
+		t.Fatalf("expected an alerting → not_alerting transition, got %+v", transitions)
+	}
+	if transitions[0].StuckDuration != 8*time.Minute {
+		t.Errorf("expected a stuck duration of 8m, got %s", transitions[0].StuckDuration)
+	}
+	if state := a.GetCronState("sales_export"); !state.LastRecovery.Equal(clock.Now()) {
+		t.Errorf("expected LastRecovery at %s, got %s", clock.Now(), state.LastRecovery)
+	}
+}
+
+func TestCountSuspiciousRowsUsesClock(t *testing.T) {
+	a, clock := newTestAnalyzer(t, "")
+	row := runningRow(1, "sales_export", clock.Now().Add(10*time.Minute))
+
+	if count := a.CountSuspiciousRows([]*database.CronSchedule{row}); count != 1 {
+		t.Fatalf("expected the row to be in the future, got %d suspicious rows", count)
+	}
+	clock.Advance(20 * time.Minute)
+	if count := a.CountSuspiciousRows([]*database.CronSchedule{row}); count != 0 {
+		t.Fatalf("expected the row to be in the past once the clock moved, got %d suspicious rows", count)
+	}
+}
+
+func TestGetJobStatesCopiesMapsAndSlices(t *testing.T) {
+	a, clock := newTestAnalyzer(t, "")
+	state := a.InitJobState("sales_export")
+	a.MarkNotified(state, "slack", clock.Now())
+	state.PendingHistory = []int{1, 2}
+
+	states := a.GetJobStates()
+	a.MarkNotified(state, "slack", clock.Now().Add(time.Minute))
+	a.MarkNotified(state, "email", clock.Now())
+	state.PendingHistory[0] = 5
+
+	copied := states["sales_export"]
+	if len(copied.LastNotified) != 1 || !copied.LastNotified["slack"].Equal(clock.Now()) {
+		t.Errorf("expected the copy's LastNotified to be unaffected, got %v", copied.LastNotified)
+	}
+	if copied.PendingHistory[0] != 1 {
+		t.Errorf("expected the copy's PendingHistory to be unaffected, got %v", copied.PendingHistory)
+	}
+}
+
+func TestAnalyzeReportsOrphanedRunInsteadOfLongRunning(t *testing.T) {
+	a, clock := newTestAnalyzer(t, "  detection:\n    max_running_time: 30m\n    orphan_running_time: 1h\n    threshold_checks: 1\n")
+	run := runningRow(1, "sales_export", clock.Now().Add(-90*time.Minute))
+	pending := &database.CronSchedule{
+		ScheduleID:  2,
+		JobCode:     "sales_export",
+		Status:      "pending",
+		CreatedAt:   clock.Now().Add(-10 * time.Minute),
+		ScheduledAt: sql.NullTime{Time: clock.Now().Add(-5 * time.Minute), Valid: true},
+	}
+
+	alerts := a.Analyze([]*database.CronSchedule{run, pending})
+	if len(alerts) != 1 || alerts[0].Detection != config.DetectionOrphanedRunning {
+		t.Fatalf("expected a single orphaned_running alert, got %+v", alerts)
+	}
+
+	// Without a newer pending run the same row is just long-running
+	clock.Advance(10 * time.Minute)
+	alerts = a.Analyze([]*database.CronSchedule{run})
+	if len(alerts) != 1 || alerts[0].Detection != config.DetectionLongRunning {
+		t.Fatalf("expected a single long_running alert, got %+v", alerts)
+	}
+}
+
+func TestAnalyzeSuppressesRepeatedAlerts(t *testing.T) {
+	a, clock := newTestAnalyzer(t, "  detection:\n    max_running_time: 30m\n    threshold_checks: 1\n    alert_suppression_window: 10m\n")
+	stuck := []*database.CronSchedule{runningRow(1, "sales_export", clock.Now().Add(-40*time.Minute))}
+
+	if alerts := a.Analyze(stuck); len(alerts) != 1 {
+		t.Fatalf("expected an alert on the first check, got %d", len(alerts))
+	}
+
+	// Inside the window the job stays stuck but is not reported again
+	for i := 0; i < 2; i++ {
+		clock.Advance(4 * time.Minute)
+		if alerts := a.Analyze(stuck); len(alerts) != 0 {
+			t.Fatalf("expected the alert to be suppressed inside the window, got %d", len(alerts))
+		}
+	}
+
+	// Once the window has passed the alert is emitted again
+	clock.Advance(2 * time.Minute)
+	alerts := a.Analyze(stuck)
+	if len(alerts) != 1 {
+		t.Fatalf("expected the alert again after alert_suppression_window, got %d", len(alerts))
+	}
+	if state := a.GetCronState("sales_export"); !state.LastAlertTime.Equal(clock.Now()) {
+		t.Errorf("expected LastAlertTime at %s, got %s", clock.Now(), state.LastAlertTime)
+	}
+}
+
+func TestHasFutureExecution(t *testing.T) {
+	now := time.Date(2025, 6, 2, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		sched *database.CronSchedule
+		want  bool
+	}{
+		{"past", runningRow(1, "sales_export", now.Add(-time.Minute)), false},
+		{"now", runningRow(1, "sales_export", now), false},
+		{"future", runningRow(1, "sales_export", now.Add(time.Second)), true},
+		{"never executed", &database.CronSchedule{ScheduleID: 1, Status: "pending"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasFutureExecution(tt.sched, now); got != tt.want {
+				t.Errorf("hasFutureExecution = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckSuspiciousRows(t *testing.T) {
+	a, clock := newTestAnalyzer(t, "  detection:\n    max_suspicious_rows: 2\n    alert_suppression_window: 10m\n")
+	future := clock.Now().Add(time.Hour)
+	schedules := []*database.CronSchedule{
+		runningRow(1, "sales_export", future),
+		successRow(2, "catalog_index", clock.Now().Add(-2*time.Minute), clock.Now().Add(-time.Minute)),
+	}
+
+	if alert := a.CheckSuspiciousRows(schedules); alert != nil {
+		t.Fatalf("expected no alert below max_suspicious_rows, got %+v", alert)
+	}
+
+	schedules = append(schedules, successRow(3, "newsletter_send_all", future, future.Add(time.Minute)))
+	alert := a.CheckSuspiciousRows(schedules)
+	if alert == nil || alert.Status != "future_executed_at" || alert.Detection != config.DetectionDataQuality {
+		t.Fatalf("expected a future_executed_at data quality alert, got %+v", alert)
+	}
+
+	clock.Advance(5 * time.Minute)
+	if alert := a.CheckSuspiciousRows(schedules); alert != nil {
+		t.Fatalf("expected the alert to be suppressed inside the window, got %+v", alert)
+	}
+}
+
+func TestAnalyzeIgnoresFutureExecutedAt(t *testing.T) {
+	a, clock := newTestAnalyzer(t, "  detection:\n    max_running_time: 30m\n    threshold_checks: 1\n")
+	row := runningRow(1, "sales_export", clock.Now().Add(2*time.Hour))
+
+	// A run starting in the future has no meaningful running time yet
+	if alerts := a.Analyze([]*database.CronSchedule{row}); len(alerts) != 0 {
+		t.Fatalf("expected no alert for a run with executed_at in the future, got %+v", alerts)
+	}
+
+	// Once the clock catches up it is measured like any other run
+	clock.Advance(2*time.Hour + 40*time.Minute)
+	if alerts := a.Analyze([]*database.CronSchedule{row}); len(alerts) != 1 || alerts[0].Detection != config.DetectionLongRunning {
+		t.Fatalf("expected a long_running alert once executed_at is in the past, got %+v", alerts)
+	}
+}
+
+func TestCheckJobDropoutsSkipsIgnoredJobs(t *testing.T) {
+	a, clock := newTestAnalyzer(t, "  detection:\n    dropout_multiplier: 3\n    ignore_jobs: [\"sales_*\"]\n")
+
+	// States of both jobs exist, as after a restore or a config reload that ignored sales_export
+	var schedules []*database.CronSchedule
+	for i, jobCode := range []string{"catalog_index", "sales_export"} {
+		a.InitJobState(jobCode)
+		for j := 0; j < 5; j++ {
+			schedules = append(schedules, &database.CronSchedule{
+				ScheduleID: i*10 + j,
+				JobCode:    jobCode,
+				Status:     "success",
+				CreatedAt:  clock.Now().Add(-time.Hour + time.Duration(j)*5*time.Minute),
+			})
+		}
+	}
+
+	alerts := a.CheckJobDropouts(schedules)
+	if len(alerts) != 1 || alerts[0].JobCode != "catalog_index" {
+		t.Fatalf("expected a dropout alert for catalog_index only, got %+v", alerts)
+	}
+}
+
+func TestCheckCadenceSkipsIgnoredJobs(t *testing.T) {
+	a, clock := newTestAnalyzer(t, "  detection:\n    cadence_tolerance: 2\n    ignore_jobs: [\"sales_*\"]\n")
+
+	// Scheduled every 5 minutes, but the last run started 30 minutes after the previous one
+	start := clock.Now().Add(-time.Hour)
+	executedOffsets := []time.Duration{0, 5, 10, 15, 45}
+	var schedules []*database.CronSchedule
+	for i, jobCode := range []string{"catalog_index", "sales_export"} {
+		a.InitJobState(jobCode)
+		for j, offset := range executedOffsets {
+			row := successRow(i*10+j, jobCode, start.Add(offset*time.Minute), start.Add(offset*time.Minute+time.Minute))
+			row.ScheduledAt = sql.NullTime{Time: start.Add(time.Duration(j) * 5 * time.Minute), Valid: true}
+			schedules = append(schedules, row)
+		}
+	}
+
+	alerts := a.CheckCadence(schedules)
+	if len(alerts) != 1 || alerts[0].JobCode != "catalog_index" {
+		t.Fatalf("expected a cadence alert for catalog_index only, got %+v", alerts)
+	}
+}
+
+// absentSchedules returns runs of jobCode every 5 minutes, the newest created 30 minutes before now
+func absentSchedules(jobCode string, now time.Time) []*database.CronSchedule {
+	var schedules []*database.CronSchedule
+	for i := 0; i < 4; i++ {
+		executedAt := now.Add(-29*time.Minute - time.Duration(i)*5*time.Minute)
+		schedules = append(schedules, successRow(i+1, jobCode, executedAt, executedAt.Add(time.Minute)))
+	}
+	return schedules
+}
+
+func TestExpectedJobAbsentAndRecovered(t *testing.T) {
+	a, clock := newTestAnalyzer(t, "  detection:\n    threshold_checks: 2\n  expected_jobs:\n    - job_code: sales_clean_quotes\n      interval: 10m\n")
+	ctx := context.Background()
+
+	// Below threshold_checks the job is counted but not yet alerting
+	if alerts := a.CheckExpectedJobs(ctx, absentSchedules("sales_clean_quotes", clock.Now()), nil); len(alerts) != 0 {
+		t.Fatalf("expected no alert on the first check, got %d", len(alerts))
+	}
+	if transitions := a.DetectStateTransitions(nil); len(transitions) != 0 {
+		t.Fatalf("expected no transition below threshold_checks, got %+v", transitions)
+	}
+
+	clock.Advance(time.Minute)
+	if alerts := a.CheckExpectedJobs(ctx, absentSchedules("sales_clean_quotes", clock.Now()), nil); len(alerts) != 1 || alerts[0].Status != "absent" {
+		t.Fatalf("expected an absent alert, got %+v", alerts)
+	}
+	transitions := a.DetectStateTransitions(nil)
+	if len(transitions) != 1 || transitions[0].ToState != "alerting" || transitions[0].Status != "absent" {
+		t.Fatalf("expected an absent alerting transition, got %+v", transitions)
+	}
+
+	// A new row within the interval resets the absent count and recovers the job
+	clock.Advance(time.Minute)
+	fresh := []*database.CronSchedule{successRow(10, "sales_clean_quotes", clock.Now().Add(-2*time.Minute), clock.Now().Add(-time.Minute))}
+	if alerts := a.CheckExpectedJobs(ctx, fresh, nil); len(alerts) != 0 {
+		t.Fatalf("expected no alert once the job is scheduled again, got %d", len(alerts))
+	}
+	state := a.GetCronState("sales_clean_quotes")
+	if state.AbsentChecks != 0 || state.MissingStatus != "" {
+		t.Errorf("expected the absent tracking to be reset, got %d checks and status %q", state.AbsentChecks, state.MissingStatus)
+	}
+	transitions = a.DetectStateTransitions(fresh)
+	if len(transitions) != 1 || transitions[0].ToState != "not_alerting" {
+		t.Fatalf("expected a recovery transition, got %+v", transitions)
+	}
+}
+
+func TestExpectedJobRecoversWhenNoLongerExpected(t *testing.T) {
+	a, clock := newTestAnalyzer(t, "  detection:\n    threshold_checks: 1\n  expected_jobs:\n    - job_code: sales_clean_quotes\n      interval: 10m\n")
+	ctx := context.Background()
+
+	a.CheckExpectedJobs(ctx, absentSchedules("sales_clean_quotes", clock.Now()), nil)
+	if transitions := a.DetectStateTransitions(nil); len(transitions) != 1 || transitions[0].ToState != "alerting" {
+		t.Fatalf("expected an alerting transition, got %+v", transitions)
+	}
+
+	// Without rows in the check, the job still recovers once it is removed from expected_jobs
+	cfg := *a.config
+	cfg.Monitor.ExpectedJobs = nil
+	a.SetConfig(&cfg)
+	a.CheckExpectedJobs(ctx, nil, nil)
+	if transitions := a.DetectStateTransitions(nil); len(transitions) != 1 || transitions[0].ToState != "not_alerting" {
+		t.Fatalf("expected a recovery transition, got %+v", transitions)
+	}
+}
+
+func TestJobDropoutAlertsAndRecovers(t *testing.T) {
+	tests := []struct {
+		name     string
+		rowsKept bool
+	}{
+		{"older rows still in scope", true},
+		{"rows left the lookback window", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, clock := newTestAnalyzer(t, "  detection:\n    dropout_multiplier: 3\n")
+			a.InitJobState("catalog_index")
+
+			// Created every 5 minutes until 40 minutes ago
+			var schedules []*database.CronSchedule
+			for i := 0; i < 5; i++ {
+				executedAt := clock.Now().Add(-59*time.Minute + time.Duration(i)*5*time.Minute)
+				schedules = append(schedules, successRow(i+1, "catalog_index", executedAt, executedAt.Add(time.Minute)))
+			}
+			if alerts := a.CheckJobDropouts(schedules); len(alerts) != 1 {
+				t.Fatalf("expected a dropout alert, got %d", len(alerts))
+			}
+
+			scoped := schedules
+			if !tt.rowsKept {
+				scoped = nil
+			}
+			transitions := a.DetectStateTransitions(scoped)
+			if len(transitions) != 1 || transitions[0].ToState != "alerting" {
+				t.Fatalf("expected an alerting transition, got %+v", transitions)
+			}
+			if !strings.Contains(transitions[0].Reason, "no new schedules created") {
+				t.Errorf("expected the dropout reason, got %q", transitions[0].Reason)
+			}
+
+			// Schedules are created again
+			clock.Advance(time.Minute)
+			fresh := []*database.CronSchedule{successRow(10, "catalog_index", clock.Now().Add(-2*time.Minute), clock.Now().Add(-time.Minute))}
+			a.CheckJobDropouts(fresh)
+			if transitions := a.DetectStateTransitions(fresh); len(transitions) != 1 || transitions[0].ToState != "not_alerting" {
+				t.Fatalf("expected a recovery transition, got %+v", transitions)
+			}
+		})
+	}
+}
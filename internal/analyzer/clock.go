@@ -0,0 +1,30 @@
+package analyzer
+
+import "time"
+
+// Clock provides the current time to the analyzer
+// Tests can inject a fake clock to drive thresholds, cooldowns and transitions deterministically
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock is the default Clock, backed by the system time
+type realClock struct{}
+
+// Now returns the current system time
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// SetClock replaces the analyzer's clock, also resetting the warmup start to the new clock's time
+func (a *Analyzer) SetClock(clock Clock) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.clock = clock
+	a.startedAt = clock.Now()
+}
+
+// since returns the time elapsed since t according to the analyzer's clock
+func (a *Analyzer) since(t time.Time) time.Duration {
+	return a.clock.Now().Sub(t)
+}
@@ -3,9 +3,13 @@ package config
 import (
 	"fmt"
 	"os"
+	"path"
+	"regexp"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/fabio/go-magento-cron-monitor/internal/webhook"
 	"github.com/spf13/viper"
 )
 
@@ -15,11 +19,93 @@ type Config struct {
 	Monitor       MonitorConfig       `mapstructure:"monitor"`
 	Logging       LoggingConfig       `mapstructure:"logging"`
 	Notifications NotificationsConfig `mapstructure:"notifications"`
+	Telemetry     TelemetryConfig     `mapstructure:"telemetry"`
+	Magento       MagentoConfig       `mapstructure:"magento"`
+	Cluster       ClusterConfig       `mapstructure:"cluster"`
+	State         StateConfig         `mapstructure:"state"`
+	Export        ExportConfig        `mapstructure:"export"`
+	Snooze        SnoozeConfig        `mapstructure:"snooze"`
+}
+
+// SnoozeConfig points at a file of job snoozes that the daemon reloads on change
+type SnoozeConfig struct {
+	File string `mapstructure:"file"` // Snooze file path, empty disables snoozing
+}
+
+// ExportConfig controls writing a JSON snapshot of the current state for external tooling
+type ExportConfig struct {
+	File     string        `mapstructure:"file"`     // Snapshot path, empty disables exporting
+	Interval time.Duration `mapstructure:"interval"` // Also export periodically (0 = only on SIGUSR2)
+}
+
+// StateConfig controls persisting job states across restarts
+type StateConfig struct {
+	File          string `mapstructure:"file"`           // Path of the state file, empty disables persistence
+	EncryptionKey string `mapstructure:"encryption_key"` // Encrypts the state file with AES-GCM when set
+}
+
+// ClusterConfig coordinates notifications between redundant monitor instances
+type ClusterConfig struct {
+	Backend  string `mapstructure:"backend"`   // none or mysql
+	LockName string `mapstructure:"lock_name"` // Advisory lock name shared by all instances
+}
+
+// MagentoConfig contains information about the monitored Magento installation
+type MagentoConfig struct {
+	Version           string `mapstructure:"version"`             // Static version label, used if it can't be read from the database
+	VersionConfigPath string `mapstructure:"version_config_path"` // core_config_data path holding the version, empty skips the lookup
+	// Map job codes to Magento cron groups by naming convention on startup, merged into monitor.job_groups
+	DetectJobGroups bool `mapstructure:"detect_job_groups"`
+}
+
+// TelemetryConfig contains OpenTelemetry tracing settings
+type TelemetryConfig struct {
+	OTLPEndpoint string `mapstructure:"otlp_endpoint"` // host:port of an OTLP/HTTP collector, empty disables tracing
+	Insecure     bool   `mapstructure:"insecure"`      // Use plain HTTP instead of HTTPS
+	ServiceName  string `mapstructure:"service_name"`
 }
 
 // NotificationsConfig contains notification settings
 type NotificationsConfig struct {
-	Slack SlackConfig `mapstructure:"slack"`
+	Slack     SlackConfig     `mapstructure:"slack"`
+	Email     EmailConfig     `mapstructure:"email"`
+	PagerDuty PagerDutyConfig `mapstructure:"pagerduty"`
+	Teams     TeamsConfig     `mapstructure:"teams"`
+	Webhook   WebhookConfig   `mapstructure:"webhook"`
+	Retry     RetryConfig     `mapstructure:"retry"`
+	// Escalation ladder: re-notify further destinations while a job stays stuck
+	Escalation []EscalationStep `mapstructure:"escalation"`
+	// Escalation ladder for the scheduler alert, measured from when the scheduler became inactive
+	SchedulerEscalation []EscalationStep `mapstructure:"scheduler_escalation"`
+	// Deliver notifications on a background worker instead of inside the check
+	Async          bool `mapstructure:"async"`
+	AsyncQueueSize int  `mapstructure:"async_queue_size"` // Pending deliveries before checks fall back to sending inline
+	// Once-a-day digest of the previous 24 hours
+	DailySummary DailySummaryConfig `mapstructure:"daily_summary"`
+	// Notify every notifier when a SIGHUP reload is rejected and the previous configuration is kept
+	NotifyReloadFailure bool `mapstructure:"notify_reload_failure"`
+	Metadata map[string]string `mapstructure:"metadata"` // Static key/values attached to every notification
+}
+
+// DailySummaryConfig controls the once-a-day digest notification
+type DailySummaryConfig struct {
+	Enabled     bool     `mapstructure:"enabled"`
+	Time        string   `mapstructure:"time"`         // HH:MM, default 09:00
+	Timezone    string   `mapstructure:"timezone"`     // IANA name, defaults to local time
+	WebhookURLs []string `mapstructure:"webhook_urls"` // Defaults to slack.webhook_urls
+}
+
+// EscalationStep re-sends a stuck job's alert to more destinations once it has been alerting for After
+type EscalationStep struct {
+	After       time.Duration `mapstructure:"after"`
+	WebhookURLs []string      `mapstructure:"webhook_urls"` // Slack webhooks of this step
+}
+
+// RetryConfig controls retrying failed notifications on later checks
+type RetryConfig struct {
+	Enabled      bool          `mapstructure:"enabled"`
+	MaxQueueSize int           `mapstructure:"max_queue_size"` // Oldest notifications are dropped beyond this
+	MaxAge       time.Duration `mapstructure:"max_age"`        // Notifications failing for longer than this are dropped
 }
 
 // SlackConfig contains Slack notification settings
@@ -30,22 +116,189 @@ type SlackConfig struct {
 	SendRecovery     bool          `mapstructure:"send_recovery"`
 	RecoveryCooldown time.Duration `mapstructure:"recovery_cooldown"`
 	Timeout          time.Duration `mapstructure:"timeout"`
+	MaxIdleConns     int           `mapstructure:"max_idle_conns"`
+	DisableKeepAlive bool          `mapstructure:"disable_keepalive"`
+	MaxMessageBytes  int           `mapstructure:"max_message_bytes"`
+	MaxRetries       *int          `mapstructure:"max_retries"` // Retries per webhook on 429, 5xx and network errors (default 3)
+	DigestWindow     time.Duration `mapstructure:"digest_window"` // Collect notifications into one digest message per window (0 disables)
+
+	// Additional webhooks notified when a critical job starts alerting
+	CriticalWebhookURLs []string `mapstructure:"critical_webhook_urls"`
+
+	// Time-of-day routing to different on-call rotations
+	ScheduleRoutes []ScheduleRouteConfig `mapstructure:"schedule_routes"`
+
+	// Routing by job code, cron group or severity; tried before schedule_routes
+	Routes []SlackRouteConfig `mapstructure:"routes"`
+}
+
+// SlackRouteConfig sends alerts matching all of its set match fields to specific webhooks
+type SlackRouteConfig struct {
+	Name        string          `mapstructure:"name"`
+	WebhookURLs []string        `mapstructure:"webhook_urls"`
+	Match       SlackRouteMatch `mapstructure:"match"`
+}
+
+// SlackRouteMatch selects the alerts of a Slack route; empty fields match anything
+type SlackRouteMatch struct {
+	JobCode   string `mapstructure:"job_code"`   // Glob pattern, e.g. indexer_*
+	CronGroup string `mapstructure:"cron_group"` // Group from monitor.job_groups
+	Severity  string `mapstructure:"severity"`   // critical, high, warning or info
+}
+
+// EmailConfig contains SMTP email notification settings
+// Emails follow the same cooldowns as Slack but are tracked separately
+type EmailConfig struct {
+	Enabled      bool          `mapstructure:"enabled"`
+	Host         string        `mapstructure:"host"`
+	Port         int           `mapstructure:"port"` // Defaults to 587, or 465 with tls: tls
+	Username     string        `mapstructure:"username"`
+	Password     string        `mapstructure:"password"`
+	From         string        `mapstructure:"from"`
+	To           []string      `mapstructure:"to"`
+	TLS          string        `mapstructure:"tls"` // starttls (default), tls or none
+	SendRecovery bool          `mapstructure:"send_recovery"`
+	Timeout      time.Duration `mapstructure:"timeout"`
+}
+
+// PagerDutyConfig contains PagerDuty Events API v2 settings
+type PagerDutyConfig struct {
+	Enabled     bool          `mapstructure:"enabled"`
+	RoutingKey  string        `mapstructure:"routing_key"`
+	EventsURL   string        `mapstructure:"events_url"`   // Defaults to the public Events API v2 endpoint
+	Source      string        `mapstructure:"source"`       // Defaults to the hostname
+	MinSeverity string        `mapstructure:"min_severity"` // critical (default), error, warning or info
+	Timeout     time.Duration `mapstructure:"timeout"`
+}
+
+// TeamsConfig contains Microsoft Teams notification settings
+type TeamsConfig struct {
+	Enabled          bool          `mapstructure:"enabled"`
+	WebhookURLs      []string      `mapstructure:"webhook_urls"`
+	AlertCooldown    time.Duration `mapstructure:"alert_cooldown"`    // Defaults to slack.alert_cooldown
+	SendRecovery     bool          `mapstructure:"send_recovery"`
+	RecoveryCooldown time.Duration `mapstructure:"recovery_cooldown"` // Defaults to slack.recovery_cooldown
+	Timeout          time.Duration `mapstructure:"timeout"`
+}
+
+// WebhookConfig contains generic JSON webhook settings
+type WebhookConfig struct {
+	Enabled      bool              `mapstructure:"enabled"`
+	URLs         []string          `mapstructure:"urls"`
+	Method       string            `mapstructure:"method"`   // Defaults to POST
+	Headers      map[string]string `mapstructure:"headers"`  // Values support ${ENV_VAR}
+	Template     string            `mapstructure:"template"` // text/template for the JSON body, executed with the alert
+	SendRecovery bool              `mapstructure:"send_recovery"`
+	Timeout      time.Duration     `mapstructure:"timeout"`
+}
+
+// ScheduleRouteConfig routes notifications sent during a time window to specific webhooks
+type ScheduleRouteConfig struct {
+	Name        string     `mapstructure:"name"`
+	Window      TimeWindow `mapstructure:",squash"`
+	WebhookURLs []string   `mapstructure:"webhook_urls"`
 }
 
 // DatabaseConfig holds database connection settings
 type DatabaseConfig struct {
+	Driver   string `mapstructure:"driver"` // mysql (default) or postgres
 	Host     string `mapstructure:"host"`
 	Port     int    `mapstructure:"port"`
+	Socket   string `mapstructure:"socket"` // Unix socket path, used instead of host and port when set
 	Name     string `mapstructure:"name"`
 	User     string `mapstructure:"user"`
 	Password string `mapstructure:"password"`
+
+	// Connection pool limits
+	MaxOpenConns    int           `mapstructure:"max_open_conns"`    // Default: 10
+	MaxIdleConns    int           `mapstructure:"max_idle_conns"`    // Default: 5, at most max_open_conns
+	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"` // Default: 5m
+
+	// Startup connection retries, for databases that come up after the monitor
+	ConnectRetries    *int          `mapstructure:"connect_retries"`     // Retries after the first attempt (default: 5)
+	ConnectRetryDelay time.Duration `mapstructure:"connect_retry_delay"` // Delay before the first retry, doubled up to 30s (default: 2s)
+
+	// Encrypted connections
+	TLS DatabaseTLSConfig `mapstructure:"tls"`
+
+	// Magento table prefix, e.g. mage_ for mage_cron_schedule
+	TablePrefix string `mapstructure:"table_prefix"`
+
+	// Column names of cron_schedule, for schemas that renamed them
+	Columns ColumnsConfig `mapstructure:"columns"`
+}
+
+// DatabaseTLSConfig holds encrypted database connection settings
+type DatabaseTLSConfig struct {
+	Mode       string `mapstructure:"mode"`        // disabled, preferred, required, verify-ca or verify-identity (empty = driver default)
+	CACert     string `mapstructure:"ca_cert"`     // PEM CA bundle used to verify the server
+	ClientCert string `mapstructure:"client_cert"` // PEM client certificate, for servers requiring X.509 authentication
+	ClientKey  string `mapstructure:"client_key"`  // PEM key of client_cert
+}
+
+// databaseTLSModes lists the supported database.tls.mode values
+var databaseTLSModes = map[string]bool{
+	"":                true,
+	"disabled":        true,
+	"preferred":       true,
+	"required":        true,
+	"verify-ca":       true,
+	"verify-identity": true,
 }
 
+// ColumnsConfig maps cron_schedule fields to their column names (default: Magento's names)
+type ColumnsConfig struct {
+	ScheduleID  string `mapstructure:"schedule_id"`
+	JobCode     string `mapstructure:"job_code"`
+	Status      string `mapstructure:"status"`
+	Messages    string `mapstructure:"messages"`
+	CreatedAt   string `mapstructure:"created_at"`
+	ScheduledAt string `mapstructure:"scheduled_at"`
+	ExecutedAt  string `mapstructure:"executed_at"`
+	FinishedAt  string `mapstructure:"finished_at"`
+}
+
+// tablePrefixPattern restricts the table prefix to identifier characters, since it is interpolated into SQL
+var tablePrefixPattern = regexp.MustCompile(`^[a-zA-Z0-9_]*$`)
+
+// columnNamePattern restricts column names to plain identifiers, since they are interpolated into SQL
+var columnNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,63}$`)
+
 // MonitorConfig holds monitoring settings
 type MonitorConfig struct {
-	Interval     time.Duration        `mapstructure:"interval"`
-	Detection    DetectionConfig      `mapstructure:"detection"`
-	JobOverrides []JobOverrideConfig  `mapstructure:"job_overrides"`
+	Interval        time.Duration       `mapstructure:"interval"`
+	Detection       DetectionConfig     `mapstructure:"detection"`
+	JobOverrides    []JobOverrideConfig `mapstructure:"job_overrides"`
+	ExpectedJobs    []ExpectedJobConfig `mapstructure:"expected_jobs"`
+	ObserveOnly     bool                `mapstructure:"observe_only"`
+	AlignToInterval bool                `mapstructure:"align_to_interval"`
+	// Randomize each check by up to ± this much and delay the first check by up to this much, to spread a fleet's queries
+	IntervalJitter time.Duration `mapstructure:"interval_jitter"`
+	// Widen the check interval after this many consecutive failed checks, e.g. while the database is down (default: 3, 0 = disabled)
+	FailureBackoffAfter *int          `mapstructure:"failure_backoff_after"`
+	MaxBackoffInterval  time.Duration `mapstructure:"max_backoff_interval"` // Cap of the widened interval (default: 15m, at least interval)
+	// QueryTimeout bounds each database query of a check, so a hung connection can't stall the loop (default: 30s)
+	QueryTimeout time.Duration `mapstructure:"query_timeout"`
+	// HTTPAddr is the listen address of the /healthz and /readyz endpoints, e.g. ":8080" (empty = disabled)
+	HTTPAddr         string        `mapstructure:"http_addr"`
+	HealthStaleAfter time.Duration `mapstructure:"health_stale_after"` // /healthz fails without a successful check this recently (default: 3x interval)
+	// Periodically delete finished cron_schedule rows older than CleanupOlderThan (0 = disabled)
+	CleanupInterval  time.Duration `mapstructure:"cleanup_interval"`
+	CleanupOlderThan time.Duration `mapstructure:"cleanup_older_than"` // Default: 24h, at least detection.lookback_window
+	// Aliases maps renamed job codes to their canonical code (old_code: new_code)
+	Aliases map[string]string `mapstructure:"aliases"`
+	// JobGroups maps group names to the job codes (or glob patterns) belonging to them
+	JobGroups map[string][]string `mapstructure:"job_groups"`
+	// GroupIntervals checks the jobs of these job_groups on their own interval instead of monitor.interval
+	GroupIntervals map[string]time.Duration `mapstructure:"group_intervals"`
+	// CriticalJobs lists job codes or glob patterns that alert on the first detection, without cooldown
+	CriticalJobs []string `mapstructure:"critical_jobs"`
+}
+
+// ExpectedJobConfig declares a job code that is expected to appear in cron_schedule
+type ExpectedJobConfig struct {
+	JobCode  string        `mapstructure:"job_code"`
+	Interval time.Duration `mapstructure:"interval"` // Flag the job as absent without rows created this recently (default: detection.lookback_window)
 }
 
 // DetectionConfig holds global detection thresholds
@@ -53,30 +306,178 @@ type DetectionConfig struct {
 	MaxRunningTime     time.Duration `mapstructure:"max_running_time"`
 	MaxPendingCount    int           `mapstructure:"max_pending_count"`
 	ConsecutiveErrors  int           `mapstructure:"consecutive_errors"`
+	ErrorCounting      string        `mapstructure:"error_counting"`        // consecutive or windowed
+	ErrorRatio         float64       `mapstructure:"error_ratio"`           // windowed: minimum share of failed runs (0 = count only)
 	MaxMissedCount     int           `mapstructure:"max_missed_count"`
+	MinSuccessRate     float64       `mapstructure:"min_success_rate"`      // Alert when fewer finished runs in the window succeeded (0 = disabled)
+	MinSamples         int           `mapstructure:"min_samples"`           // Finished runs needed before min_success_rate applies (default: 5)
 	LookbackWindow     time.Duration `mapstructure:"lookback_window"`
+	LookbackField      string        `mapstructure:"lookback_field"`        // created_at or scheduled_at
+	IgnoreOlderThan    time.Duration `mapstructure:"ignore_older_than"`     // Drop rows older than this from detection (0 = use the whole lookback window)
 	ThresholdChecks    int           `mapstructure:"threshold_checks"`      // Consecutive checks before alerting
+
+	// Job selection (job codes or glob patterns, case-sensitive); ignore_jobs wins over only_jobs
+	IgnoreJobs []string `mapstructure:"ignore_jobs"` // Never analyze these jobs
+	OnlyJobs   []string `mapstructure:"only_jobs"`   // Analyze only these jobs (empty = all)
+
+	// Rule enable flags (default: all enabled)
+	DetectLongRunning *bool `mapstructure:"detect_long_running"`
+	DetectPending     *bool `mapstructure:"detect_pending"`
+	DetectErrors      *bool `mapstructure:"detect_errors"`
+	DetectMissed      *bool `mapstructure:"detect_missed"`
+	DetectScheduler   *bool `mapstructure:"detect_scheduler"`
+
+	// Trend detection settings
+	PendingGrowthChecks int `mapstructure:"pending_growth_checks"` // Alert when the pending count grows this many checks in a row (0 = disabled)
+
+	// Orphaned run settings
+	OrphanRunningTime time.Duration `mapstructure:"orphan_running_time"` // Flag a run this old with a newer pending run queued behind it (0 = disabled)
+
+	// Scheduling latency settings
+	MaxScheduleLag time.Duration `mapstructure:"max_schedule_lag"` // Alert when the latest run started this long after its scheduled_at (0 = disabled)
+
+	// Schedule dropout settings
+	DropoutMultiplier float64 `mapstructure:"dropout_multiplier"` // Alert when no rows were created for this many learned intervals (0 = disabled)
+
+	// schedule_id sequence settings
+	ScheduleIDJumpRatio float64 `mapstructure:"schedule_id_jump_ratio"` // Alert when schedule_id advances this many times faster than rows appear, or resets (0 = disabled)
+
+	// Execution cadence settings
+	CadenceTolerance float64 `mapstructure:"cadence_tolerance"` // Alert when an executed_at gap exceeds this many expected intervals (0 = disabled)
+
+	// Suspiciously short completion settings
+	MinCompletionTime time.Duration `mapstructure:"min_completion_time"` // Successful runs faster than this are flagged (0 = disabled)
+	ShortRunStddev    float64       `mapstructure:"short_run_stddev"`    // Flag runs below mean - k*stddev of the job's runtime baseline (0 = disabled)
 	
 	// Scheduler health check settings
-	SchedulerInactivityMinutes int `mapstructure:"scheduler_inactivity_minutes"` // No new jobs created in X minutes
-	SchedulerLookaheadMinutes  int `mapstructure:"scheduler_lookahead_minutes"`  // No pending jobs scheduled in next X minutes
+	SchedulerInactivityMinutes int    `mapstructure:"scheduler_inactivity_minutes"` // No new jobs created in X minutes
+	SchedulerLookaheadMinutes  int    `mapstructure:"scheduler_lookahead_minutes"`  // No pending jobs scheduled in next X minutes
+	SchedulerHealthMode        string `mapstructure:"scheduler_health_mode"`        // any or all
+	SchedulerWarmup            *time.Duration `mapstructure:"scheduler_warmup"` // Skip the check this long after startup (default: the inactivity threshold)
+	// Alert when fewer distinct job codes than this are pending in the lookahead (0 = disabled)
+	SchedulerMinDistinctUpcoming int `mapstructure:"scheduler_min_distinct_upcoming"`
+	// Minimum interval between repeated scheduler alerts while it stays inactive (default: alert_suppression_window)
+	SchedulerAlertCooldown time.Duration `mapstructure:"scheduler_alert_cooldown"`
+
+	// Time-of-day overrides of scheduler_inactivity_minutes (e.g. more tolerance overnight)
+	SchedulerInactivityWindows []SchedulerInactivityWindow `mapstructure:"scheduler_inactivity_windows"`
+
+	// Hold back a new alerting transition for this long after a job recovers (0 = disabled)
+	RecoveryHold time.Duration `mapstructure:"recovery_hold"`
+
+	// Periods (e.g. deploys) during which state is tracked but no alerts are raised
+	MaintenanceWindows []MaintenanceWindow `mapstructure:"maintenance_windows"`
+
+	// Severity per detection type (e.g. orphaned_running: critical), overriding the built-in defaults
+	Severities map[string]string `mapstructure:"severities"`
+
+	// Suppress repeated alerts of the same job or data-quality check within this window (default: 5m)
+	AlertSuppressionWindow time.Duration `mapstructure:"alert_suppression_window"`
+
+	// Alert when the lookback query returns no rows at all (disable on idle dev stores)
+	AlertOnEmptyResult bool `mapstructure:"alert_on_empty_result"`
+
+	// Alert when at least this many rows have executed_at in the future (0 = disabled)
+	MaxSuspiciousRows int `mapstructure:"max_suspicious_rows"`
+	// Alert when at least this many rows have timing fields that contradict their status (0 = disabled)
+	MaxInconsistentRows int `mapstructure:"max_inconsistent_rows"`
+
+	// Detection mode: "rules" alerts per rule, "score" alerts on a weighted health score
+	Mode    string        `mapstructure:"mode"`
+	Scoring ScoringConfig `mapstructure:"scoring"`
+}
+
+// SchedulerInactivityWindow overrides the scheduler inactivity threshold during a daily time window
+type SchedulerInactivityWindow struct {
+	Window            TimeWindow `mapstructure:",squash"`
+	InactivityMinutes int        `mapstructure:"inactivity_minutes"`
+}
+
+// ScoringConfig holds the weighted health score settings used when detection.mode is "score"
+// Each signal is the observed value divided by its rule threshold (1.0 = at threshold)
+type ScoringConfig struct {
+	Threshold float64        `mapstructure:"threshold"` // Alert when the weighted score reaches this value
+	Weights   ScoringWeights `mapstructure:"weights"`
+}
+
+// ScoringWeights holds the per-signal weights of the health score
+type ScoringWeights struct {
+	RunningTime *float64 `mapstructure:"running_time"`
+	Pending     *float64 `mapstructure:"pending"`
+	Errors      *float64 `mapstructure:"errors"`
+	Missed      *float64 `mapstructure:"missed"`
 }
 
 // JobOverrideConfig holds per-job configuration overrides for specific job codes
 type JobOverrideConfig struct {
 	JobCode            string         `mapstructure:"job_code"`
+	Group              string         `mapstructure:"group"` // Applies to every job of a monitor.job_groups group instead of a single job code
+	Match              string         `mapstructure:"match"` // Applies to every job code matching this glob pattern, e.g. catalog_*
 	MaxRunningTime     *time.Duration `mapstructure:"max_running_time"`
 	MaxPendingCount    *int           `mapstructure:"max_pending_count"`
 	ConsecutiveErrors  *int           `mapstructure:"consecutive_errors"`
+	ErrorCounting      *string        `mapstructure:"error_counting"`
+	ErrorRatio         *float64       `mapstructure:"error_ratio"`
 	MaxMissedCount     *int           `mapstructure:"max_missed_count"`
+	MinSuccessRate     *float64       `mapstructure:"min_success_rate"`
+	MinSamples         *int           `mapstructure:"min_samples"`
 	ThresholdChecks    *int           `mapstructure:"threshold_checks"`
+
+	// Rule enable overrides
+	DetectLongRunning *bool `mapstructure:"detect_long_running"`
+	DetectPending     *bool `mapstructure:"detect_pending"`
+	DetectErrors      *bool `mapstructure:"detect_errors"`
+	DetectMissed      *bool `mapstructure:"detect_missed"`
+
+	// Trend detection overrides
+	PendingGrowthChecks *int `mapstructure:"pending_growth_checks"`
+
+	// Orphaned run override
+	OrphanRunningTime *time.Duration `mapstructure:"orphan_running_time"`
+
+	// Scheduling latency override
+	MaxScheduleLag *time.Duration `mapstructure:"max_schedule_lag"`
+
+	// Execution cadence override
+	CadenceTolerance *float64 `mapstructure:"cadence_tolerance"`
+
+	// Suspiciously short completion overrides
+	MinCompletionTime *time.Duration `mapstructure:"min_completion_time"`
+	ShortRunStddev    *float64       `mapstructure:"short_run_stddev"`
+
+	// Post-recovery suppression override
+	RecoveryHold *time.Duration `mapstructure:"recovery_hold"`
+
+	// Duplicate alert suppression override
+	AlertSuppressionWindow *time.Duration `mapstructure:"alert_suppression_window"`
+
+	// Notification cooldown overrides
+	AlertCooldown    *time.Duration `mapstructure:"alert_cooldown"`
+	RecoveryCooldown *time.Duration `mapstructure:"recovery_cooldown"`
+}
+
+// CooldownConfig holds the effective notification cooldowns for a job
+type CooldownConfig struct {
+	AlertCooldown    time.Duration
+	RecoveryCooldown time.Duration
 }
 
 // LoggingConfig holds logging settings
 type LoggingConfig struct {
-	File   string `mapstructure:"file"`
-	Level  string `mapstructure:"level"`
-	Format string `mapstructure:"format"` // json or text
+	File   string       `mapstructure:"file"`
+	Level  string       `mapstructure:"level"`
+	Format string       `mapstructure:"format"` // json or text
+	Target []string     `mapstructure:"target"` // Any of file, stdout and syslog (default: file and stdout)
+	Syslog SyslogConfig `mapstructure:"syslog"`
+	// Console overrides whether entries are written to stdout (default: per target, off for a detached daemon)
+	Console *bool `mapstructure:"console"`
+	// Timezone of log and notification timestamps: utc, local or an IANA name (default: utc)
+	Timezone string `mapstructure:"timezone"`
+	// TimestampFormat of log entries: rfc3339, rfc3339_milli, rfc3339_nano or a Go time layout (default: rfc3339)
+	TimestampFormat string `mapstructure:"timestamp_format"`
+	// At most AlertRateLimit stuck cron entries per job are logged per AlertRateWindow (0 = unlimited)
+	AlertRateLimit  int           `mapstructure:"alert_rate_limit"`
+	AlertRateWindow time.Duration `mapstructure:"alert_rate_window"` // Default: 1h
 }
 
 // Load reads and parses the configuration file
@@ -100,6 +501,20 @@ func Load(configPath string) (*Config, error) {
 		envVar := strings.TrimSuffix(strings.TrimPrefix(password, "${"), "}")
 		v.Set("database.password", os.Getenv(envVar))
 	}
+	if password := v.GetString("notifications.email.password"); strings.HasPrefix(password, "${") && strings.HasSuffix(password, "}") {
+		envVar := strings.TrimSuffix(strings.TrimPrefix(password, "${"), "}")
+		v.Set("notifications.email.password", os.Getenv(envVar))
+	}
+	if key := v.GetString("notifications.pagerduty.routing_key"); strings.HasPrefix(key, "${") && strings.HasSuffix(key, "}") {
+		envVar := strings.TrimSuffix(strings.TrimPrefix(key, "${"), "}")
+		v.Set("notifications.pagerduty.routing_key", os.Getenv(envVar))
+	}
+
+	// Expand environment variables in the state encryption key
+	if key := v.GetString("state.encryption_key"); strings.HasPrefix(key, "${") && strings.HasSuffix(key, "}") {
+		envVar := strings.TrimSuffix(strings.TrimPrefix(key, "${"), "}")
+		v.Set("state.encryption_key", os.Getenv(envVar))
+	}
 
 	var cfg Config
 	if err := v.Unmarshal(&cfg); err != nil {
@@ -110,6 +525,29 @@ func Load(configPath string) (*Config, error) {
 	if cfg.Monitor.Interval == 0 {
 		cfg.Monitor.Interval = 2 * time.Minute
 	}
+	if cfg.Monitor.CleanupOlderThan == 0 {
+		cfg.Monitor.CleanupOlderThan = 24 * time.Hour
+	}
+	if cfg.Monitor.QueryTimeout == 0 {
+		cfg.Monitor.QueryTimeout = 30 * time.Second
+	}
+	if cfg.Monitor.FailureBackoffAfter == nil {
+		after := 3
+		cfg.Monitor.FailureBackoffAfter = &after
+	}
+	if cfg.Monitor.MaxBackoffInterval == 0 {
+		cfg.Monitor.MaxBackoffInterval = 15 * time.Minute
+		// Longer intervals are not widened unless a cap is configured
+		if cfg.Monitor.MaxBackoffInterval < cfg.Monitor.Interval {
+			cfg.Monitor.MaxBackoffInterval = cfg.Monitor.Interval
+		}
+	} else if cfg.Monitor.MaxBackoffInterval > 0 && cfg.Monitor.MaxBackoffInterval < cfg.Monitor.Interval {
+		// A cap below interval was accepted while backoff was opt-in; it now just disables the widening
+		cfg.Monitor.MaxBackoffInterval = cfg.Monitor.Interval
+	}
+	if cfg.Monitor.HealthStaleAfter == 0 {
+		cfg.Monitor.HealthStaleAfter = 3 * cfg.Monitor.Interval
+	}
 	if cfg.Monitor.Detection.MaxRunningTime == 0 {
 		cfg.Monitor.Detection.MaxRunningTime = 30 * time.Minute
 	}
@@ -119,23 +557,122 @@ func Load(configPath string) (*Config, error) {
 	if cfg.Monitor.Detection.ConsecutiveErrors == 0 {
 		cfg.Monitor.Detection.ConsecutiveErrors = 3
 	}
+	if cfg.Monitor.Detection.ErrorCounting == "" {
+		cfg.Monitor.Detection.ErrorCounting = "consecutive"
+	}
 	if cfg.Monitor.Detection.MaxMissedCount == 0 {
 		cfg.Monitor.Detection.MaxMissedCount = 5
 	}
+	if cfg.Monitor.Detection.MinSamples == 0 {
+		cfg.Monitor.Detection.MinSamples = 5
+	}
 	if cfg.Monitor.Detection.LookbackWindow == 0 {
 		cfg.Monitor.Detection.LookbackWindow = 1 * time.Hour
 	}
+	if cfg.Monitor.Detection.LookbackField == "" {
+		cfg.Monitor.Detection.LookbackField = "created_at"
+	}
+	if cfg.Monitor.Detection.AlertSuppressionWindow == 0 {
+		cfg.Monitor.Detection.AlertSuppressionWindow = 5 * time.Minute
+	}
+	if cfg.Monitor.Detection.SchedulerAlertCooldown == 0 {
+		cfg.Monitor.Detection.SchedulerAlertCooldown = cfg.Monitor.Detection.AlertSuppressionWindow
+	}
 	if cfg.Monitor.Detection.ThresholdChecks == 0 {
 		cfg.Monitor.Detection.ThresholdChecks = 2
 	}
+	if cfg.Monitor.Detection.SchedulerHealthMode == "" {
+		cfg.Monitor.Detection.SchedulerHealthMode = "any"
+	}
+	if cfg.Monitor.Detection.Mode == "" {
+		cfg.Monitor.Detection.Mode = "rules"
+	}
+	if cfg.Monitor.Detection.Scoring.Threshold == 0 {
+		cfg.Monitor.Detection.Scoring.Threshold = 1.0
+	}
+	weights := &cfg.Monitor.Detection.Scoring.Weights
+	for _, weight := range []**float64{&weights.RunningTime, &weights.Pending, &weights.Errors, &weights.Missed} {
+		if *weight == nil {
+			defaultWeight := 1.0
+			*weight = &defaultWeight
+		}
+	}
+	detection := &cfg.Monitor.Detection
+	for _, flag := range []**bool{&detection.DetectLongRunning, &detection.DetectPending, &detection.DetectErrors, &detection.DetectMissed, &detection.DetectScheduler} {
+		if *flag == nil {
+			enabled := true
+			*flag = &enabled
+		}
+	}
 	if cfg.Logging.Level == "" {
 		cfg.Logging.Level = "info"
 	}
 	if cfg.Logging.Format == "" {
 		cfg.Logging.Format = "json"
 	}
+	if len(cfg.Logging.Target) == 0 {
+		cfg.Logging.Target = append([]string(nil), defaultLogTargets...)
+	}
+	if cfg.Logging.Syslog.Facility == "" {
+		cfg.Logging.Syslog.Facility = "daemon"
+	}
+	if cfg.Logging.Syslog.Tag == "" {
+		cfg.Logging.Syslog.Tag = "go-magento-cron-monitor"
+	}
+	if cfg.Logging.Timezone == "" {
+		cfg.Logging.Timezone = "utc"
+	}
+	if cfg.Logging.TimestampFormat == "" {
+		cfg.Logging.TimestampFormat = "rfc3339"
+	}
+	if cfg.Logging.AlertRateWindow == 0 {
+		cfg.Logging.AlertRateWindow = time.Hour
+	}
+	if cfg.Database.Driver == "" {
+		cfg.Database.Driver = "mysql"
+	}
+	if cfg.Database.MaxOpenConns == 0 {
+		cfg.Database.MaxOpenConns = 10
+	}
+	if cfg.Database.MaxIdleConns == 0 {
+		cfg.Database.MaxIdleConns = 5
+		if cfg.Database.MaxOpenConns > 0 && cfg.Database.MaxOpenConns < 5 {
+			cfg.Database.MaxIdleConns = cfg.Database.MaxOpenConns
+		}
+	}
+	if cfg.Database.ConnMaxLifetime == 0 {
+		cfg.Database.ConnMaxLifetime = 5 * time.Minute
+	}
+	if cfg.Database.ConnectRetries == nil {
+		retries := 5
+		cfg.Database.ConnectRetries = &retries
+	}
+	if cfg.Database.ConnectRetryDelay == 0 {
+		cfg.Database.ConnectRetryDelay = 2 * time.Second
+	}
 	if cfg.Database.Port == 0 {
 		cfg.Database.Port = 3306
+		if cfg.Database.Driver == "postgres" {
+			cfg.Database.Port = 5432
+		}
+	}
+	columns := &cfg.Database.Columns
+	for _, column := range []struct {
+		name *string
+		def  string
+	}{
+		{&columns.ScheduleID, "schedule_id"},
+		{&columns.JobCode, "job_code"},
+		{&columns.Status, "status"},
+		{&columns.Messages, "messages"},
+		{&columns.CreatedAt, "created_at"},
+		{&columns.ScheduledAt, "scheduled_at"},
+		{&columns.ExecutedAt, "executed_at"},
+		{&columns.FinishedAt, "finished_at"},
+	} {
+		if *column.name == "" {
+			*column.name = column.def
+		}
 	}
 
 	// Notification defaults
@@ -148,6 +685,86 @@ func Load(configPath string) (*Config, error) {
 	if cfg.Notifications.Slack.Timeout == 0 {
 		cfg.Notifications.Slack.Timeout = 10 * time.Second
 	}
+	if cfg.Notifications.Slack.MaxMessageBytes == 0 {
+		cfg.Notifications.Slack.MaxMessageBytes = 40000
+	}
+	if cfg.Notifications.Slack.MaxRetries == nil {
+		maxRetries := 3
+		cfg.Notifications.Slack.MaxRetries = &maxRetries
+	}
+	if cfg.Notifications.Email.TLS == "" {
+		cfg.Notifications.Email.TLS = "starttls"
+	}
+	if cfg.Notifications.Email.Port == 0 {
+		cfg.Notifications.Email.Port = 587
+		if cfg.Notifications.Email.TLS == "tls" {
+			cfg.Notifications.Email.Port = 465
+		}
+	}
+	if cfg.Notifications.Email.Timeout == 0 {
+		cfg.Notifications.Email.Timeout = 10 * time.Second
+	}
+	if cfg.Notifications.PagerDuty.EventsURL == "" {
+		cfg.Notifications.PagerDuty.EventsURL = "https://events.pagerduty.com/v2/enqueue"
+	}
+	if cfg.Notifications.PagerDuty.Source == "" {
+		if hostname, err := os.Hostname(); err == nil {
+			cfg.Notifications.PagerDuty.Source = hostname
+		}
+	}
+	if cfg.Notifications.PagerDuty.MinSeverity == "" {
+		cfg.Notifications.PagerDuty.MinSeverity = "critical"
+	}
+	if cfg.Notifications.PagerDuty.Timeout == 0 {
+		cfg.Notifications.PagerDuty.Timeout = 10 * time.Second
+	}
+	if cfg.Notifications.Teams.AlertCooldown == 0 {
+		cfg.Notifications.Teams.AlertCooldown = cfg.Notifications.Slack.AlertCooldown
+	}
+	if cfg.Notifications.Teams.RecoveryCooldown == 0 {
+		cfg.Notifications.Teams.RecoveryCooldown = cfg.Notifications.Slack.RecoveryCooldown
+	}
+	if cfg.Notifications.Teams.Timeout == 0 {
+		cfg.Notifications.Teams.Timeout = 10 * time.Second
+	}
+	if cfg.Notifications.Webhook.Method == "" {
+		cfg.Notifications.Webhook.Method = "POST"
+	}
+	cfg.Notifications.Webhook.Method = strings.ToUpper(cfg.Notifications.Webhook.Method)
+	if cfg.Notifications.Webhook.Timeout == 0 {
+		cfg.Notifications.Webhook.Timeout = 10 * time.Second
+	}
+	// Expand environment variables in header values, e.g. auth tokens
+	for name, value := range cfg.Notifications.Webhook.Headers {
+		if strings.HasPrefix(value, "${") && strings.HasSuffix(value, "}") {
+			cfg.Notifications.Webhook.Headers[name] = os.Getenv(strings.TrimSuffix(strings.TrimPrefix(value, "${"), "}"))
+		}
+	}
+	if cfg.Notifications.Retry.MaxQueueSize == 0 {
+		cfg.Notifications.Retry.MaxQueueSize = 100
+	}
+	if cfg.Notifications.Retry.MaxAge == 0 {
+		cfg.Notifications.Retry.MaxAge = 1 * time.Hour
+	}
+	if cfg.Notifications.DailySummary.Time == "" {
+		cfg.Notifications.DailySummary.Time = "09:00"
+	}
+	if cfg.Notifications.AsyncQueueSize == 0 {
+		cfg.Notifications.AsyncQueueSize = 100
+	}
+
+	// Cluster defaults
+	if cfg.Cluster.Backend == "" {
+		cfg.Cluster.Backend = "none"
+	}
+	if cfg.Cluster.LockName == "" {
+		cfg.Cluster.LockName = "go-magento-cron-monitor"
+	}
+
+	// Telemetry defaults
+	if cfg.Telemetry.ServiceName == "" {
+		cfg.Telemetry.ServiceName = "go-magento-cron-monitor"
+	}
 
 	// Validate
 	if err := validate(&cfg); err != nil {
@@ -158,8 +775,32 @@ func Load(configPath string) (*Config, error) {
 }
 
 func validate(cfg *Config) error {
-	if cfg.Database.Host == "" {
-		return fmt.Errorf("database.host is required")
+	if driver := cfg.Database.Driver; driver != "mysql" && driver != "postgres" {
+		return fmt.Errorf("database.driver must be 'mysql' or 'postgres'")
+	}
+	if cfg.Database.Host == "" && cfg.Database.Socket == "" {
+		return fmt.Errorf("database.host or database.socket is required")
+	}
+	if cfg.Database.MaxOpenConns < 0 || cfg.Database.MaxIdleConns < 0 {
+		return fmt.Errorf("database.max_open_conns and database.max_idle_conns must not be negative")
+	}
+	if cfg.Database.MaxIdleConns > cfg.Database.MaxOpenConns {
+		return fmt.Errorf("database.max_idle_conns must not exceed database.max_open_conns")
+	}
+	if cfg.Database.ConnMaxLifetime < 0 {
+		return fmt.Errorf("database.conn_max_lifetime must not be negative")
+	}
+	if *cfg.Database.ConnectRetries < 0 {
+		return fmt.Errorf("database.connect_retries must not be negative")
+	}
+	if cfg.Database.ConnectRetryDelay < 0 {
+		return fmt.Errorf("database.connect_retry_delay must not be negative")
+	}
+	if !tablePrefixPattern.MatchString(cfg.Database.TablePrefix) {
+		return fmt.Errorf("database.table_prefix must contain only letters, digits and underscores")
+	}
+	if err := validateDatabaseTLS(cfg.Database); err != nil {
+		return err
 	}
 	if cfg.Database.Name == "" {
 		return fmt.Errorf("database.name is required")
@@ -167,42 +808,720 @@ func validate(cfg *Config) error {
 	if cfg.Database.User == "" {
 		return fmt.Errorf("database.user is required")
 	}
-	if cfg.Logging.File == "" {
-		return fmt.Errorf("logging.file is required")
+	columns := cfg.Database.Columns
+	for field, name := range map[string]string{
+		"schedule_id":  columns.ScheduleID,
+		"job_code":     columns.JobCode,
+		"status":       columns.Status,
+		"messages":     columns.Messages,
+		"created_at":   columns.CreatedAt,
+		"scheduled_at": columns.ScheduledAt,
+		"executed_at":  columns.ExecutedAt,
+		"finished_at":  columns.FinishedAt,
+	} {
+		if !columnNamePattern.MatchString(name) {
+			return fmt.Errorf("database.columns.%s must contain only letters, digits and underscores", field)
+		}
+	}
+	if err := validateLogging(cfg.Logging); err != nil {
+		return err
+	}
+	if cfg.Monitor.Detection.IgnoreOlderThan < 0 {
+		return fmt.Errorf("monitor.detection.ignore_older_than must not be negative")
+	}
+	if counting := cfg.Monitor.Detection.ErrorCounting; counting != "consecutive" && counting != "windowed" {
+		return fmt.Errorf("monitor.detection.error_counting must be 'consecutive' or 'windowed'")
+	}
+	if ratio := cfg.Monitor.Detection.ErrorRatio; ratio < 0 || ratio > 1 {
+		return fmt.Errorf("monitor.detection.error_ratio must be between 0 and 1")
+	}
+	if rate := cfg.Monitor.Detection.MinSuccessRate; rate < 0 || rate > 1 {
+		return fmt.Errorf("monitor.detection.min_success_rate must be between 0 and 1")
+	}
+	if cfg.Monitor.Detection.MinSamples < 0 {
+		return fmt.Errorf("monitor.detection.min_samples must not be negative")
+	}
+	for group, patterns := range cfg.Monitor.JobGroups {
+		for _, pattern := range patterns {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("monitor.job_groups.%s: invalid pattern %q: %w", group, pattern, err)
+			}
+		}
+	}
+	for group, interval := range cfg.Monitor.GroupIntervals {
+		if _, ok := cfg.Monitor.JobGroups[group]; !ok {
+			return fmt.Errorf("monitor.group_intervals: group %q is not defined in monitor.job_groups", group)
+		}
+		if interval <= 0 {
+			return fmt.Errorf("monitor.group_intervals.%s must be positive", group)
+		}
+	}
+	for i, job := range cfg.Monitor.JobOverrides {
+		set := 0
+		for _, field := range []string{job.JobCode, job.Group, job.Match} {
+			if field != "" {
+				set++
+			}
+		}
+		if set != 1 {
+			return fmt.Errorf("monitor.job_overrides[%d]: exactly one of job_code, group or match is required", i)
+		}
+		if _, err := path.Match(job.Match, ""); err != nil {
+			return fmt.Errorf("monitor.job_overrides[%d]: invalid match pattern %q: %w", i, job.Match, err)
+		}
+		if _, ok := cfg.Monitor.JobGroups[strings.ToLower(job.Group)]; job.Group != "" && !ok {
+			return fmt.Errorf("monitor.job_overrides[%d]: group %q is not defined in monitor.job_groups", i, job.Group)
+		}
+		if job.ErrorCounting != nil && *job.ErrorCounting != "consecutive" && *job.ErrorCounting != "windowed" {
+			return fmt.Errorf("monitor.job_overrides[%d]: error_counting must be 'consecutive' or 'windowed'", i)
+		}
+		if job.ErrorRatio != nil && (*job.ErrorRatio <= 0 || *job.ErrorRatio > 1) {
+			return fmt.Errorf("monitor.job_overrides[%d]: error_ratio must be greater than 0 and at most 1", i)
+		}
+		if job.MinSamples != nil && *job.MinSamples < 0 {
+			return fmt.Errorf("monitor.job_overrides[%d]: min_samples must not be negative", i)
+		}
+		if job.MinSuccessRate != nil && (*job.MinSuccessRate < 0 || *job.MinSuccessRate > 1) {
+			return fmt.Errorf("monitor.job_overrides[%d]: min_success_rate must be between 0 and 1", i)
+		}
+		if job.AlertSuppressionWindow != nil && *job.AlertSuppressionWindow < 0 {
+			return fmt.Errorf("monitor.job_overrides[%d]: alert_suppression_window must not be negative", i)
+		}
+		if job.CadenceTolerance != nil && *job.CadenceTolerance < 0 {
+			return fmt.Errorf("monitor.job_overrides[%d]: cadence_tolerance must not be negative", i)
+		}
+	}
+	if field := cfg.Monitor.Detection.LookbackField; field != "created_at" && field != "scheduled_at" {
+		return fmt.Errorf("monitor.detection.lookback_field must be 'created_at' or 'scheduled_at'")
+	}
+	if mode := cfg.Monitor.Detection.SchedulerHealthMode; mode != "any" && mode != "all" {
+		return fmt.Errorf("monitor.detection.scheduler_health_mode must be 'any' or 'all'")
+	}
+	if mode := cfg.Monitor.Detection.Mode; mode != "rules" && mode != "score" {
+		return fmt.Errorf("monitor.detection.mode must be 'rules' or 'score'")
+	}
+	if cfg.Monitor.Detection.Scoring.Threshold < 0 {
+		return fmt.Errorf("monitor.detection.scoring.threshold must not be negative")
+	}
+	for i, window := range cfg.Monitor.Detection.SchedulerInactivityWindows {
+		if err := window.Window.Validate(); err != nil {
+			return fmt.Errorf("monitor.detection.scheduler_inactivity_windows[%d]: %w", i, err)
+		}
+		if window.InactivityMinutes <= 0 {
+			return fmt.Errorf("monitor.detection.scheduler_inactivity_windows[%d]: inactivity_minutes must be positive", i)
+		}
+	}
+	for i, window := range cfg.Monitor.Detection.MaintenanceWindows {
+		if err := window.Validate(); err != nil {
+			return fmt.Errorf("monitor.detection.maintenance_windows[%d]: %w", i, err)
+		}
+	}
+	if err := validateSeverities(cfg.Monitor.Detection.Severities); err != nil {
+		return err
+	}
+	if summary := cfg.Notifications.DailySummary; summary.Enabled {
+		if _, err := NextDailyTime(summary.Time, summary.Timezone, time.Now()); err != nil {
+			return fmt.Errorf("notifications.daily_summary: %w", err)
+		}
+	}
+	if cfg.Notifications.AsyncQueueSize < 0 {
+		return fmt.Errorf("notifications.async_queue_size must not be negative")
+	}
+	if cfg.Notifications.Retry.MaxQueueSize < 0 {
+		return fmt.Errorf("notifications.retry.max_queue_size must not be negative")
+	}
+	if warmup := cfg.Monitor.Detection.SchedulerWarmup; warmup != nil && *warmup < 0 {
+		return fmt.Errorf("monitor.detection.scheduler_warmup must not be negative")
+	}
+	if cfg.Monitor.Detection.SchedulerMinDistinctUpcoming < 0 {
+		return fmt.Errorf("monitor.detection.scheduler_min_distinct_upcoming must not be negative")
+	}
+	if cfg.Monitor.Detection.ScheduleIDJumpRatio != 0 && cfg.Monitor.Detection.ScheduleIDJumpRatio < 1 {
+		return fmt.Errorf("monitor.detection.schedule_id_jump_ratio must be 0 (disabled) or at least 1")
 	}
-	if cfg.Logging.Format != "json" && cfg.Logging.Format != "text" {
-		return fmt.Errorf("logging.format must be 'json' or 'text'")
+	if cfg.Monitor.CleanupInterval < 0 {
+		return fmt.Errorf("monitor.cleanup_interval must not be negative")
+	}
+	if cfg.Monitor.CleanupInterval > 0 && cfg.Monitor.CleanupOlderThan < cfg.Monitor.Detection.LookbackWindow {
+		return fmt.Errorf("monitor.cleanup_older_than must not be shorter than monitor.detection.lookback_window")
+	}
+	if cfg.Monitor.QueryTimeout < 0 {
+		return fmt.Errorf("monitor.query_timeout must not be negative")
+	}
+	if cfg.Monitor.HealthStaleAfter < 0 {
+		return fmt.Errorf("monitor.health_stale_after must not be negative")
+	}
+	if cfg.Monitor.IntervalJitter < 0 {
+		return fmt.Errorf("monitor.interval_jitter must not be negative")
+	}
+	if cfg.Monitor.IntervalJitter > cfg.Monitor.Interval/2 {
+		return fmt.Errorf("monitor.interval_jitter must be at most half of monitor.interval")
+	}
+	if cfg.Monitor.IntervalJitter > 0 && cfg.Monitor.AlignToInterval {
+		return fmt.Errorf("monitor.interval_jitter and monitor.align_to_interval can't be combined")
+	}
+	if *cfg.Monitor.FailureBackoffAfter < 0 {
+		return fmt.Errorf("monitor.failure_backoff_after must not be negative")
+	}
+	if cfg.Monitor.MaxBackoffInterval < 0 {
+		return fmt.Errorf("monitor.max_backoff_interval must not be negative")
+	}
+	for alias, canonical := range cfg.Monitor.Aliases {
+		if canonical == "" {
+			return fmt.Errorf("monitor.aliases.%s: canonical job code is required", alias)
+		}
+		if _, chained := cfg.Monitor.Aliases[strings.ToLower(canonical)]; chained {
+			return fmt.Errorf("monitor.aliases.%s: canonical job code %q is itself an alias", alias, canonical)
+		}
+	}
+	for i, pattern := range cfg.Monitor.CriticalJobs {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("monitor.critical_jobs[%d]: invalid pattern %q: %w", i, pattern, err)
+		}
+	}
+	for i, pattern := range cfg.Monitor.Detection.IgnoreJobs {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("monitor.detection.ignore_jobs[%d]: invalid pattern %q: %w", i, pattern, err)
+		}
+	}
+	for i, pattern := range cfg.Monitor.Detection.OnlyJobs {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("monitor.detection.only_jobs[%d]: invalid pattern %q: %w", i, pattern, err)
+		}
+	}
+	if cfg.Monitor.Detection.MaxInconsistentRows < 0 {
+		return fmt.Errorf("monitor.detection.max_inconsistent_rows must not be negative")
+	}
+	if cfg.Monitor.Detection.SchedulerAlertCooldown < 0 {
+		return fmt.Errorf("monitor.detection.scheduler_alert_cooldown must not be negative")
+	}
+	if cfg.Monitor.Detection.OrphanRunningTime < 0 {
+		return fmt.Errorf("monitor.detection.orphan_running_time must not be negative")
+	}
+	if cfg.Monitor.Detection.MaxScheduleLag < 0 {
+		return fmt.Errorf("monitor.detection.max_schedule_lag must not be negative")
+	}
+	if cfg.Monitor.Detection.AlertSuppressionWindow < 0 {
+		return fmt.Errorf("monitor.detection.alert_suppression_window must not be negative")
+	}
+	if cfg.Monitor.Detection.CadenceTolerance < 0 {
+		return fmt.Errorf("monitor.detection.cadence_tolerance must not be negative")
+	}
+	if err := validateEscalation("notifications.escalation", cfg.Notifications.Escalation); err != nil {
+		return err
+	}
+	if err := validateEscalation("notifications.scheduler_escalation", cfg.Notifications.SchedulerEscalation); err != nil {
+		return err
+	}
+	if cfg.Export.Interval < 0 {
+		return fmt.Errorf("export.interval must not be negative")
+	}
+	if backend := cfg.Cluster.Backend; backend != "none" && backend != "mysql" {
+		return fmt.Errorf("cluster.backend must be 'none' or 'mysql'")
+	}
+	if cfg.Cluster.Backend == "mysql" && cfg.Database.Driver != "mysql" {
+		return fmt.Errorf("cluster.backend 'mysql' requires database.driver 'mysql'")
+	}
+	// The advisory lock pins a pool connection for as long as it is held
+	if cfg.Cluster.Backend == "mysql" && cfg.Database.MaxOpenConns < 2 {
+		return fmt.Errorf("cluster.backend 'mysql' requires database.max_open_conns of at least 2 (the leader lock holds one connection)")
+	}
+	for i, job := range cfg.Monitor.ExpectedJobs {
+		if job.JobCode == "" {
+			return fmt.Errorf("monitor.expected_jobs[%d]: job_code is required", i)
+		}
+		if job.Interval < 0 || job.Interval > cfg.Monitor.Detection.LookbackWindow {
+			return fmt.Errorf("monitor.expected_jobs[%d]: interval must be between 0 and detection.lookback_window", i)
+		}
+	}
+	if *cfg.Notifications.Slack.MaxRetries < 0 {
+		return fmt.Errorf("notifications.slack.max_retries must not be negative")
+	}
+	if cfg.Notifications.Slack.DigestWindow < 0 {
+		return fmt.Errorf("notifications.slack.digest_window must not be negative")
+	}
+	if err := validateEmail(cfg.Notifications.Email); err != nil {
+		return err
+	}
+	if pd := cfg.Notifications.PagerDuty; pd.Enabled {
+		if pd.RoutingKey == "" {
+			return fmt.Errorf("notifications.pagerduty.routing_key is required")
+		}
+		if sev := pd.MinSeverity; sev != "critical" && sev != "error" && sev != "warning" && sev != "info" {
+			return fmt.Errorf("notifications.pagerduty.min_severity must be 'critical', 'error', 'warning' or 'info'")
+		}
+		if pd.Timeout < 0 {
+			return fmt.Errorf("notifications.pagerduty.timeout must not be negative")
+		}
+	}
+	if teams := cfg.Notifications.Teams; teams.Enabled {
+		if len(teams.WebhookURLs) == 0 {
+			return fmt.Errorf("notifications.teams.webhook_urls must list at least one webhook")
+		}
+		if teams.AlertCooldown < 0 || teams.RecoveryCooldown < 0 || teams.Timeout < 0 {
+			return fmt.Errorf("notifications.teams: cooldowns and timeout must not be negative")
+		}
+	}
+	if hook := cfg.Notifications.Webhook; hook.Enabled {
+		if len(hook.URLs) == 0 {
+			return fmt.Errorf("notifications.webhook.urls must list at least one URL")
+		}
+		if m := hook.Method; m != "POST" && m != "PUT" && m != "PATCH" {
+			return fmt.Errorf("notifications.webhook.method must be POST, PUT or PATCH")
+		}
+		if err := webhook.CheckTemplate(hook.Template); err != nil {
+			return fmt.Errorf("notifications.webhook.template: %w", err)
+		}
+		if hook.Timeout < 0 {
+			return fmt.Errorf("notifications.webhook.timeout must not be negative")
+		}
+	}
+	for i, route := range cfg.Notifications.Slack.Routes {
+		name := fmt.Sprintf("notifications.slack.routes[%d]", i)
+		if len(route.WebhookURLs) == 0 {
+			return fmt.Errorf("%s: webhook_urls is required", name)
+		}
+		match := route.Match
+		if match.JobCode == "" && match.CronGroup == "" && match.Severity == "" {
+			return fmt.Errorf("%s: match needs at least one of job_code, cron_group or severity", name)
+		}
+		if _, err := path.Match(match.JobCode, ""); err != nil {
+			return fmt.Errorf("%s: invalid job_code pattern %q: %w", name, match.JobCode, err)
+		}
+		if match.CronGroup != "" {
+			if _, ok := cfg.Monitor.JobGroups[strings.ToLower(match.CronGroup)]; !ok {
+				return fmt.Errorf("%s: cron_group %q is not defined in monitor.job_groups", name, match.CronGroup)
+			}
+		}
+		if sev := match.Severity; sev != "" && !validSeverity(sev) {
+			return fmt.Errorf("%s: severity must be 'critical', 'high', 'warning' or 'info'", name)
+		}
+	}
+	for i, route := range cfg.Notifications.Slack.ScheduleRoutes {
+		if err := route.Window.Validate(); err != nil {
+			return fmt.Errorf("notifications.slack.schedule_routes[%d]: %w", i, err)
+		}
+		if len(route.WebhookURLs) == 0 {
+			return fmt.Errorf("notifications.slack.schedule_routes[%d]: webhook_urls is required", i)
+		}
 	}
 	return nil
 }
 
-// GetDetectionConfig returns the effective detection configuration for a specific job
-// Priority: job_overrides > global defaults
-func (c *Config) GetDetectionConfig(jobCode string) DetectionConfig {
-	cfg := c.Monitor.Detection // Start with global defaults
+// validateEmail checks the SMTP settings of enabled email notifications
+func validateEmail(email EmailConfig) error {
+	if !email.Enabled {
+		return nil
+	}
+	if email.Host == "" {
+		return fmt.Errorf("notifications.email.host is required")
+	}
+	if email.From == "" {
+		return fmt.Errorf("notifications.email.from is required")
+	}
+	if len(email.To) == 0 {
+		return fmt.Errorf("notifications.email.to must list at least one recipient")
+	}
+	if tls := email.TLS; tls != "starttls" && tls != "tls" && tls != "none" {
+		return fmt.Errorf("notifications.email.tls must be 'starttls', 'tls' or 'none'")
+	}
+	if email.Port < 1 || email.Port > 65535 {
+		return fmt.Errorf("notifications.email.port must be between 1 and 65535")
+	}
+	if email.Timeout < 0 {
+		return fmt.Errorf("notifications.email.timeout must not be negative")
+	}
+	return nil
+}
 
-	// Apply job-specific overrides if available (highest priority)
+// Warnings returns non-fatal configuration problems, to be logged at startup
+func (c *Config) Warnings() []string {
+	var warnings []string
+	if c.Database.Socket != "" && c.Database.Host != "" {
+		warnings = append(warnings, "database.socket and database.host are both set; connecting through the socket, host and port are ignored")
+	}
+	return warnings
+}
+
+// StrictWarnings returns valid but suspicious settings, e.g. windows too short for the check interval
+func (c *Config) StrictWarnings() []string {
+	var warnings []string
+	interval := c.Monitor.Interval
+	detection := c.Monitor.Detection
+
+	if detection.LookbackWindow < interval {
+		warnings = append(warnings, fmt.Sprintf("monitor.detection.lookback_window (%s) is shorter than monitor.interval (%s); rows created between checks may never be analyzed", detection.LookbackWindow, interval))
+	}
+	if c.Monitor.QueryTimeout >= interval {
+		warnings = append(warnings, fmt.Sprintf("monitor.query_timeout (%s) is not shorter than monitor.interval (%s); a slow database delays the following checks", c.Monitor.QueryTimeout, interval))
+	}
+	if c.Monitor.HealthStaleAfter < interval {
+		warnings = append(warnings, fmt.Sprintf("monitor.health_stale_after (%s) is shorter than monitor.interval (%s); /healthz fails between checks", c.Monitor.HealthStaleAfter, interval))
+	}
+	if c.Notifications.Slack.Enabled && c.Notifications.Slack.AlertCooldown < interval {
+		warnings = append(warnings, fmt.Sprintf("notifications.slack.alert_cooldown (%s) is shorter than monitor.interval (%s); every check re-notifies stuck jobs", c.Notifications.Slack.AlertCooldown, interval))
+	}
+
+	// A run that stays running past the lookback window is no longer fetched, so it can't be flagged
+	scopes := []string{""}
 	for _, job := range c.Monitor.JobOverrides {
-		if job.JobCode == jobCode {
-			// Apply job-specific overrides
-			if job.MaxRunningTime != nil {
-				cfg.MaxRunningTime = *job.MaxRunningTime
-			}
-			if job.MaxPendingCount != nil {
-				cfg.MaxPendingCount = *job.MaxPendingCount
-			}
-			if job.ConsecutiveErrors != nil {
-				cfg.ConsecutiveErrors = *job.ConsecutiveErrors
+		if job.JobCode != "" {
+			scopes = append(scopes, job.JobCode)
+		}
+	}
+	for _, jobCode := range scopes {
+		jobDetection := c.GetDetectionConfig(jobCode)
+		if !Enabled(jobDetection.DetectLongRunning) || jobDetection.MaxRunningTime < detection.LookbackWindow {
+			continue
+		}
+		setting := "monitor.detection.max_running_time"
+		if jobCode != "" {
+			if jobDetection.MaxRunningTime == detection.MaxRunningTime {
+				continue
 			}
-			if job.MaxMissedCount != nil {
-				cfg.MaxMissedCount = *job.MaxMissedCount
+			setting = fmt.Sprintf("max_running_time of job %s", jobCode)
+		}
+		warnings = append(warnings, fmt.Sprintf("%s (%s) is not shorter than monitor.detection.lookback_window (%s); long-running jobs drop out of the lookback before they are flagged", setting, jobDetection.MaxRunningTime, detection.LookbackWindow))
+	}
+	return warnings
+}
+
+// JobGroup returns the monitor.job_groups group of a job code, or "" if it belongs to none
+// Groups are tried in name order, so a job matching several groups always resolves the same way
+func (c *Config) JobGroup(jobCode string) string {
+	if len(c.Monitor.JobGroups) == 0 || jobCode == "" {
+		return ""
+	}
+
+	groups := make([]string, 0, len(c.Monitor.JobGroups))
+	for group := range c.Monitor.JobGroups {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	for _, group := range groups {
+		for _, pattern := range c.Monitor.JobGroups[group] {
+			if matched, _ := path.Match(pattern, jobCode); matched {
+				return group
 			}
-			if job.ThresholdChecks != nil {
-				cfg.ThresholdChecks = *job.ThresholdChecks
+		}
+	}
+	return ""
+}
+
+// GroupInterval returns the check interval of a monitor.job_groups group
+// ok is false for groups without a monitor.group_intervals entry, which are checked every monitor.interval
+func (c *Config) GroupInterval(group string) (interval time.Duration, ok bool) {
+	if group == "" {
+		return c.Monitor.Interval, false
+	}
+	if interval, ok := c.Monitor.GroupIntervals[group]; ok {
+		return interval, true
+	}
+	return c.Monitor.Interval, false
+}
+
+// jobOverrides returns the overrides applying to a job, least specific first: its group's,
+// then those of matching patterns, then its own
+func (c *Config) jobOverrides(jobCode string) []JobOverrideConfig {
+	var overrides []JobOverrideConfig
+	if group := c.JobGroup(jobCode); group != "" {
+		for _, job := range c.Monitor.JobOverrides {
+			if job.Group != "" && strings.EqualFold(job.Group, group) {
+				overrides = append(overrides, job)
+				break
 			}
+		}
+	}
+
+	// Of overlapping patterns the most specific wins, on a tie the one listed first
+	var patterns []JobOverrideConfig
+	for _, job := range c.Monitor.JobOverrides {
+		if matched, _ := path.Match(job.Match, jobCode); job.Match != "" && matched {
+			patterns = append(patterns, job)
+		}
+	}
+	sort.SliceStable(patterns, func(i, j int) bool {
+		return patternSpecificity(patterns[i].Match) > patternSpecificity(patterns[j].Match)
+	})
+	for i := len(patterns) - 1; i >= 0; i-- {
+		overrides = append(overrides, patterns[i])
+	}
+
+	for _, job := range c.Monitor.JobOverrides {
+		if job.JobCode != "" && job.JobCode == jobCode {
+			overrides = append(overrides, job)
 			break
 		}
 	}
+	return overrides
+}
+
+// patternSpecificity ranks glob patterns by their literal characters, e.g. catalog_product_* over catalog_*
+func patternSpecificity(pattern string) int {
+	literal := 0
+	escaped := false
+	inClass := false
+	for _, r := range pattern {
+		switch {
+		case escaped:
+			escaped = false
+			literal++
+		case r == '\\':
+			escaped = true
+		case inClass:
+			inClass = r != ']'
+		case r == '[':
+			inClass = true
+		case r != '*' && r != '?':
+			literal++
+		}
+	}
+	return literal
+}
+
+// GetDetectionConfig returns the effective detection configuration for a specific job
+// Priority: critical_jobs > job_code overrides > match overrides > group overrides > global defaults
+func (c *Config) GetDetectionConfig(jobCode string) DetectionConfig {
+	cfg := c.Monitor.Detection // Start with global defaults
+
+	// Apply group, then job-specific overrides (highest priority)
+	for _, job := range c.jobOverrides(jobCode) {
+		if job.MaxRunningTime != nil {
+			cfg.MaxRunningTime = *job.MaxRunningTime
+		}
+		if job.MaxPendingCount != nil {
+			cfg.MaxPendingCount = *job.MaxPendingCount
+		}
+		if job.ConsecutiveErrors != nil {
+			cfg.ConsecutiveErrors = *job.ConsecutiveErrors
+		}
+		if job.ErrorCounting != nil {
+			cfg.ErrorCounting = *job.ErrorCounting
+		}
+		if job.ErrorRatio != nil {
+			cfg.ErrorRatio = *job.ErrorRatio
+		}
+		if job.MaxMissedCount != nil {
+			cfg.MaxMissedCount = *job.MaxMissedCount
+		}
+		if job.MinSuccessRate != nil {
+			cfg.MinSuccessRate = *job.MinSuccessRate
+		}
+		if job.MinSamples != nil {
+			cfg.MinSamples = *job.MinSamples
+		}
+		if job.ThresholdChecks != nil {
+			cfg.ThresholdChecks = *job.ThresholdChecks
+		}
+		if job.DetectLongRunning != nil {
+			cfg.DetectLongRunning = job.DetectLongRunning
+		}
+		if job.DetectPending != nil {
+			cfg.DetectPending = job.DetectPending
+		}
+		if job.DetectErrors != nil {
+			cfg.DetectErrors = job.DetectErrors
+		}
+		if job.DetectMissed != nil {
+			cfg.DetectMissed = job.DetectMissed
+		}
+		if job.PendingGrowthChecks != nil {
+			cfg.PendingGrowthChecks = *job.PendingGrowthChecks
+		}
+		if job.OrphanRunningTime != nil {
+			cfg.OrphanRunningTime = *job.OrphanRunningTime
+		}
+		if job.MaxScheduleLag != nil {
+			cfg.MaxScheduleLag = *job.MaxScheduleLag
+		}
+		if job.CadenceTolerance != nil {
+			cfg.CadenceTolerance = *job.CadenceTolerance
+		}
+		if job.MinCompletionTime != nil {
+			cfg.MinCompletionTime = *job.MinCompletionTime
+		}
+		if job.ShortRunStddev != nil {
+			cfg.ShortRunStddev = *job.ShortRunStddev
+		}
+		if job.RecoveryHold != nil {
+			cfg.RecoveryHold = *job.RecoveryHold
+		}
+		if job.AlertSuppressionWindow != nil {
+			cfg.AlertSuppressionWindow = *job.AlertSuppressionWindow
+		}
+	}
+
+	// Critical jobs alert on the first detection, even right after a recovery
+	if c.IsCriticalJob(jobCode) {
+		cfg.ThresholdChecks = 1
+		cfg.RecoveryHold = 0
+	}
 
 	return cfg
 }
+
+// IsCriticalJob reports whether a job code matches an entry of monitor.critical_jobs
+func (c *Config) IsCriticalJob(jobCode string) bool {
+	return matchesAny(c.Monitor.CriticalJobs, jobCode)
+}
+
+// IsMonitoredJob reports whether a job is analyzed at all
+// A job matching detection.ignore_jobs is skipped even if it also matches detection.only_jobs
+func (c *Config) IsMonitoredJob(jobCode string) bool {
+	if matchesAny(c.Monitor.Detection.IgnoreJobs, jobCode) {
+		return false
+	}
+	return len(c.Monitor.Detection.OnlyJobs) == 0 || matchesAny(c.Monitor.Detection.OnlyJobs, jobCode)
+}
+
+// matchesAny reports whether a job code matches one of the glob patterns
+func matchesAny(patterns []string, jobCode string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, jobCode); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Enabled reports whether a rule enable flag is set, treating an unset flag as enabled
+func Enabled(flag *bool) bool {
+	return flag == nil || *flag
+}
+
+// CanonicalJobCode resolves a renamed job code through monitor.aliases
+// Alias keys are matched case-insensitively since the config loader lowercases map keys
+func (c *Config) CanonicalJobCode(jobCode string) string {
+	if canonical, ok := c.Monitor.Aliases[strings.ToLower(jobCode)]; ok {
+		return canonical
+	}
+	return jobCode
+}
+
+// GetCooldownConfig returns the effective notification cooldowns for a specific job
+// Priority: critical_jobs > job_code overrides > group overrides > notifications.slack defaults
+func (c *Config) GetCooldownConfig(jobCode string) CooldownConfig {
+	return c.GetCooldownConfigWithDefaults(jobCode, CooldownConfig{
+		AlertCooldown:    c.Notifications.Slack.AlertCooldown,
+		RecoveryCooldown: c.Notifications.Slack.RecoveryCooldown,
+	})
+}
+
+// GetCooldownConfigWithDefaults applies job overrides and critical_jobs to a notifier's own default cooldowns
+func (c *Config) GetCooldownConfigWithDefaults(jobCode string, cfg CooldownConfig) CooldownConfig {
+	for _, job := range c.jobOverrides(jobCode) {
+		if job.AlertCooldown != nil {
+			cfg.AlertCooldown = *job.AlertCooldown
+		}
+		if job.RecoveryCooldown != nil {
+			cfg.RecoveryCooldown = *job.RecoveryCooldown
+		}
+	}
+
+	// Every new incident of a critical job is notified
+	if c.IsCriticalJob(jobCode) {
+		cfg.AlertCooldown = 0
+	}
+
+	return cfg
+}
+
+// MatchSlackRoute returns the webhooks of the first Slack route matching an alert
+// ok is false when no route matches, in which case the time-of-day routes apply
+func (c *Config) MatchSlackRoute(jobCode, cronGroup, severity string) (webhookURLs []string, name string, ok bool) {
+	for _, route := range c.Notifications.Slack.Routes {
+		match := route.Match
+		if match.JobCode != "" {
+			if matched, _ := path.Match(match.JobCode, jobCode); !matched {
+				continue
+			}
+		}
+		if match.CronGroup != "" && !strings.EqualFold(match.CronGroup, cronGroup) {
+			continue
+		}
+		if match.Severity != "" && match.Severity != severity {
+			continue
+		}
+		return route.WebhookURLs, route.Name, true
+	}
+	return nil, "", false
+}
+
+// GetSlackWebhookURLs returns the Slack webhooks to notify at the given time
+// The first schedule route whose window contains now wins; otherwise the default webhook_urls are used
+func (c *Config) GetSlackWebhookURLs(now time.Time) ([]string, string) {
+	for _, route := range c.Notifications.Slack.ScheduleRoutes {
+		if route.Window.Contains(now) {
+			return route.WebhookURLs, route.Name
+		}
+	}
+	return c.Notifications.Slack.WebhookURLs, "default"
+}
+
+// validateDatabaseTLS checks the TLS mode and that the referenced certificate files exist
+func validateDatabaseTLS(db DatabaseConfig) error {
+	tlsCfg := db.TLS
+	if !databaseTLSModes[tlsCfg.Mode] {
+		return fmt.Errorf("database.tls.mode must be one of disabled, preferred, required, verify-ca, verify-identity")
+	}
+	if tlsCfg.Mode == "preferred" && db.Driver == "postgres" {
+		return fmt.Errorf("database.tls.mode 'preferred' is not supported with database.driver 'postgres'")
+	}
+	if tlsCfg.Mode == "verify-ca" && tlsCfg.CACert == "" {
+		return fmt.Errorf("database.tls.ca_cert is required with database.tls.mode 'verify-ca'")
+	}
+	if (tlsCfg.ClientCert == "") != (tlsCfg.ClientKey == "") {
+		return fmt.Errorf("database.tls.client_cert and database.tls.client_key must be set together")
+	}
+	for field, file := range map[string]string{
+		"ca_cert":     tlsCfg.CACert,
+		"client_cert": tlsCfg.ClientCert,
+		"client_key":  tlsCfg.ClientKey,
+	} {
+		if file == "" {
+			continue
+		}
+		if _, err := os.Stat(file); err != nil {
+			return fmt.Errorf("database.tls.%s: %w", field, err)
+		}
+	}
+	return nil
+}
+
+// validateEscalation checks that escalation steps have webhooks and strictly increasing delays
+func validateEscalation(name string, steps []EscalationStep) error {
+	for i, step := range steps {
+		if step.After <= 0 {
+			return fmt.Errorf("%s[%d]: after must be positive", name, i)
+		}
+		if i > 0 && step.After <= steps[i-1].After {
+			return fmt.Errorf("%s[%d]: after must be greater than the previous step", name, i)
+		}
+		if len(step.WebhookURLs) == 0 {
+			return fmt.Errorf("%s[%d]: webhook_urls is required", name, i)
+		}
+	}
+	return nil
+}
+
+// ActiveMaintenanceWindow returns the first maintenance window containing now
+func (c *Config) ActiveMaintenanceWindow(now time.Time) (MaintenanceWindow, bool) {
+	for _, window := range c.Monitor.Detection.MaintenanceWindows {
+		if window.Contains(now) {
+			return window, true
+		}
+	}
+	return MaintenanceWindow{}, false
+}
+
+// GetSchedulerInactivityMinutes returns the scheduler inactivity threshold in effect at the given time
+// The first matching scheduler_inactivity_windows entry wins; otherwise scheduler_inactivity_minutes is used
+func (c *Config) GetSchedulerInactivityMinutes(now time.Time) int {
+	for _, window := range c.Monitor.Detection.SchedulerInactivityWindows {
+		if window.Window.Contains(now) {
+			return window.InactivityMinutes
+		}
+	}
+	return c.Monitor.Detection.SchedulerInactivityMinutes
+}
@@ -0,0 +1,236 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func duration(d time.Duration) *time.Duration {
+	return &d
+}
+
+func TestPatternSpecificity(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    int
+	}{
+		{"*", 0},
+		{"catalog_*", 8},
+		{"catalog_product_*", 16},
+		{"catalog_?_*", 9},
+		{"catalog_[ab]*", 8},
+		{`catalog\*`, 8},
+		{"sales_export", 12},
+	}
+
+	for _, tt := range tests {
+		if got := patternSpecificity(tt.pattern); got != tt.want {
+			t.Errorf("patternSpecificity(%q) = %d, want %d", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestGetDetectionConfigPrecedence(t *testing.T) {
+	cfg := &Config{
+		Monitor: MonitorConfig{
+			Detection: DetectionConfig{MaxRunningTime: 30 * time.Minute},
+			JobGroups: map[string][]string{
+				"catalog": {"catalog_*"},
+			},
+			JobOverrides: []JobOverrideConfig{
+				// Listed most specific first to show the order in the file doesn't matter
+				{JobCode: "catalog_product_alert", MaxRunningTime: duration(5 * time.Hour)},
+				{Match: "catalog_product_*", MaxRunningTime: duration(4 * time.Hour)},
+				{Match: "catalog_*", MaxRunningTime: duration(3 * time.Hour)},
+				{Group: "catalog", MaxRunningTime: duration(2 * time.Hour)},
+				// Equally specific overlapping patterns: the one listed first wins
+				{Match: "sales_*_export", MaxRunningTime: duration(6 * time.Hour)},
+				{Match: "sales_order_*", MaxRunningTime: duration(7 * time.Hour)},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		jobCode string
+		want    time.Duration
+	}{
+		{"global default", "newsletter_send_all", 30 * time.Minute},
+		{"group over global", "catalog_index_refresh_price", 3 * time.Hour},
+		{"more specific pattern over less specific one", "catalog_product_frontend_actions_flush", 4 * time.Hour},
+		{"job_code over patterns and group", "catalog_product_alert", 5 * time.Hour},
+		{"first listed of equally specific patterns", "sales_order_export", 6 * time.Hour},
+		{"only matching pattern", "sales_order_grid", 7 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cfg.GetDetectionConfig(tt.jobCode).MaxRunningTime; got != tt.want {
+				t.Errorf("max_running_time of %s = %s, want %s", tt.jobCode, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetDetectionConfigMergesLevels(t *testing.T) {
+	cfg := &Config{
+		Monitor: MonitorConfig{
+			Detection: DetectionConfig{MaxRunningTime: 30 * time.Minute, ThresholdChecks: 2},
+			JobGroups: map[string][]string{
+				"index": {"indexer_*"},
+			},
+			JobOverrides: []JobOverrideConfig{
+				{Group: "index", MaxRunningTime: duration(2 * time.Hour)},
+				{Match: "indexer_*", ThresholdChecks: intPtr(4)},
+			},
+		},
+	}
+
+	// Overrides only replace the settings they set, the others come from less specific levels
+	got := cfg.GetDetectionConfig("indexer_reindex_all_invalid")
+	if got.MaxRunningTime != 2*time.Hour {
+		t.Errorf("expected max_running_time from the group override, got %s", got.MaxRunningTime)
+	}
+	if got.ThresholdChecks != 4 {
+		t.Errorf("expected threshold_checks from the pattern override, got %d", got.ThresholdChecks)
+	}
+}
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func TestGetDetectionConfigCriticalJob(t *testing.T) {
+	cfg := &Config{
+		Monitor: MonitorConfig{
+			Detection:    DetectionConfig{ThresholdChecks: 2},
+			CriticalJobs: []string{"sales_*"},
+			JobOverrides: []JobOverrideConfig{
+				{JobCode: "sales_export", ThresholdChecks: intPtr(5)},
+			},
+		},
+	}
+
+	// critical_jobs wins over any override
+	if got := cfg.GetDetectionConfig("sales_export").ThresholdChecks; got != 1 {
+		t.Errorf("expected threshold_checks 1 for a critical job, got %d", got)
+	}
+	if got := cfg.GetDetectionConfig("catalog_export").ThresholdChecks; got != 2 {
+		t.Errorf("expected the global threshold_checks for other jobs, got %d", got)
+	}
+}
+
+func TestLoadClusterRequiresSpareConnection(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxOpenConns int
+		wantErr      bool
+	}{
+		{"single connection", 1, true},
+		{"two connections", 2, false},
+		{"default pool", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadTestConfig(t, "database:\n  host: localhost\n  user: test\n  name: magento\n  max_open_conns: "+strconv.Itoa(tt.maxOpenConns)+"\ncluster:\n  backend: mysql\n")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// loadTestConfig writes content, plus a log file setting, to a temporary config file and loads it
+func loadTestConfig(t *testing.T, content string) (*Config, error) {
+	t.Helper()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	content += "logging:\n  file: " + filepath.Join(dir, "monitor.log") + "\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return Load(path)
+}
+
+func TestLoadRejectsNegativeCadenceTolerance(t *testing.T) {
+	base := "database:\n  host: localhost\n  user: test\n  name: magento\nmonitor:\n"
+	tests := []struct {
+		name    string
+		monitor string
+		wantErr bool
+	}{
+		{"disabled", "  detection:\n    cadence_tolerance: 0\n", false},
+		{"positive", "  detection:\n    cadence_tolerance: 2.5\n", false},
+		{"negative", "  detection:\n    cadence_tolerance: -1\n", true},
+		{"negative override", "  job_overrides:\n    - job_code: sales_export\n      cadence_tolerance: -0.5\n", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadTestConfig(t, base+tt.monitor)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadClampsMaxBackoffInterval(t *testing.T) {
+	base := "database:\n  host: localhost\n  user: test\n  name: magento\nmonitor:\n  interval: 5m\n"
+	tests := []struct {
+		name    string
+		monitor string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"default", "", 15 * time.Minute, false},
+		{"longer cap", "  max_backoff_interval: 1h\n", time.Hour, false},
+		{"cap below interval", "  max_backoff_interval: 1m\n", 5 * time.Minute, false},
+		{"cap below interval without backoff", "  failure_backoff_after: 0\n  max_backoff_interval: 1m\n", 5 * time.Minute, false},
+		{"negative cap", "  max_backoff_interval: -1m\n", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := loadTestConfig(t, base+tt.monitor)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && cfg.Monitor.MaxBackoffInterval != tt.want {
+				t.Errorf("max_backoff_interval = %s, want %s", cfg.Monitor.MaxBackoffInterval, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadValidatesOverrideRatios(t *testing.T) {
+	base := "database:\n  host: localhost\n  user: test\n  name: magento\nmonitor:\n  job_overrides:\n    - job_code: sales_export\n"
+	tests := []struct {
+		name     string
+		override string
+		wantErr  bool
+	}{
+		{"error_ratio in range", "      error_ratio: 0.2\n", false},
+		{"error_ratio of one", "      error_ratio: 1\n", false},
+		{"zero error_ratio", "      error_ratio: 0\n", true},
+		{"negative error_ratio", "      error_ratio: -0.1\n", true},
+		{"error_ratio above one", "      error_ratio: 1.5\n", true},
+		{"min_samples", "      min_samples: 10\n", false},
+		{"zero min_samples", "      min_samples: 0\n", false},
+		{"negative min_samples", "      min_samples: -1\n", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := loadTestConfig(t, base+tt.override)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Load() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Logging targets, the values of logging.target
+const (
+	LogTargetFile   = "file"
+	LogTargetStdout = "stdout"
+	LogTargetSyslog = "syslog"
+)
+
+// defaultLogTargets are used when logging.target is not set
+var defaultLogTargets = []string{LogTargetFile, LogTargetStdout}
+
+// SyslogConfig controls the syslog logging target
+type SyslogConfig struct {
+	Facility string `mapstructure:"facility"` // e.g. daemon, user or local0-local7 (default: daemon)
+	Tag      string `mapstructure:"tag"`      // Program name of the messages (default: go-magento-cron-monitor)
+}
+
+// SyslogFacilities are the valid values of logging.syslog.facility
+var SyslogFacilities = []string{
+	"kern", "user", "mail", "daemon", "auth", "syslog", "lpr", "news", "uucp", "cron", "authpriv", "ftp",
+	"local0", "local1", "local2", "local3", "local4", "local5", "local6", "local7",
+}
+
+// timestampFormats are the named values of logging.timestamp_format
+var timestampFormats = map[string]string{
+	"rfc3339":       time.RFC3339,
+	"rfc3339_milli": "2006-01-02T15:04:05.000Z07:00",
+	"rfc3339_nano":  time.RFC3339Nano,
+}
+
+// Location returns the timezone of logging.timezone
+func (l LoggingConfig) Location() (*time.Location, error) {
+	switch strings.ToLower(l.Timezone) {
+	case "", "utc":
+		return time.UTC, nil
+	case "local":
+		return time.Local, nil
+	}
+	return time.LoadLocation(l.Timezone)
+}
+
+// TimestampLayout returns the Go time layout of logging.timestamp_format
+func (l LoggingConfig) TimestampLayout() string {
+	if l.TimestampFormat == "" {
+		return time.RFC3339
+	}
+	if layout, ok := timestampFormats[strings.ToLower(l.TimestampFormat)]; ok {
+		return layout
+	}
+	return l.TimestampFormat
+}
+
+// HasTarget reports whether log entries are written to a target (file, stdout or syslog)
+func (l LoggingConfig) HasTarget(target string) bool {
+	targets := l.Target
+	if len(targets) == 0 {
+		targets = defaultLogTargets
+	}
+	for _, t := range targets {
+		if t == target {
+			return true
+		}
+	}
+	return false
+}
+
+// WritesConsole reports whether log entries are written to stdout: logging.console if set,
+// otherwise whether stdout is a target
+func (l LoggingConfig) WritesConsole() bool {
+	if l.Console != nil {
+		return *l.Console
+	}
+	return l.HasTarget(LogTargetStdout)
+}
+
+// validateLogging checks the logging targets and their settings
+func validateLogging(logging LoggingConfig) error {
+	for _, target := range logging.Target {
+		if target != LogTargetFile && target != LogTargetStdout && target != LogTargetSyslog {
+			return fmt.Errorf("logging.target: unknown target %q (expected file, stdout or syslog)", target)
+		}
+	}
+	if logging.HasTarget(LogTargetFile) && logging.File == "" {
+		return fmt.Errorf("logging.file is required when logging to a file")
+	}
+	if logging.Format != "json" && logging.Format != "text" {
+		return fmt.Errorf("logging.format must be 'json' or 'text'")
+	}
+	if logging.AlertRateLimit < 0 {
+		return fmt.Errorf("logging.alert_rate_limit must not be negative")
+	}
+	if logging.AlertRateWindow < 0 {
+		return fmt.Errorf("logging.alert_rate_window must not be negative")
+	}
+	if _, err := logging.Location(); err != nil {
+		return fmt.Errorf("logging.timezone: %w", err)
+	}
+	// A custom layout without any reference-time element would print the same text for every entry
+	if layout := logging.TimestampLayout(); time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC).Format(layout) == layout {
+		return fmt.Errorf("logging.timestamp_format %q is neither rfc3339, rfc3339_milli, rfc3339_nano nor a Go time layout", logging.TimestampFormat)
+	}
+	if logging.HasTarget(LogTargetSyslog) {
+		valid := false
+		for _, facility := range SyslogFacilities {
+			if logging.Syslog.Facility == facility {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("logging.syslog.facility: unknown facility %q", logging.Syslog.Facility)
+		}
+	}
+	return nil
+}
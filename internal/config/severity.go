@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Detection types, the keys of monitor.detection.severities
+const (
+	DetectionScheduler           = "scheduler"
+	DetectionOrphanedRunning     = "orphaned_running"
+	DetectionLongRunning         = "long_running"
+	DetectionConsecutiveErrors   = "consecutive_errors"
+	DetectionSuccessRate         = "success_rate"
+	DetectionPendingAccumulation = "pending_accumulation"
+	DetectionPendingGrowth       = "pending_growth"
+	DetectionMissedExecutions    = "missed_executions"
+	DetectionSchedulingLatency   = "scheduling_latency"
+	DetectionShortCompletion     = "short_completion"
+	DetectionHealthScore         = "health_score"
+	DetectionMissingJob          = "missing_job"
+	DetectionDropout             = "dropout"
+	DetectionEmptySchedule       = "empty_schedule"
+	DetectionDataQuality         = "data_quality"
+)
+
+// defaultSeverities is the severity of each detection type unless overridden in monitor.detection.severities
+var defaultSeverities = map[string]string{
+	DetectionScheduler:           "critical",
+	DetectionOrphanedRunning:     "critical",
+	DetectionLongRunning:         "warning",
+	DetectionConsecutiveErrors:   "high",
+	DetectionSuccessRate:         "high",
+	DetectionPendingAccumulation: "warning",
+	DetectionPendingGrowth:       "warning",
+	DetectionMissedExecutions:    "warning",
+	DetectionSchedulingLatency:   "warning",
+	DetectionShortCompletion:     "warning",
+	DetectionHealthScore:         "warning",
+	DetectionMissingJob:          "warning",
+	DetectionDropout:             "warning",
+	DetectionEmptySchedule:       "warning",
+	DetectionDataQuality:         "info",
+}
+
+// validSeverity reports whether s is an alert severity
+func validSeverity(s string) bool {
+	return s == "critical" || s == "high" || s == "warning" || s == "info"
+}
+
+// AlertSeverity returns the severity of alerts raised by a detection type
+func (c *Config) AlertSeverity(detection string) string {
+	if severity, ok := c.Monitor.Detection.Severities[detection]; ok {
+		return severity
+	}
+	return defaultSeverities[detection]
+}
+
+// AlertSeverities returns the effective severity of every detection type, defaults included
+func (c *Config) AlertSeverities() map[string]string {
+	severities := make(map[string]string, len(defaultSeverities))
+	for detection := range defaultSeverities {
+		severities[detection] = c.AlertSeverity(detection)
+	}
+	return severities
+}
+
+// validateSeverities checks monitor.detection.severities for unknown detection types and severities
+func validateSeverities(severities map[string]string) error {
+	for detection, severity := range severities {
+		if _, ok := defaultSeverities[detection]; !ok {
+			known := make([]string, 0, len(defaultSeverities))
+			for name := range defaultSeverities {
+				known = append(known, name)
+			}
+			sort.Strings(known)
+			return fmt.Errorf("monitor.detection.severities: unknown detection %q (expected one of %s)", detection, strings.Join(known, ", "))
+		}
+		if !validSeverity(severity) {
+			return fmt.Errorf("monitor.detection.severities.%s: severity must be 'critical', 'high', 'warning' or 'info'", detection)
+		}
+	}
+	return nil
+}
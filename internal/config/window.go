@@ -0,0 +1,184 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeWindow represents a recurring daily time range in a given timezone
+// A window whose end is before its start wraps around midnight (e.g. 20:00-08:00)
+type TimeWindow struct {
+	Start    string `mapstructure:"start"`    // HH:MM
+	End      string `mapstructure:"end"`      // HH:MM
+	Timezone string `mapstructure:"timezone"` // IANA name, defaults to local time
+}
+
+// Contains reports whether t falls inside the window
+func (w TimeWindow) Contains(t time.Time) bool {
+	loc, err := w.location()
+	if err != nil {
+		return false
+	}
+	start, err := parseClock(w.Start)
+	if err != nil {
+		return false
+	}
+	end, err := parseClock(w.End)
+	if err != nil {
+		return false
+	}
+
+	local := t.In(loc)
+	minute := local.Hour()*60 + local.Minute()
+
+	if start <= end {
+		return minute >= start && minute < end
+	}
+	// Window wraps around midnight
+	return minute >= start || minute < end
+}
+
+// Validate checks the window's times and timezone
+func (w TimeWindow) Validate() error {
+	if _, err := parseClock(w.Start); err != nil {
+		return fmt.Errorf("invalid start %q: %w", w.Start, err)
+	}
+	if _, err := parseClock(w.End); err != nil {
+		return fmt.Errorf("invalid end %q: %w", w.End, err)
+	}
+	if _, err := w.location(); err != nil {
+		return fmt.Errorf("invalid timezone %q: %w", w.Timezone, err)
+	}
+	return nil
+}
+
+// location returns the window's timezone
+func (w TimeWindow) location() (*time.Location, error) {
+	if w.Timezone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(w.Timezone)
+}
+
+// parseClock parses HH:MM into minutes since midnight
+func parseClock(value string) (int, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("expected HH:MM")
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// NextDailyTime returns the next occurrence of the HH:MM clock time in the timezone strictly after t
+func NextDailyTime(clock, timezone string, after time.Time) (time.Time, error) {
+	minutes, err := parseClock(clock)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: %w", clock, err)
+	}
+	loc, err := TimeWindow{Timezone: timezone}.location()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timezone %q: %w", timezone, err)
+	}
+
+	local := after.In(loc)
+	next := time.Date(local.Year(), local.Month(), local.Day(), minutes/60, minutes%60, 0, 0, loc)
+	if !next.After(after) {
+		next = time.Date(local.Year(), local.Month(), local.Day()+1, minutes/60, minutes%60, 0, 0, loc)
+	}
+	return next, nil
+}
+
+// weekdays maps the day names accepted in maintenance windows to weekdays
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// MaintenanceWindow is a period during which alerts are suppressed, either recurring
+// (start/end clock times on the listed days) or one-off (start_at/end_at timestamps)
+type MaintenanceWindow struct {
+	Name string `mapstructure:"name"`
+	// Recurring window; a window wrapping around midnight belongs to the day it starts on
+	Window TimeWindow `mapstructure:",squash"`
+	Days   []string   `mapstructure:"days"` // mon..sun, empty = every day
+	// One-off window, unquoted RFC 3339 timestamps in YAML
+	StartAt time.Time `mapstructure:"start_at"`
+	EndAt   time.Time `mapstructure:"end_at"`
+}
+
+// OneOff reports whether the window is a one-off window rather than a recurring one
+func (w MaintenanceWindow) OneOff() bool {
+	return !w.StartAt.IsZero() || !w.EndAt.IsZero()
+}
+
+// Label returns the window's name, or a description of its times when it is unnamed
+func (w MaintenanceWindow) Label() string {
+	if w.Name != "" {
+		return w.Name
+	}
+	if w.OneOff() {
+		return w.StartAt.Format(time.RFC3339) + " - " + w.EndAt.Format(time.RFC3339)
+	}
+	return w.Window.Start + "-" + w.Window.End
+}
+
+// Contains reports whether t falls inside the window
+func (w MaintenanceWindow) Contains(t time.Time) bool {
+	if w.OneOff() {
+		return !t.Before(w.StartAt) && t.Before(w.EndAt)
+	}
+
+	if !w.Window.Contains(t) {
+		return false
+	}
+	if len(w.Days) == 0 {
+		return true
+	}
+
+	// After midnight, a wrapping window is still the previous day's
+	loc, _ := w.Window.location()
+	local := t.In(loc)
+	start, _ := parseClock(w.Window.Start)
+	day := local.Weekday()
+	if local.Hour()*60+local.Minute() < start {
+		day = (day + 6) % 7
+	}
+	for _, name := range w.Days {
+		if weekdays[strings.ToLower(name)] == day {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the window's times, days and timezone
+func (w MaintenanceWindow) Validate() error {
+	if w.OneOff() {
+		if w.Window.Start != "" || w.Window.End != "" || len(w.Days) > 0 {
+			return fmt.Errorf("start_at/end_at can't be combined with start, end or days")
+		}
+		if w.StartAt.IsZero() || w.EndAt.IsZero() {
+			return fmt.Errorf("a one-off window needs both start_at and end_at")
+		}
+		if !w.EndAt.After(w.StartAt) {
+			return fmt.Errorf("end_at must be after start_at")
+		}
+		return nil
+	}
+
+	if err := w.Window.Validate(); err != nil {
+		return err
+	}
+	for _, name := range w.Days {
+		if _, ok := weekdays[strings.ToLower(name)]; !ok {
+			return fmt.Errorf("invalid day %q: expected mon, tue, wed, thu, fri, sat or sun", name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package cronalert
+
+import (
+	"fmt"
+	"time"
+)
+
+// Type distinguishes alerts from recoveries
+type Type string
+
+const (
+	Alerting    Type = "alerting"
+	NotAlerting Type = "not_alerting"
+)
+
+// String returns the alert type's name
+func (t Type) String() string {
+	return string(t)
+}
+
+// Validate returns an error for anything but the declared alert types
+func (t Type) Validate() error {
+	switch t {
+	case Alerting, NotAlerting:
+		return nil
+	}
+	return fmt.Errorf("unknown alert type %q", string(t))
+}
+
+// Alert is a cron job alert or recovery as delivered by the notifiers
+type Alert struct {
+	Type          Type
+	CronCode      string // e.g., "indexer_reindex_all_invalid"
+	CronGroup     string // Group from monitor.job_groups, empty if none
+	Status        string // e.g., "pending", "running", "missed"
+	LastExecution time.Time
+	StuckDuration time.Duration // For recovery notifications
+	Timestamp     time.Time
+
+	// Enhanced fields for detailed alerts
+	RunningTime      *time.Duration
+	ScheduledAt      *time.Time
+	Reason           string
+	Reasons          []string // All reasons of a combined alert; rendered as a list instead of Reason when set
+	ConsecutiveStuck int
+	PendingCount     int
+	ErrorCount       int
+	MissedCount      int
+
+	// CompletionTime is how long the recovering run took (recovery notifications)
+	CompletionTime *time.Duration
+
+	// Metadata holds static instance-wide key/values (e.g. region, cluster)
+	Metadata map[string]string
+	// MagentoVersion is the version of the monitored Magento installation, if known
+	MagentoVersion string
+
+	// ErrorMessage is the last error output of the job, if any
+	ErrorMessage string
+	// Truncated is set when sections were shortened to fit the message size limit
+	Truncated bool
+
+	// EscalationLevel is the escalation step (1-based) of an escalation re-notification, 0 otherwise
+	EscalationLevel int
+	// Critical is set for jobs listed in monitor.critical_jobs
+	Critical bool
+	// Severity comes from the detection (monitor.detection.severities) or DeriveSeverity;
+	// recoveries carry the severity of the alert they resolve
+	Severity string
+}
+
+// Alert severities, from most to least severe
+const (
+	SeverityCritical = "critical"
+	SeverityHigh     = "high"
+	SeverityWarning  = "warning"
+	SeverityInfo     = "info"
+)
+
+// SeverityIcon returns the emoji alert headers use for a severity
+func SeverityIcon(severity string) string {
+	switch severity {
+	case SeverityCritical:
+		return "🔥"
+	case SeverityWarning:
+		return "⚠️"
+	case SeverityInfo:
+		return "ℹ️"
+	}
+	return "🚨"
+}
+
+// dataQualityStatuses are statuses of alerts about inconsistent cron_schedule data rather than failing jobs
+var dataQualityStatuses = map[string]bool{
+	"future_executed_at":  true,
+	"null_scheduled_at":   true,
+	"inconsistent_timing": true,
+	"schedule_id_reset":   true,
+	"schedule_id_jump":    true,
+	"irregular_cadence":   true,
+}
+
+// DeriveSeverity derives an alert's severity: critical for critical jobs, otherwise the
+// severity set by the detection, falling back to critical for the scheduler, high for
+// failing jobs, info for data-quality findings and warning for everything else
+func DeriveSeverity(alert Alert) string {
+	switch {
+	case alert.Critical:
+		return SeverityCritical
+	case alert.Severity != "":
+		return alert.Severity
+	case alert.CronCode == "SCHEDULER":
+		return SeverityCritical
+	case alert.Status == "error":
+		return SeverityHigh
+	case dataQualityStatuses[alert.Status]:
+		return SeverityInfo
+	}
+	return SeverityWarning
+}
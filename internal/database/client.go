@@ -1,11 +1,17 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
 	"github.com/fabio/go-magento-cron-monitor/internal/config"
 )
 
@@ -16,43 +22,220 @@ type CronSchedule struct {
 	Status      string
 	Messages    sql.NullString
 	CreatedAt   time.Time
-	ScheduledAt time.Time
+	ScheduledAt sql.NullTime // NULL only in malformed rows
 	ExecutedAt  sql.NullTime
 	FinishedAt  sql.NullTime
+	CronGroup   string // Resolved from monitor.job_groups, Magento doesn't store it in cron_schedule
+}
+
+// DedupSchedules removes repeated schedule_ids, keeping the first occurrence
+// It returns the unique schedules and the number of duplicates dropped
+func DedupSchedules(schedules []*CronSchedule) ([]*CronSchedule, int) {
+	seen := make(map[int]bool, len(schedules))
+	unique := make([]*CronSchedule, 0, len(schedules))
+	for _, s := range schedules {
+		if seen[s.ScheduleID] {
+			continue
+		}
+		seen[s.ScheduleID] = true
+		unique = append(unique, s)
+	}
+	return unique, len(schedules) - len(unique)
 }
 
 // Client wraps database operations
 type Client struct {
-	db *sql.DB
+	db     *sql.DB
+	cols   config.ColumnsConfig
+	driver string // mysql or postgres
+	prefix string // Magento table prefix
 }
 
+// RetryPolicy controls how the initial connection is retried
+type RetryPolicy struct {
+	MaxAttempts  int           // Total attempts including the first; 1 or less disables retries
+	InitialDelay time.Duration // Delay before the first retry
+	MaxDelay     time.Duration // Upper bound of the delay (0 = unbounded)
+	Multiplier   float64       // Factor applied to the delay after each retry (values below 1 keep it constant)
+}
+
+// RetryFunc is called after a failed connection attempt, before waiting delay for the next one
+type RetryFunc func(attempt int, delay time.Duration, err error)
+
 // NewClient creates a new database client
 func NewClient(cfg config.DatabaseConfig) (*Client, error) {
-	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/%s?parseTime=true",
+	return NewClientWithRetry(context.Background(), cfg, RetryPolicy{}, nil)
+}
+
+// NewClientWithRetry creates a new database client, retrying the connection test with exponential backoff
+// This covers a database that is still starting up, e.g. during container orchestration; cancelling ctx stops retrying
+func NewClientWithRetry(ctx context.Context, cfg config.DatabaseConfig, policy RetryPolicy, onRetry RetryFunc) (*Client, error) {
+	driver := cfg.Driver
+	if driver == "" {
+		driver = "mysql"
+	}
+
+	dsn, err := dataSourceName(driver, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// Configure connection pool (defaults are applied when the config is loaded)
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+	// Test the connection
+	if err := pingWithRetry(ctx, db, policy, onRetry); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &Client{db: db, cols: cfg.Columns, driver: driver, prefix: cfg.TablePrefix}, nil
+}
+
+// pingWithRetry pings the database until it succeeds or the policy's attempts are exhausted
+func pingWithRetry(ctx context.Context, db *sql.DB, policy RetryPolicy, onRetry RetryFunc) error {
+	delay := policy.InitialDelay
+	for attempt := 1; ; attempt++ {
+		err := db.PingContext(ctx)
+		if err == nil {
+			return nil
+		}
+		if attempt >= policy.MaxAttempts {
+			if attempt == 1 {
+				return fmt.Errorf("failed to ping database: %w", err)
+			}
+			return fmt.Errorf("failed to ping database after %d attempts: %w", attempt, err)
+		}
+
+		if onRetry != nil {
+			onRetry(attempt, delay, err)
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return fmt.Errorf("failed to ping database after %d attempts: %w", attempt, ctx.Err())
+		}
+
+		if policy.Multiplier > 1 {
+			delay = time.Duration(float64(delay) * policy.Multiplier)
+		}
+		if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+			delay = policy.MaxDelay
+		}
+	}
+}
+
+// dataSourceName builds the connection string for the driver
+// parseTime is MySQL-only; PostgreSQL timestamps are always scanned as time.Time,
+// and without a tls.mode its TLS mode follows the libpq defaults (e.g. the PGSSLMODE environment variable)
+func dataSourceName(driver string, cfg config.DatabaseConfig) (string, error) {
+	if driver == "postgres" {
+		params := postgresSSLParams(cfg.TLS)
+		dsn := url.URL{
+			Scheme: "postgres",
+			User:   url.UserPassword(cfg.User, cfg.Password),
+			Host:   net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+			Path:   "/" + cfg.Name,
+		}
+		if cfg.Socket != "" {
+			// libpq takes the directory containing the socket as host
+			dsn.Host = ""
+			params.Set("host", cfg.Socket)
+		}
+		dsn.RawQuery = params.Encode()
+		return dsn.String(), nil
+	}
+
+	address := fmt.Sprintf("tcp(%s:%d)", cfg.Host, cfg.Port)
+	if cfg.Socket != "" {
+		address = fmt.Sprintf("unix(%s)", cfg.Socket)
+	}
+	dsn := fmt.Sprintf("%s:%s@%s/%s?parseTime=true",
 		cfg.User,
 		cfg.Password,
-		cfg.Host,
-		cfg.Port,
+		address,
 		cfg.Name,
 	)
 
-	db, err := sql.Open("mysql", dsn)
+	tlsParam, err := mysqlTLSParam(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
+		return "", err
 	}
+	if tlsParam != "" {
+		dsn += "&tls=" + url.QueryEscape(tlsParam)
+	}
+	return dsn, nil
+}
 
-	// Configure connection pool
-	db.SetMaxOpenConns(10)
-	db.SetMaxIdleConns(5)
-	db.SetConnMaxLifetime(5 * time.Minute)
+// col returns the quoted name of a cron_schedule column
+// Names are validated as plain identifiers when the config is loaded
+func (c *Client) col(name string) string {
+	if c.driver == "postgres" {
+		return `"` + name + `"`
+	}
+	return "`" + name + "`"
+}
 
-	// Test the connection
-	if err := db.Ping(); err != nil {
-		db.Close()
-		return nil, fmt.Errorf("failed to ping database: %w", err)
+// table returns the quoted name of a Magento table, including the configured table prefix
+// The prefix is validated as a plain identifier when the config is loaded
+func (c *Client) table(name string) string {
+	return c.col(c.prefix + name)
+}
+
+// rebind rewrites ? placeholders into the driver's bind syntax
+func (c *Client) rebind(query string) string {
+	if c.driver != "postgres" {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// minutesAgo returns an SQL expression for the database time a bound number of minutes ago
+func (c *Client) minutesAgo() string {
+	if c.driver == "postgres" {
+		return "NOW() - CAST(? AS integer) * INTERVAL '1 minute'"
+	}
+	return "DATE_SUB(NOW(), INTERVAL ? MINUTE)"
+}
+
+// minutesAhead returns an SQL expression for the database time a bound number of minutes ahead
+func (c *Client) minutesAhead() string {
+	if c.driver == "postgres" {
+		return "NOW() + CAST(? AS integer) * INTERVAL '1 minute'"
 	}
+	return "DATE_ADD(NOW(), INTERVAL ? MINUTE)"
+}
 
-	return &Client{db: db}, nil
+// scheduleColumns returns the SELECT list matching the scan order of CronSchedule
+func (c *Client) scheduleColumns() string {
+	return strings.Join([]string{
+		c.col(c.cols.ScheduleID),
+		c.col(c.cols.JobCode),
+		c.col(c.cols.Status),
+		c.col(c.cols.Messages),
+		c.col(c.cols.CreatedAt),
+		c.col(c.cols.ScheduledAt),
+		c.col(c.cols.ExecutedAt),
+		c.col(c.cols.FinishedAt),
+	}, ", ")
 }
 
 // Close closes the database connection
@@ -61,37 +244,121 @@ func (c *Client) Close() error {
 }
 
 // Ping tests the database connection
-func (c *Client) Ping() error {
-	return c.db.Ping()
+func (c *Client) Ping(ctx context.Context) error {
+	return c.db.PingContext(ctx)
 }
 
 // GetCronScheduleCount returns the total number of cron_schedule records
-func (c *Client) GetCronScheduleCount() (int, error) {
+func (c *Client) GetCronScheduleCount(ctx context.Context) (int, error) {
 	var count int
-	err := c.db.QueryRow("SELECT COUNT(*) FROM cron_schedule").Scan(&count)
+	err := c.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+c.table("cron_schedule")).Scan(&count)
 	return count, err
 }
 
+// GetMagentoVersion reads the Magento version from a default-scope core_config_data path
+func (c *Client) GetMagentoVersion(ctx context.Context, configPath string) (string, error) {
+	var version sql.NullString
+	err := c.db.QueryRowContext(ctx,
+		c.rebind("SELECT value FROM "+c.table("core_config_data")+" WHERE path = ? AND scope = 'default' AND scope_id = 0"),
+		configPath,
+	).Scan(&version)
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to query magento version: %w", err)
+	}
+	return version.String, nil
+}
+
+// GetCronGroupConfig returns the default-scope system/cron/<group>/<setting> values of core_config_data by group
+// Magento only stores settings that differ from a group's defaults there, and never which jobs belong to a group
+func (c *Client) GetCronGroupConfig(ctx context.Context) (map[string]map[string]string, error) {
+	rows, err := c.db.QueryContext(ctx,
+		"SELECT path, value FROM "+c.table("core_config_data")+" WHERE path LIKE 'system/cron/%' AND scope = 'default' AND scope_id = 0",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cron group config: %w", err)
+	}
+	defer rows.Close()
+
+	groups := make(map[string]map[string]string)
+	for rows.Next() {
+		var configPath string
+		var value sql.NullString
+		if err := rows.Scan(&configPath, &value); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		parts := strings.Split(strings.TrimPrefix(configPath, "system/cron/"), "/")
+		if len(parts) != 2 || parts[0] == "" {
+			continue
+		}
+		if groups[parts[0]] == nil {
+			groups[parts[0]] = make(map[string]string)
+		}
+		groups[parts[0]][parts[1]] = value.String
+	}
+	return groups, rows.Err()
+}
+
+// GetJobCodes returns the distinct job codes in cron_schedule, sorted
+func (c *Client) GetJobCodes(ctx context.Context) ([]string, error) {
+	query := fmt.Sprintf("SELECT DISTINCT %[1]s FROM %[2]s ORDER BY %[1]s", c.col(c.cols.JobCode), c.table("cron_schedule"))
+	rows, err := c.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query job codes: %w", err)
+	}
+	defer rows.Close()
+
+	var jobCodes []string
+	for rows.Next() {
+		var jobCode string
+		if err := rows.Scan(&jobCode); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		jobCodes = append(jobCodes, jobCode)
+	}
+	return jobCodes, rows.Err()
+}
+
+// GetJobScheduleCount returns the total number of cron_schedule records for a job code
+func (c *Client) GetJobScheduleCount(ctx context.Context, jobCode string) (int, error) {
+	var count int
+	query := fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE %s = ?", c.table("cron_schedule"), c.col(c.cols.JobCode))
+	err := c.db.QueryRowContext(ctx, c.rebind(query), jobCode).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query job schedule count: %w", err)
+	}
+	return count, nil
+}
+
+// lookbackFields lists the fields the lookback window may apply to
+var lookbackFields = map[string]bool{
+	"created_at":   true,
+	"scheduled_at": true,
+}
+
 // GetRecentCronSchedules retrieves cron schedules within the lookback window
-func (c *Client) GetRecentCronSchedules(lookbackWindow time.Duration) ([]*CronSchedule, error) {
-	cutoffTime := time.Now().Add(-lookbackWindow)
-
-	query := `
-		SELECT 
-			schedule_id,
-			job_code,
-			status,
-			messages,
-			created_at,
-			scheduled_at,
-			executed_at,
-			finished_at
-		FROM cron_schedule
-		WHERE created_at >= ?
-		ORDER BY created_at DESC
-	`
-
-	rows, err := c.db.Query(query, cutoffTime)
+// lookbackField selects the timestamp field the window applies to (created_at or scheduled_at)
+func (c *Client) GetRecentCronSchedules(ctx context.Context, lookbackWindow time.Duration, lookbackField string) ([]*CronSchedule, error) {
+	if !lookbackFields[lookbackField] {
+		return nil, fmt.Errorf("unsupported lookback field: %q", lookbackField)
+	}
+	column := c.cols.CreatedAt
+	if lookbackField == "scheduled_at" {
+		column = c.cols.ScheduledAt
+	}
+
+	cutoffTime := time.Now().Add(-lookbackWindow).UTC()
+
+	query := fmt.Sprintf(`
+		SELECT %[1]s
+		FROM %[3]s
+		WHERE %[2]s >= ?
+		ORDER BY %[2]s DESC
+	`, c.scheduleColumns(), c.col(column), c.table("cron_schedule"))
+
+	rows, err := c.db.QueryContext(ctx, c.rebind(query), cutoffTime)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query cron_schedule: %w", err)
 	}
@@ -124,23 +391,15 @@ func (c *Client) GetRecentCronSchedules(lookbackWindow time.Duration) ([]*CronSc
 }
 
 // GetRunningCronJobs retrieves all cron jobs currently in running status
-func (c *Client) GetRunningCronJobs() ([]*CronSchedule, error) {
-	query := `
-		SELECT 
-			schedule_id,
-			job_code,
-			status,
-			messages,
-			created_at,
-			scheduled_at,
-			executed_at,
-			finished_at
-		FROM cron_schedule
-		WHERE status = 'running'
-		ORDER BY executed_at ASC
-	`
-
-	rows, err := c.db.Query(query)
+func (c *Client) GetRunningCronJobs(ctx context.Context) ([]*CronSchedule, error) {
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %s
+		WHERE %s = 'running'
+		ORDER BY %s ASC
+	`, c.scheduleColumns(), c.table("cron_schedule"), c.col(c.cols.Status), c.col(c.cols.ExecutedAt))
+
+	rows, err := c.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query running cron jobs: %w", err)
 	}
@@ -168,27 +427,28 @@ func (c *Client) GetRunningCronJobs() ([]*CronSchedule, error) {
 	return schedules, nil
 }
 
-// GetJobHistory retrieves recent history for a specific job code
-func (c *Client) GetJobHistory(jobCode string, lookbackWindow time.Duration, limit int) ([]*CronSchedule, error) {
-	cutoffTime := time.Now().Add(-lookbackWindow)
-
-	query := `
-		SELECT 
-			schedule_id,
-			job_code,
-			status,
-			messages,
-			created_at,
-			scheduled_at,
-			executed_at,
-			finished_at
-		FROM cron_schedule
-		WHERE job_code = ? AND created_at >= ?
-		ORDER BY created_at DESC
+// GetJobHistory retrieves recent history for a specific job code, newest first
+// A non-empty status limits the history to rows with that status
+func (c *Client) GetJobHistory(ctx context.Context, jobCode string, lookbackWindow time.Duration, limit int, status string) ([]*CronSchedule, error) {
+	cutoffTime := time.Now().Add(-lookbackWindow).UTC()
+
+	args := []interface{}{jobCode, cutoffTime}
+	statusFilter := ""
+	if status != "" {
+		statusFilter = fmt.Sprintf("AND %s = ?", c.col(c.cols.Status))
+		args = append(args, status)
+	}
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM %[4]s
+		WHERE %[2]s = ? AND %[3]s >= ? %[5]s
+		ORDER BY %[3]s DESC
 		LIMIT ?
-	`
+	`, c.scheduleColumns(), c.col(c.cols.JobCode), c.col(c.cols.CreatedAt), c.table("cron_schedule"), statusFilter)
 
-	rows, err := c.db.Query(query, jobCode, cutoffTime, limit)
+	rows, err := c.db.QueryContext(ctx, c.rebind(query), args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query job history: %w", err)
 	}
@@ -217,15 +477,15 @@ func (c *Client) GetJobHistory(jobCode string, lookbackWindow time.Duration, lim
 }
 
 // GetPendingJobCounts returns count of pending jobs grouped by job_code
-func (c *Client) GetPendingJobCounts() (map[string]int, error) {
-	query := `
-		SELECT job_code, COUNT(*) as count
-		FROM cron_schedule
-		WHERE status = 'pending'
-		GROUP BY job_code
-	`
-
-	rows, err := c.db.Query(query)
+func (c *Client) GetPendingJobCounts(ctx context.Context) (map[string]int, error) {
+	query := fmt.Sprintf(`
+		SELECT %[1]s, COUNT(*) as count
+		FROM %[3]s
+		WHERE %[2]s = 'pending'
+		GROUP BY %[1]s
+	`, c.col(c.cols.JobCode), c.col(c.cols.Status), c.table("cron_schedule"))
+
+	rows, err := c.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query pending job counts: %w", err)
 	}
@@ -244,37 +504,68 @@ func (c *Client) GetPendingJobCounts() (map[string]int, error) {
 	return counts, nil
 }
 
-// GetRecentlyCreatedJobCount returns count of jobs created within the specified time window
-func (c *Client) GetRecentlyCreatedJobCount(minutes int) (int, error) {
-	query := `
-		SELECT COUNT(*) 
-		FROM cron_schedule 
-		WHERE created_at >= DATE_SUB(NOW(), INTERVAL ? MINUTE)
-	`
+// SchedulerActivity summarizes recent scheduler output
+type SchedulerActivity struct {
+	Created          int // Jobs created within the inactivity window
+	Upcoming         int // Pending jobs scheduled within the lookahead window
+	DistinctUpcoming int // Distinct job codes among the upcoming pending jobs
+}
 
-	var count int
-	err := c.db.QueryRow(query, minutes).Scan(&count)
+// GetSchedulerActivity returns, in a single round trip, the jobs created within the last
+// createdMinutes and the pending jobs scheduled within the next upcomingMinutes
+func (c *Client) GetSchedulerActivity(ctx context.Context, createdMinutes, upcomingMinutes int) (SchedulerActivity, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			(SELECT COUNT(*)
+				FROM %[1]s
+				WHERE %[2]s >= %[3]s),
+			COUNT(*),
+			COUNT(DISTINCT %[4]s)
+		FROM %[1]s
+		WHERE %[5]s = 'pending'
+		AND %[6]s BETWEEN NOW() AND %[7]s
+	`, c.table("cron_schedule"), c.col(c.cols.CreatedAt), c.minutesAgo(), c.col(c.cols.JobCode), c.col(c.cols.Status), c.col(c.cols.ScheduledAt), c.minutesAhead())
+
+	var activity SchedulerActivity
+	err := c.db.QueryRowContext(ctx, c.rebind(query), createdMinutes, upcomingMinutes).Scan(&activity.Created, &activity.Upcoming, &activity.DistinctUpcoming)
 	if err != nil {
-		return 0, fmt.Errorf("failed to query recently created jobs: %w", err)
+		return SchedulerActivity{}, fmt.Errorf("failed to query scheduler activity: %w", err)
 	}
 
-	return count, nil
+	return activity, nil
 }
 
-// GetUpcomingPendingJobCount returns count of pending jobs scheduled in the near future
-func (c *Client) GetUpcomingPendingJobCount(minutes int) (int, error) {
-	query := `
-		SELECT COUNT(*) 
-		FROM cron_schedule 
-		WHERE status = 'pending' 
-		AND scheduled_at BETWEEN NOW() AND DATE_ADD(NOW(), INTERVAL ? MINUTE)
-	`
-
-	var count int
-	err := c.db.QueryRow(query, minutes).Scan(&count)
-	if err != nil {
-		return 0, fmt.Errorf("failed to query upcoming pending jobs: %w", err)
+// deleteBatchSize caps the rows removed per DELETE statement, keeping lock times short on large tables
+const deleteBatchSize = 1000
+
+// DeleteSchedulesOlderThan removes finished schedules (success, error, missed) created more than age ago
+// Running and pending rows are never touched. Rows are deleted in batches; on error the number of
+// rows already removed is returned along with the error
+func (c *Client) DeleteSchedulesOlderThan(ctx context.Context, age time.Duration) (int64, error) {
+	cutoffTime := time.Now().Add(-age).UTC()
+
+	condition := fmt.Sprintf("%s IN ('success', 'error', 'missed') AND %s < ?", c.col(c.cols.Status), c.col(c.cols.CreatedAt))
+	query := fmt.Sprintf("DELETE FROM %s WHERE %s LIMIT %d", c.table("cron_schedule"), condition, deleteBatchSize)
+	if c.driver == "postgres" {
+		// PostgreSQL has no DELETE ... LIMIT
+		query = fmt.Sprintf("DELETE FROM %[1]s WHERE %[2]s IN (SELECT %[2]s FROM %[1]s WHERE %[3]s LIMIT %[4]d)",
+			c.table("cron_schedule"), c.col(c.cols.ScheduleID), condition, deleteBatchSize)
 	}
+	query = c.rebind(query)
 
-	return count, nil
+	var total int64
+	for {
+		result, err := c.db.ExecContext(ctx, query, cutoffTime)
+		if err != nil {
+			return total, fmt.Errorf("failed to delete old cron schedules: %w", err)
+		}
+		deleted, err := result.RowsAffected()
+		if err != nil {
+			return total, fmt.Errorf("failed to count deleted cron schedules: %w", err)
+		}
+		total += deleted
+		if deleted < deleteBatchSize {
+			return total, nil
+		}
+	}
 }
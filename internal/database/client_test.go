@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDedupSchedules(t *testing.T) {
+	first := &CronSchedule{ScheduleID: 1, JobCode: "sales_export", Status: "running"}
+	second := &CronSchedule{ScheduleID: 2, JobCode: "catalog_index", Status: "pending"}
+	duplicate := &CronSchedule{ScheduleID: 1, JobCode: "sales_export", Status: "success"}
+
+	unique, dropped := DedupSchedules([]*CronSchedule{first, second, duplicate, second})
+	if dropped != 2 {
+		t.Errorf("expected 2 duplicates dropped, got %d", dropped)
+	}
+	if len(unique) != 2 || unique[0] != first || unique[1] != second {
+		t.Fatalf("expected the first occurrence of each schedule_id in order, got %+v", unique)
+	}
+}
+
+func TestDedupSchedulesWithoutDuplicates(t *testing.T) {
+	schedules := []*CronSchedule{{ScheduleID: 1}, {ScheduleID: 2}, {ScheduleID: 3}}
+
+	unique, dropped := DedupSchedules(schedules)
+	if dropped != 0 || len(unique) != len(schedules) {
+		t.Errorf("expected all %d schedules kept, got %d (%d dropped)", len(schedules), len(unique), dropped)
+	}
+}
+
+func TestPingWithRetryStopsOnCancel(t *testing.T) {
+	// Nothing listens on port 1, so every ping fails right away
+	db, err := sql.Open("mysql", "user:pass@tcp(127.0.0.1:1)/magento?timeout=1s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	policy := RetryPolicy{MaxAttempts: 5, InitialDelay: time.Hour}
+	retries := 0
+	start := time.Now()
+	err = pingWithRetry(ctx, db, policy, func(attempt int, delay time.Duration, err error) {
+		retries++
+		cancel()
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected a cancellation error, got %v", err)
+	}
+	if retries != 1 {
+		t.Errorf("expected to stop after the first retry, got %d", retries)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("expected the retry wait to end on cancel, took %s", elapsed)
+	}
+}
@@ -0,0 +1,79 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+)
+
+// AdvisoryLock is a named MySQL advisory lock (GET_LOCK) held on a dedicated connection
+// MySQL releases the lock when that connection closes, so a crashed holder frees it automatically
+type AdvisoryLock struct {
+	db   *sql.DB
+	name string
+	conn *sql.Conn
+	mu   sync.Mutex
+}
+
+// NewAdvisoryLock creates an advisory lock with the given name
+func (c *Client) NewAdvisoryLock(name string) *AdvisoryLock {
+	return &AdvisoryLock{db: c.db, name: name}
+}
+
+// TryAcquire takes the lock without waiting, or confirms it is still held
+// It returns false when another session holds the lock
+func (l *AdvisoryLock) TryAcquire(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn != nil {
+		// Confirm the lock survived, the connection may have been dropped
+		var holder sql.NullBool
+		err := l.conn.QueryRowContext(ctx, "SELECT IS_USED_LOCK(?) = CONNECTION_ID()", l.name).Scan(&holder)
+		if err == nil && holder.Valid && holder.Bool {
+			return true, nil
+		}
+		l.conn.Close()
+		l.conn = nil
+		if err != nil {
+			return false, fmt.Errorf("failed to check advisory lock: %w", err)
+		}
+	}
+
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get connection for advisory lock: %w", err)
+	}
+
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 0)", l.name).Scan(&acquired); err != nil {
+		conn.Close()
+		return false, fmt.Errorf("failed to acquire advisory lock: %w", err)
+	}
+	if !acquired.Valid || acquired.Int64 != 1 {
+		conn.Close()
+		return false, nil
+	}
+
+	l.conn = conn
+	return true, nil
+}
+
+// Release gives up the lock if it is held
+func (l *AdvisoryLock) Release() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil {
+		return nil
+	}
+
+	_, err := l.conn.ExecContext(context.Background(), "DO RELEASE_LOCK(?)", l.name)
+	l.conn.Close()
+	l.conn = nil
+	if err != nil {
+		return fmt.Errorf("failed to release advisory lock: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// MinDurationSamples is the number of runs below which duration statistics are flagged as unreliable
+const MinDurationSamples = 5
+
+// JobDurationStats summarizes the runtimes (finished_at - executed_at) of a job's successful runs
+type JobDurationStats struct {
+	JobCode    string
+	Samples    int
+	Min        time.Duration
+	Avg        time.Duration
+	Max        time.Duration
+	P95        time.Duration
+	LowSamples bool // Fewer than MinDurationSamples runs, so the figures are noisy
+}
+
+// GetJobDurationStats returns runtime statistics per job code for successful runs executed within the lookback window
+// Percentiles aren't portable SQL, so the runtimes are aggregated here rather than in the query
+func (c *Client) GetJobDurationStats(ctx context.Context, lookbackWindow time.Duration) (map[string]*JobDurationStats, error) {
+	cutoffTime := time.Now().Add(-lookbackWindow).UTC()
+
+	query := fmt.Sprintf(`
+		SELECT %[1]s, %[2]s, %[3]s
+		FROM %[5]s
+		WHERE %[4]s = 'success'
+		AND %[2]s IS NOT NULL AND %[3]s IS NOT NULL
+		AND %[2]s >= ?
+	`, c.col(c.cols.JobCode), c.col(c.cols.ExecutedAt), c.col(c.cols.FinishedAt), c.col(c.cols.Status), c.table("cron_schedule"))
+
+	rows, err := c.db.QueryContext(ctx, c.rebind(query), cutoffTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query job durations: %w", err)
+	}
+	defer rows.Close()
+
+	durations := make(map[string][]time.Duration)
+	for rows.Next() {
+		var jobCode string
+		var executedAt, finishedAt time.Time
+		if err := rows.Scan(&jobCode, &executedAt, &finishedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+		// Rows finishing before they started are clock skew, not runtimes
+		if d := finishedAt.Sub(executedAt); d >= 0 {
+			durations[jobCode] = append(durations[jobCode], d)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	stats := make(map[string]*JobDurationStats, len(durations))
+	for jobCode, values := range durations {
+		stats[jobCode] = durationStats(jobCode, values)
+	}
+	return stats, nil
+}
+
+// durationStats computes the statistics of a non-empty set of runtimes
+func durationStats(jobCode string, values []time.Duration) *JobDurationStats {
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	var total time.Duration
+	for _, d := range values {
+		total += d
+	}
+
+	// Nearest-rank percentile
+	rank := int(math.Ceil(0.95*float64(len(values)))) - 1
+
+	return &JobDurationStats{
+		JobCode:    jobCode,
+		Samples:    len(values),
+		Min:        values[0],
+		Avg:        total / time.Duration(len(values)),
+		Max:        values[len(values)-1],
+		P95:        values[rank],
+		LowSamples: len(values) < MinDurationSamples,
+	}
+}
@@ -0,0 +1,120 @@
+package database
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/config"
+	"github.com/go-sql-driver/mysql"
+)
+
+// tlsConfigName is the name the custom TLS configuration is registered under with the mysql driver
+const tlsConfigName = "cron-monitor"
+
+// mysqlTLSParam returns the tls DSN parameter for the configured mode
+// Modes that verify the server or present a client certificate register a custom tls.Config
+func mysqlTLSParam(cfg config.DatabaseConfig) (string, error) {
+	tlsCfg := cfg.TLS
+	switch tlsCfg.Mode {
+	case "", "disabled":
+		return "", nil
+	case "preferred":
+		// Encrypt if the server supports it, without verification
+		return "preferred", nil
+	}
+
+	tc := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if tlsCfg.ClientCert != "" {
+		cert, err := tls.LoadX509KeyPair(tlsCfg.ClientCert, tlsCfg.ClientKey)
+		if err != nil {
+			return "", fmt.Errorf("failed to load database client certificate: %w", err)
+		}
+		tc.Certificates = []tls.Certificate{cert}
+	}
+
+	var roots *x509.CertPool
+	if tlsCfg.CACert != "" {
+		pem, err := os.ReadFile(tlsCfg.CACert)
+		if err != nil {
+			return "", fmt.Errorf("failed to read database CA certificate: %w", err)
+		}
+		roots = x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(pem) {
+			return "", fmt.Errorf("no certificates found in database CA certificate %s", tlsCfg.CACert)
+		}
+	}
+
+	switch tlsCfg.Mode {
+	case "required":
+		// Encrypt, but accept any server certificate
+		tc.InsecureSkipVerify = true
+	case "verify-ca":
+		// Verify the chain against the CA but not the hostname, which crypto/tls can't do on its own
+		tc.InsecureSkipVerify = true
+		tc.VerifyPeerCertificate = verifyChain(roots)
+	case "verify-identity":
+		tc.RootCAs = roots // nil uses the system roots
+		tc.ServerName = cfg.Host
+	default:
+		return "", fmt.Errorf("unsupported database.tls.mode: %q", tlsCfg.Mode)
+	}
+
+	if err := mysql.RegisterTLSConfig(tlsConfigName, tc); err != nil {
+		return "", fmt.Errorf("failed to register database TLS config: %w", err)
+	}
+	return tlsConfigName, nil
+}
+
+// verifyChain returns a certificate check that verifies the server chain against roots, ignoring the hostname
+func verifyChain(roots *x509.CertPool) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+		if len(rawCerts) == 0 {
+			return errors.New("server presented no certificate")
+		}
+
+		certs := make([]*x509.Certificate, len(rawCerts))
+		for i, raw := range rawCerts {
+			cert, err := x509.ParseCertificate(raw)
+			if err != nil {
+				return fmt.Errorf("failed to parse server certificate: %w", err)
+			}
+			certs[i] = cert
+		}
+
+		intermediates := x509.NewCertPool()
+		for _, cert := range certs[1:] {
+			intermediates.AddCert(cert)
+		}
+		_, err := certs[0].Verify(x509.VerifyOptions{Roots: roots, Intermediates: intermediates})
+		return err
+	}
+}
+
+// postgresSSLModes maps tls.mode to libpq's sslmode
+var postgresSSLModes = map[string]string{
+	"disabled":        "disable",
+	"required":        "require",
+	"verify-ca":       "verify-ca",
+	"verify-identity": "verify-full",
+}
+
+// postgresSSLParams returns the sslmode and certificate DSN parameters for PostgreSQL
+func postgresSSLParams(tlsCfg config.DatabaseTLSConfig) url.Values {
+	params := url.Values{}
+	if mode, ok := postgresSSLModes[tlsCfg.Mode]; ok {
+		params.Set("sslmode", mode)
+	}
+	if tlsCfg.CACert != "" {
+		params.Set("sslrootcert", tlsCfg.CACert)
+	}
+	if tlsCfg.ClientCert != "" {
+		params.Set("sslcert", tlsCfg.ClientCert)
+		params.Set("sslkey", tlsCfg.ClientKey)
+	}
+	return params
+}
@@ -0,0 +1,144 @@
+package email
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/cronalert"
+)
+
+// TLS modes for the SMTP connection
+const (
+	TLSModeNone     = "none"     // Plain connection
+	TLSModeSTARTTLS = "starttls" // Upgrade a plain connection with STARTTLS
+	TLSModeTLS      = "tls"      // Implicit TLS (SMTPS)
+)
+
+// Config represents email notification configuration
+type Config struct {
+	Enabled      bool          `yaml:"enabled"`
+	Host         string        `yaml:"host"`
+	Port         int           `yaml:"port"`
+	Username     string        `yaml:"username"`
+	Password     string        `yaml:"password"`
+	From         string        `yaml:"from"`
+	To           []string      `yaml:"to"`
+	TLSMode      string        `yaml:"tls"`
+	SendRecovery bool          `yaml:"send_recovery"`
+	Timeout      time.Duration `yaml:"timeout"`
+}
+
+// Client sends cron alerts by email over SMTP
+type Client struct {
+	config Config
+}
+
+// New creates a new email client
+func New(config Config) *Client {
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second
+	}
+	if config.TLSMode == "" {
+		config.TLSMode = TLSModeSTARTTLS
+	}
+
+	return &Client{config: config}
+}
+
+// SendAlert emails a cron alert to all configured recipients
+func (c *Client) SendAlert(ctx context.Context, alert cronalert.Alert) error {
+	if !c.config.Enabled {
+		return nil
+	}
+
+	if len(c.config.To) == 0 {
+		return fmt.Errorf("no email recipients configured")
+	}
+
+	message, err := FormatAlert(alert, c.config.From, c.config.To)
+	if err != nil {
+		return err
+	}
+
+	return c.send(ctx, message)
+}
+
+// send delivers a formatted message to all recipients in a single SMTP session
+// Cancelling ctx aborts the session by closing the connection
+func (c *Client) send(ctx context.Context, message []byte) error {
+	addr := net.JoinHostPort(c.config.Host, strconv.Itoa(c.config.Port))
+	dialer := &net.Dialer{Timeout: c.config.Timeout}
+	tlsConfig := &tls.Config{ServerName: c.config.Host}
+
+	var conn net.Conn
+	var err error
+	if c.config.TLSMode == TLSModeTLS {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: tlsConfig}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server %s: %w", addr, err)
+	}
+	// Bound the whole session, not just the dial
+	if err := conn.SetDeadline(time.Now().Add(c.config.Timeout)); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to set SMTP deadline: %w", err)
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	client, err := smtp.NewClient(conn, c.config.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer client.Close()
+
+	if c.config.TLSMode == TLSModeSTARTTLS {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("STARTTLS failed: %w", err)
+		}
+	}
+
+	if c.config.Username != "" {
+		auth := smtp.PlainAuth("", c.config.Username, c.config.Password, c.config.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(c.config.From); err != nil {
+		return fmt.Errorf("SMTP MAIL FROM rejected: %w", err)
+	}
+	for _, to := range c.config.To {
+		if err := client.Rcpt(to); err != nil {
+			return fmt.Errorf("SMTP recipient %s rejected: %w", to, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("SMTP DATA failed: %w", err)
+	}
+	if _, err := w.Write(message); err != nil {
+		w.Close()
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("SMTP server rejected email: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// GetConfig returns the client configuration
+func (c *Client) GetConfig() Config {
+	return c.config
+}
@@ -0,0 +1,260 @@
+package email
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"mime"
+	"mime/quotedprintable"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/cronalert"
+	"github.com/fabio/go-magento-cron-monitor/internal/slack"
+)
+
+// field is a labelled value shown in both the text and HTML bodies
+type field struct {
+	Label string
+	Value string
+}
+
+// content is the rendered alert, independent of the body format
+type content struct {
+	Subject string
+	Heading string
+	Fields  []field
+	Reasons []string
+	Error   string
+	Footer  []string
+}
+
+// FormatAlert renders a cron alert as a complete multipart (text and HTML) email
+func FormatAlert(alert cronalert.Alert, from string, to []string) ([]byte, error) {
+	if err := alert.Type.Validate(); err != nil {
+		return nil, err
+	}
+
+	var c content
+	if alert.Type == cronalert.Alerting {
+		c = alertingContent(alert)
+	} else {
+		c = notAlertingContent(alert)
+	}
+
+	boundary, err := newBoundary()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(to, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", c.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", alert.Timestamp.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%q\r\n\r\n", boundary)
+
+	if err := writePart(&buf, boundary, "text/plain", renderText(c)); err != nil {
+		return nil, err
+	}
+	if err := writePart(&buf, boundary, "text/html", renderHTML(c)); err != nil {
+		return nil, err
+	}
+	fmt.Fprintf(&buf, "--%s--\r\n", boundary)
+
+	return buf.Bytes(), nil
+}
+
+// alertingContent builds the content of an alerting notification
+func alertingContent(alert cronalert.Alert) content {
+	heading := cronalert.SeverityIcon(alert.Severity) + " Cron Job Alert"
+	subject := fmt.Sprintf("[ALERT] Cron job %s is alerting", alert.CronCode)
+	if alert.Critical {
+		heading = "🔥 Critical Cron Job Alert"
+		subject = fmt.Sprintf("[CRITICAL] Cron job %s is alerting", alert.CronCode)
+	}
+
+	fields := jobFields(alert, "🔴 Alerting")
+	fields = append(fields, field{"Consecutive Issues", fmt.Sprintf("%d", alert.ConsecutiveStuck)})
+
+	scheduledAt := "N/A"
+	if alert.ScheduledAt != nil && !alert.ScheduledAt.IsZero() {
+		scheduledAt = formatTime(*alert.ScheduledAt)
+	}
+	runningTime := "N/A"
+	if alert.RunningTime != nil {
+		runningTime = slack.FormatDuration(*alert.RunningTime)
+	}
+	fields = append(fields,
+		field{"Scheduled At", scheduledAt},
+		field{"Last Execution", lastExecution(alert)},
+		field{"Running Time", runningTime},
+	)
+
+	reasons := alert.Reasons
+	if len(reasons) == 0 && alert.Reason != "" {
+		reasons = []string{alert.Reason}
+	}
+
+	return content{
+		Subject: subject,
+		Heading: heading,
+		Fields:  fields,
+		Reasons: reasons,
+		Error:   alert.ErrorMessage,
+		Footer:  footer(alert, "Alerted at "+formatTime(alert.Timestamp)),
+	}
+}
+
+// notAlertingContent builds the content of a recovery notification
+func notAlertingContent(alert cronalert.Alert) content {
+	fields := jobFields(alert, "🟢 Not Alerting")
+	fields = append(fields,
+		field{"Was Alerting For", slack.FormatDuration(alert.StuckDuration)},
+		field{"Last Successful Execution", lastExecution(alert)},
+	)
+	if alert.CompletionTime != nil {
+		fields = append(fields, field{"Completed In", slack.FormatDuration(*alert.CompletionTime)})
+	}
+
+	return content{
+		Subject: fmt.Sprintf("[RESOLVED] Cron job %s is no longer alerting", alert.CronCode),
+		Heading: "✅ Cron Job No Longer Alerting",
+		Fields:  fields,
+		Footer:  footer(alert, "No longer alerting at "+formatTime(alert.Timestamp)),
+	}
+}
+
+// jobFields returns the fields identifying the job
+func jobFields(alert cronalert.Alert, status string) []field {
+	fields := []field{
+		{"Cron Job", alert.CronCode},
+		{"Monitor Status", status},
+	}
+	if alert.CronGroup != "" {
+		fields = append(fields, field{"Cron Group", alert.CronGroup})
+	}
+	return fields
+}
+
+// footer returns the trailing context lines: timestamp, metadata, Magento version and escalation level
+func footer(alert cronalert.Alert, timestampText string) []string {
+	lines := []string{timestampText}
+
+	if len(alert.Metadata) > 0 {
+		keys := make([]string, 0, len(alert.Metadata))
+		for k := range alert.Metadata {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		pairs := make([]string, 0, len(keys))
+		for _, k := range keys {
+			pairs = append(pairs, fmt.Sprintf("%s: %s", k, alert.Metadata[k]))
+		}
+		lines = append(lines, strings.Join(pairs, " · "))
+	}
+
+	if alert.MagentoVersion != "" {
+		lines = append(lines, "Magento "+alert.MagentoVersion)
+	}
+
+	if alert.EscalationLevel > 0 {
+		lines = append(lines, fmt.Sprintf("Escalation level %d: still alerting after %s", alert.EscalationLevel, slack.FormatDuration(alert.StuckDuration)))
+	}
+
+	return lines
+}
+
+// renderText renders the plain text body
+func renderText(c content) string {
+	var b strings.Builder
+	b.WriteString(c.Heading + "\n\n")
+	for _, f := range c.Fields {
+		fmt.Fprintf(&b, "%s: %s\n", f.Label, f.Value)
+	}
+	if len(c.Reasons) > 0 {
+		b.WriteString("\nProblem Details:\n")
+		for _, reason := range c.Reasons {
+			b.WriteString("- " + reason + "\n")
+		}
+	}
+	if c.Error != "" {
+		b.WriteString("\nError Message:\n" + c.Error + "\n")
+	}
+	b.WriteString("\n--\n")
+	for _, line := range c.Footer {
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// renderHTML renders the HTML body, escaping every value
+func renderHTML(c content) string {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html><body style=\"font-family: sans-serif;\">\n")
+	fmt.Fprintf(&b, "<h2>%s</h2>\n<table cellpadding=\"4\">\n", html.EscapeString(c.Heading))
+	for _, f := range c.Fields {
+		fmt.Fprintf(&b, "<tr><td><strong>%s</strong></td><td>%s</td></tr>\n", html.EscapeString(f.Label), html.EscapeString(f.Value))
+	}
+	b.WriteString("</table>\n")
+	if len(c.Reasons) > 0 {
+		b.WriteString("<h3>Problem Details</h3>\n<ul>\n")
+		for _, reason := range c.Reasons {
+			fmt.Fprintf(&b, "<li>%s</li>\n", html.EscapeString(reason))
+		}
+		b.WriteString("</ul>\n")
+	}
+	if c.Error != "" {
+		fmt.Fprintf(&b, "<h3>Error Message</h3>\n<pre>%s</pre>\n", html.EscapeString(c.Error))
+	}
+	b.WriteString("<hr>\n")
+	for _, line := range c.Footer {
+		fmt.Fprintf(&b, "<p style=\"color: #666; font-size: small;\">%s</p>\n", html.EscapeString(line))
+	}
+	b.WriteString("</body></html>\n")
+	return b.String()
+}
+
+// writePart writes one quoted-printable encoded part of a multipart message
+func writePart(buf *bytes.Buffer, boundary, contentType, body string) error {
+	fmt.Fprintf(buf, "--%s\r\n", boundary)
+	fmt.Fprintf(buf, "Content-Type: %s; charset=utf-8\r\n", contentType)
+	buf.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+
+	w := quotedprintable.NewWriter(buf)
+	if _, err := w.Write([]byte(body)); err != nil {
+		return fmt.Errorf("failed to encode email body: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("failed to encode email body: %w", err)
+	}
+	buf.WriteString("\r\n")
+	return nil
+}
+
+// newBoundary returns a random multipart boundary
+func newBoundary() (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate MIME boundary: %w", err)
+	}
+	return "cron-monitor-" + hex.EncodeToString(b), nil
+}
+
+// lastExecution formats the alert's last execution time
+func lastExecution(alert cronalert.Alert) string {
+	if alert.LastExecution.IsZero() {
+		return "Never"
+	}
+	return formatTime(alert.LastExecution)
+}
+
+// formatTime formats a timestamp in the timezone of logging.timezone, like the Slack messages
+func formatTime(t time.Time) string {
+	return slack.FormatTime(t)
+}
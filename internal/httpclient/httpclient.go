@@ -0,0 +1,32 @@
+package httpclient
+
+import (
+	"net/http"
+	"time"
+)
+
+// Config holds HTTP transport settings for a notifier
+type Config struct {
+	Timeout          time.Duration
+	MaxIdleConns     int
+	DisableKeepAlive bool
+}
+
+// New creates an HTTP client honoring the notifier's timeout and keep-alive settings
+func New(cfg Config) *http.Client {
+	if cfg.Timeout == 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DisableKeepAlives = cfg.DisableKeepAlive
+	if cfg.MaxIdleConns > 0 {
+		transport.MaxIdleConns = cfg.MaxIdleConns
+		transport.MaxIdleConnsPerHost = cfg.MaxIdleConns
+	}
+
+	return &http.Client{
+		Timeout:   cfg.Timeout,
+		Transport: transport,
+	}
+}
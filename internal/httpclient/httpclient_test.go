@@ -0,0 +1,51 @@
+package httpclient
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewTimesOutOnSlowServer(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := New(Config{Timeout: 50 * time.Millisecond})
+
+	start := time.Now()
+	resp, err := client.Get(server.URL)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatal("expected the request to time out")
+	}
+	var netErr net.Error
+	if !errors.As(err, &netErr) || !netErr.Timeout() {
+		t.Errorf("expected a timeout error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected the request to give up after about 50ms, took %s", elapsed)
+	}
+}
+
+func TestNewDefaults(t *testing.T) {
+	client := New(Config{})
+	if client.Timeout != 10*time.Second {
+		t.Errorf("expected a default timeout of 10s, got %s", client.Timeout)
+	}
+
+	client = New(Config{MaxIdleConns: 4, DisableKeepAlive: true})
+	transport := client.Transport.(*http.Transport)
+	if transport.MaxIdleConnsPerHost != 4 || !transport.DisableKeepAlives {
+		t.Errorf("expected the transport settings to be applied, got max idle %d, keep-alive disabled %v", transport.MaxIdleConnsPerHost, transport.DisableKeepAlives)
+	}
+}
@@ -39,11 +39,17 @@ func (l Level) String() string {
 
 // Logger handles structured logging
 type Logger struct {
-	file      *os.File
+	file      *os.File   // nil unless logging.target includes file
+	console   bool       // Whether entries are also written to stdout
+	syslog    syslogSink // nil unless logging.target includes syslog
 	format    string
+	location  *time.Location // Timezone of entry timestamps
+	layout    string         // Time layout of entry timestamps
 	level     Level
 	verbosity int
 	mu        sync.Mutex
+	// Per-job cap of stuck cron entries (nil unless logging.alert_rate_limit is set)
+	alerts *alertLimiter
 }
 
 // LogEntry represents a structured log entry
@@ -55,32 +61,75 @@ type LogEntry struct {
 	Error     string                 `json:"error,omitempty"`
 }
 
-// New creates a new logger
-func New(cfg config.LoggingConfig, verbosity int) (*Logger, error) {
-	// Create log directory if it doesn't exist
-	logDir := filepath.Dir(cfg.File)
-	if err := os.MkdirAll(logDir, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create log directory: %w", err)
-	}
+// syslogSink writes log entries to the system logger at the priority of their level
+type syslogSink interface {
+	write(level Level, msg string) error
+	Close() error
+}
 
-	file, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+// New creates a new logger writing to the targets of logging.target
+func New(cfg config.LoggingConfig, verbosity int) (*Logger, error) {
+	location, err := cfg.Location()
 	if err != nil {
-		return nil, fmt.Errorf("failed to open log file: %w", err)
+		return nil, fmt.Errorf("invalid logging.timezone: %w", err)
 	}
 
-	level := parseLevel(cfg.Level)
-
-	return &Logger{
-		file:      file,
+	l := &Logger{
+		console:   cfg.WritesConsole(),
 		format:    cfg.Format,
-		level:     level,
+		location:  location,
+		layout:    cfg.TimestampLayout(),
+		level:     parseLevel(cfg.Level),
 		verbosity: verbosity,
-	}, nil
+	}
+
+	if cfg.AlertRateLimit > 0 {
+		l.alerts = newAlertLimiter(cfg.AlertRateLimit, cfg.AlertRateWindow)
+	}
+
+	if cfg.HasTarget(config.LogTargetFile) {
+		// Create log directory if it doesn't exist
+		logDir := filepath.Dir(cfg.File)
+		if err := os.MkdirAll(logDir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create log directory: %w", err)
+		}
+
+		file, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open log file: %w", err)
+		}
+		l.file = file
+	}
+
+	if cfg.HasTarget(config.LogTargetSyslog) {
+		sink, err := newSyslogSink(cfg.Syslog.Facility, cfg.Syslog.Tag)
+		if err != nil {
+			l.Close()
+			return nil, fmt.Errorf("failed to connect to syslog: %w", err)
+		}
+		l.syslog = sink
+	}
+
+	return l, nil
 }
 
-// Close closes the log file
+// Close closes the log file and the syslog connection
+// Entries suppressed by logging.alert_rate_limit are summarized first
 func (l *Logger) Close() error {
-	return l.file.Close()
+	if l.alerts != nil {
+		l.logSuppressed(l.alerts.expire(time.Now(), true))
+	}
+
+	var err error
+	if l.file != nil {
+		err = l.file.Close()
+	}
+	if l.syslog != nil {
+		if syslogErr := l.syslog.Close(); err == nil {
+			err = syslogErr
+		}
+	}
+	return err
 }
 
 func parseLevel(levelStr string) Level {
@@ -106,6 +155,8 @@ func isStartupMessage(msg string) bool {
 		"Monitoring ticker interval",
 		"Received shutdown signal",
 		"Monitor stopped",
+		"On-demand check triggered",
+		"State export triggered",
 	}
 	
 	for _, sm := range startupMessages {
@@ -141,7 +192,7 @@ func (l *Logger) log(level Level, msg string, err error, fields map[string]inter
 	}
 
 	entry := LogEntry{
-		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Timestamp: time.Now().In(l.location).Format(l.layout),
 		Level:     level.String(),
 		Message:   msg,
 		Fields:    fields,
@@ -151,7 +202,7 @@ func (l *Logger) log(level Level, msg string, err error, fields map[string]inter
 		entry.Error = err.Error()
 	}
 
-	var output string
+	var output, syslogMsg string
 	if l.format == "json" {
 		data, marshalErr := json.Marshal(entry)
 		if marshalErr != nil {
@@ -159,23 +210,36 @@ func (l *Logger) log(level Level, msg string, err error, fields map[string]inter
 			return
 		}
 		output = string(data) + "\n"
+		syslogMsg = string(data)
 	} else {
 		// Text format: [2025-10-22T06:56:00Z] info: Message {"field":"value"}
-		output = l.formatText(entry)
+		// Syslog stamps its messages itself, so they leave out the timestamp
+		syslogMsg = l.formatText(entry)
+		output = fmt.Sprintf("[%s] %s\n", entry.Timestamp, syslogMsg)
 	}
 
 	// Write to file
-	if _, writeErr := l.file.WriteString(output); writeErr != nil {
-		fmt.Fprintf(os.Stderr, "Failed to write log: %v\n", writeErr)
+	if l.file != nil {
+		if _, writeErr := l.file.WriteString(output); writeErr != nil {
+			fmt.Fprintf(os.Stderr, "Failed to write log: %v\n", writeErr)
+		}
+	}
+
+	if l.syslog != nil {
+		if writeErr := l.syslog.write(level, syslogMsg); writeErr != nil {
+			fmt.Fprintf(os.Stderr, "Failed to write to syslog: %v\n", writeErr)
+		}
 	}
 
 	// Also write to stdout
-	io.WriteString(os.Stdout, output)
+	if l.console {
+		io.WriteString(os.Stdout, output)
+	}
 }
 
-// formatText formats a log entry as text
+// formatText formats a log entry as text, without the timestamp
 func (l *Logger) formatText(entry LogEntry) string {
-	output := fmt.Sprintf("[%s] %s: %s", entry.Timestamp, entry.Level, entry.Message)
+	output := fmt.Sprintf("%s: %s", entry.Level, entry.Message)
 
 	if len(entry.Fields) > 0 {
 		fieldsJSON, _ := json.Marshal(entry.Fields)
@@ -186,7 +250,7 @@ func (l *Logger) formatText(entry LogEntry) string {
 		output += fmt.Sprintf(" error=%s", entry.Error)
 	}
 
-	return output + "\n"
+	return output
 }
 
 // Debug logs a debug message
@@ -211,6 +275,18 @@ func (l *Logger) Error(msg string, err error, fields map[string]interface{}) {
 
 // LogStuckCron logs a stuck cron alert with all relevant details
 func (l *Logger) LogStuckCron(alert *StuckCronAlert) {
+	message, fields := stuckCronEntry(alert)
+	l.logStuckCronEntry(LevelWarn, alert.JobCode, message, fields)
+}
+
+// LogObservedCron logs a would-be alert at info level with an [OBSERVE] prefix
+func (l *Logger) LogObservedCron(alert *StuckCronAlert) {
+	message, fields := stuckCronEntry(alert)
+	l.logStuckCronEntry(LevelInfo, alert.JobCode, "[OBSERVE] "+message, fields)
+}
+
+// stuckCronEntry builds the log message and fields for a stuck cron alert
+func stuckCronEntry(alert *StuckCronAlert) (string, map[string]interface{}) {
 	fields := map[string]interface{}{
 		"job_code":          alert.JobCode,
 		"status":            alert.Status,
@@ -218,6 +294,12 @@ func (l *Logger) LogStuckCron(alert *StuckCronAlert) {
 		"consecutive_stuck": alert.ConsecutiveStuck,
 	}
 
+	if alert.Detection != "" {
+		fields["detection"] = alert.Detection
+	}
+	if alert.Severity != "" {
+		fields["severity"] = alert.Severity
+	}
 	if alert.RunningTime != nil {
 		fields["running_time"] = alert.RunningTime.String()
 	}
@@ -239,20 +321,35 @@ func (l *Logger) LogStuckCron(alert *StuckCronAlert) {
 	if alert.ErrorMessage != "" {
 		fields["error_message"] = alert.ErrorMessage
 	}
+	if alert.CronGroup != "" {
+		fields["cron_group"] = alert.CronGroup
+	}
+	if alert.MagentoVersion != "" {
+		fields["magento_version"] = alert.MagentoVersion
+	}
 
 	// Use different message for scheduler alerts
 	message := "STUCK CRON DETECTED"
-	if alert.JobCode == "SCHEDULER" {
+	switch alert.JobCode {
+	case "SCHEDULER":
 		message = "STUCK CRON SCHEDULER"
+	case "CRON_SCHEDULE":
+		message = "SUSPICIOUS CRON SCHEDULE DATA"
+		if alert.Status == "empty" {
+			message = "EMPTY CRON SCHEDULE"
+		}
 	}
 
-	l.log(LevelWarn, message, nil, fields)
+	return message, fields
 }
 
 // StuckCronAlert represents a stuck cron alert
 type StuckCronAlert struct {
 	JobCode          string
+	CronGroup        string
 	Status           string
+	Detection        string // Detection type that raised the alert, e.g. long_running
+	Severity         string // critical, high, warning or info; set per detection type by the analyzer
 	RunningTime      *time.Duration
 	ScheduledAt      *time.Time
 	ExecutedAt       *time.Time
@@ -262,4 +359,5 @@ type StuckCronAlert struct {
 	ErrorCount       int
 	MissedCount      int
 	ErrorMessage     string
+	MagentoVersion   string
 }
@@ -0,0 +1,107 @@
+package logger
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// alertLimiter caps the stuck cron log entries of each job at limit per window
+// It only keeps the log readable; notifications have their own cooldowns
+type alertLimiter struct {
+	limit  int
+	window time.Duration
+	mu     sync.Mutex
+	jobs   map[string]*alertWindow
+}
+
+// alertWindow counts a job's entries in the current window
+type alertWindow struct {
+	start      time.Time
+	logged     int
+	suppressed int
+}
+
+// suppressedAlerts is the number of entries dropped for a job in a window that ended
+type suppressedAlerts struct {
+	jobCode    string
+	suppressed int
+}
+
+func newAlertLimiter(limit int, window time.Duration) *alertLimiter {
+	return &alertLimiter{
+		limit:  limit,
+		window: window,
+		jobs:   make(map[string]*alertWindow),
+	}
+}
+
+// allow reports whether an entry for the job may be logged, counting it as suppressed otherwise
+func (a *alertLimiter) allow(jobCode string, now time.Time) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	w, ok := a.jobs[jobCode]
+	if !ok {
+		w = &alertWindow{start: now}
+		a.jobs[jobCode] = w
+	}
+	if w.logged < a.limit {
+		w.logged++
+		return true
+	}
+	w.suppressed++
+	return false
+}
+
+// expire ends the windows that are over (or all of them) and returns the jobs that had entries suppressed
+func (a *alertLimiter) expire(now time.Time, all bool) []suppressedAlerts {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	var expired []suppressedAlerts
+	for jobCode, w := range a.jobs {
+		if !all && now.Sub(w.start) < a.window {
+			continue
+		}
+		if w.suppressed > 0 {
+			expired = append(expired, suppressedAlerts{jobCode: jobCode, suppressed: w.suppressed})
+		}
+		delete(a.jobs, jobCode)
+	}
+	sort.Slice(expired, func(i, j int) bool { return expired[i].jobCode < expired[j].jobCode })
+	return expired
+}
+
+// logStuckCronEntry logs a stuck cron entry unless the job exceeded logging.alert_rate_limit
+func (l *Logger) logStuckCronEntry(level Level, jobCode, message string, fields map[string]interface{}) {
+	if l.alerts != nil {
+		now := time.Now()
+		l.logSuppressed(l.alerts.expire(now, false))
+		if !l.alerts.allow(jobCode, now) {
+			return
+		}
+	}
+	l.log(level, message, nil, fields)
+}
+
+// FlushSuppressedAlerts logs how many stuck cron entries were suppressed per job in windows that have ended
+// The monitor calls it after every check, so the summary appears even once a job stops alerting
+func (l *Logger) FlushSuppressedAlerts() {
+	if l.alerts == nil {
+		return
+	}
+	l.logSuppressed(l.alerts.expire(time.Now(), false))
+}
+
+// logSuppressed writes one summary line per job with suppressed entries
+func (l *Logger) logSuppressed(expired []suppressedAlerts) {
+	for _, e := range expired {
+		l.log(LevelWarn, "Suppressed repeated stuck cron log entries", nil, map[string]interface{}{
+			"job_code":   e.jobCode,
+			"suppressed": e.suppressed,
+			"limit":      l.alerts.limit,
+			"window":     l.alerts.window.String(),
+		})
+	}
+}
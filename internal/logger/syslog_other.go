@@ -0,0 +1,10 @@
+//go:build windows || plan9
+
+package logger
+
+import "errors"
+
+// newSyslogSink fails, log/syslog is not available on this platform
+func newSyslogSink(facility, tag string) (syslogSink, error) {
+	return nil, errors.New("syslog is not supported on this platform")
+}
@@ -0,0 +1,69 @@
+//go:build !windows && !plan9
+
+package logger
+
+import (
+	"fmt"
+	"log/syslog"
+)
+
+// syslogFacilities maps logging.syslog.facility names to syslog facilities
+var syslogFacilities = map[string]syslog.Priority{
+	"kern":     syslog.LOG_KERN,
+	"user":     syslog.LOG_USER,
+	"mail":     syslog.LOG_MAIL,
+	"daemon":   syslog.LOG_DAEMON,
+	"auth":     syslog.LOG_AUTH,
+	"syslog":   syslog.LOG_SYSLOG,
+	"lpr":      syslog.LOG_LPR,
+	"news":     syslog.LOG_NEWS,
+	"uucp":     syslog.LOG_UUCP,
+	"cron":     syslog.LOG_CRON,
+	"authpriv": syslog.LOG_AUTHPRIV,
+	"ftp":      syslog.LOG_FTP,
+	"local0":   syslog.LOG_LOCAL0,
+	"local1":   syslog.LOG_LOCAL1,
+	"local2":   syslog.LOG_LOCAL2,
+	"local3":   syslog.LOG_LOCAL3,
+	"local4":   syslog.LOG_LOCAL4,
+	"local5":   syslog.LOG_LOCAL5,
+	"local6":   syslog.LOG_LOCAL6,
+	"local7":   syslog.LOG_LOCAL7,
+}
+
+// unixSyslog writes to the local syslog daemon (or journald's syslog socket)
+type unixSyslog struct {
+	writer *syslog.Writer
+}
+
+// newSyslogSink connects to the local syslog daemon
+func newSyslogSink(facility, tag string) (syslogSink, error) {
+	priority, ok := syslogFacilities[facility]
+	if !ok {
+		return nil, fmt.Errorf("unknown syslog facility %q", facility)
+	}
+	writer, err := syslog.New(priority|syslog.LOG_INFO, tag)
+	if err != nil {
+		return nil, err
+	}
+	return &unixSyslog{writer: writer}, nil
+}
+
+// write sends a message at the syslog priority of the level
+func (s *unixSyslog) write(level Level, msg string) error {
+	switch level {
+	case LevelDebug:
+		return s.writer.Debug(msg)
+	case LevelWarn:
+		return s.writer.Warning(msg)
+	case LevelError:
+		return s.writer.Err(msg)
+	default:
+		return s.writer.Info(msg)
+	}
+}
+
+// Close closes the syslog connection
+func (s *unixSyslog) Close() error {
+	return s.writer.Close()
+}
@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// checkDurationBuckets are the upper bounds (seconds) of the check duration histogram
+var checkDurationBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60}
+
+// Metrics holds the monitor's own metrics in a dedicated Prometheus registry
+// The registry only holds these collectors, so /metrics doesn't mix in the process and Go runtime metrics
+type Metrics struct {
+	registry         *prometheus.Registry
+	alertingJobs     prometheus.Gauge
+	alerts           *prometheus.CounterVec
+	jobAlerts        *prometheus.CounterVec
+	checkDuration    prometheus.Histogram
+	schedulesFetched prometheus.Gauge
+	databaseUp       prometheus.Gauge
+}
+
+// New creates the metrics and registers them in a new registry
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		alertingJobs: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "cron_monitor_alerting_jobs",
+			Help: "Jobs currently in the alerting state.",
+		}),
+		alerts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cron_monitor_alerts_total",
+			Help: "Alerts emitted, by detection type.",
+		}, []string{"detection"}),
+		jobAlerts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cron_monitor_job_alerts_total",
+			Help: "Alerts emitted, by job code.",
+		}, []string{"job_code"}),
+		checkDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "cron_monitor_check_duration_seconds",
+			Help:    "Duration of monitoring checks.",
+			Buckets: checkDurationBuckets,
+		}),
+		schedulesFetched: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "cron_monitor_schedules_fetched",
+			Help: "cron_schedule rows fetched by the last check.",
+		}),
+		databaseUp: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "cron_monitor_database_up",
+			Help: "Whether the last check could query the database (1) or not (0).",
+		}),
+	}
+
+	m.registry.MustRegister(
+		m.alertingJobs,
+		m.alerts,
+		m.jobAlerts,
+		m.checkDuration,
+		m.schedulesFetched,
+		m.databaseUp,
+	)
+	return m
+}
+
+// RecordAlert counts an alert by detection type and job code
+func (m *Metrics) RecordAlert(detection, jobCode string) {
+	m.alerts.WithLabelValues(detection).Inc()
+	m.jobAlerts.WithLabelValues(jobCode).Inc()
+}
+
+// ObserveCheck records the duration of a completed check
+func (m *Metrics) ObserveCheck(duration time.Duration) {
+	m.checkDuration.Observe(duration.Seconds())
+}
+
+// SetSchedulesFetched sets the number of cron_schedule rows fetched by the last check
+func (m *Metrics) SetSchedulesFetched(n int) {
+	m.schedulesFetched.Set(float64(n))
+}
+
+// SetAlertingJobs sets the number of jobs currently in the alerting state
+func (m *Metrics) SetAlertingJobs(n int) {
+	m.alertingJobs.Set(float64(n))
+}
+
+// SetDatabaseUp records whether the last database query of a check succeeded
+func (m *Metrics) SetDatabaseUp(up bool) {
+	if up {
+		m.databaseUp.Set(1)
+	} else {
+		m.databaseUp.Set(0)
+	}
+}
+
+// Handler serves the metrics for Prometheus scrapes
+// A metric that fails to gather is left out instead of failing the whole scrape
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{
+		ErrorHandling: promhttp.ContinueOnError,
+	})
+}
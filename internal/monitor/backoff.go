@@ -0,0 +1,82 @@
+package monitor
+
+import (
+	"time"
+)
+
+// runScheduledCheck runs a periodic check, skipping it while checks are backed off after repeated failures
+func (s *Service) runScheduledCheck() {
+	now := time.Now()
+
+	// Half an interval of slack so a tick arriving slightly early isn't skipped
+	if until, ok := s.backingOff(now.Add(s.config.Monitor.Interval / 2)); ok {
+		s.logger.Debug("Skipping check (backing off after failures)", map[string]interface{}{
+			"next_check": until.Format(time.RFC3339),
+		})
+		return
+	}
+
+	err := s.runOnce(checkScope{})
+	if err != nil {
+		s.logger.Error("Check failed", err, nil)
+	}
+	s.recordCheckResult(err, now)
+}
+
+// recordCheckResult tracks consecutive failed checks and widens the check interval once
+// failure_backoff_after is reached, doubling it per further failure up to max_backoff_interval
+func (s *Service) recordCheckResult(err error, now time.Time) {
+	after := *s.config.Monitor.FailureBackoffAfter
+	if after <= 0 {
+		return
+	}
+
+	if err == nil {
+		if s.consecutiveFailures >= after {
+			s.logger.Info("Check succeeded, resuming normal check interval", map[string]interface{}{
+				"failed_checks": s.consecutiveFailures,
+				"interval":      s.config.Monitor.Interval.String(),
+			})
+		}
+		s.consecutiveFailures = 0
+		s.setBackoffUntil(time.Time{})
+		return
+	}
+
+	s.consecutiveFailures++
+	if s.consecutiveFailures < after {
+		return
+	}
+
+	interval := s.config.Monitor.Interval
+	for i := after; i <= s.consecutiveFailures && interval < s.config.Monitor.MaxBackoffInterval; i++ {
+		interval *= 2
+	}
+	if interval > s.config.Monitor.MaxBackoffInterval {
+		interval = s.config.Monitor.MaxBackoffInterval
+	}
+
+	if interval != s.backoffInterval || s.consecutiveFailures == after {
+		s.logger.Warn("Checks keep failing, widening check interval", map[string]interface{}{
+			"failed_checks": s.consecutiveFailures,
+			"interval":      interval.String(),
+		})
+	}
+	s.backoffInterval = interval
+	s.setBackoffUntil(now.Add(interval))
+}
+
+// setBackoffUntil sets when checks resume after failures, zero when they aren't backed off
+func (s *Service) setBackoffUntil(until time.Time) {
+	s.backoffMu.Lock()
+	defer s.backoffMu.Unlock()
+	s.backoffUntil = until
+}
+
+// backingOff reports whether checks are still backed off at the given time, and until when
+// Group check loops follow the main loop's backoff, since they query the same database
+func (s *Service) backingOff(at time.Time) (time.Time, bool) {
+	s.backoffMu.RLock()
+	defer s.backoffMu.RUnlock()
+	return s.backoffUntil, at.Before(s.backoffUntil)
+}
@@ -0,0 +1,32 @@
+package monitor
+
+import "time"
+
+// cleanupSchedules prunes finished cron_schedule rows older than monitor.cleanup_older_than
+func (s *Service) cleanupSchedules() {
+	s.checkMu.Lock()
+	defer s.checkMu.Unlock()
+
+	// With clustering only the leader prunes, so instances don't compete for the same rows
+	if s.leaderLock != nil && !s.isLeader {
+		s.logger.Debug("Skipping cron_schedule cleanup (not cluster leader)", nil)
+		return
+	}
+
+	start := time.Now()
+	ctx, cancel := s.queryContext(s.ctx)
+	deleted, err := s.db.DeleteSchedulesOlderThan(ctx, s.config.Monitor.CleanupOlderThan)
+	cancel()
+
+	fields := map[string]interface{}{
+		"deleted":    deleted,
+		"older_than": s.config.Monitor.CleanupOlderThan.String(),
+		"duration":   time.Since(start).String(),
+	}
+	if err != nil {
+		// Batches deleted before the error stay deleted; the next run continues from there
+		s.logger.Error("Failed to clean up old cron schedules", err, fields)
+		return
+	}
+	s.logger.Info("Cleaned up old cron schedules", fields)
+}
@@ -0,0 +1,79 @@
+package monitor
+
+import (
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/cronalert"
+	"github.com/fabio/go-magento-cron-monitor/internal/notifier"
+)
+
+// pendingDigest is a notifier's alerts collected during the current digest window
+type pendingDigest struct {
+	notifier notifier.Notifier
+	alerts   []cronalert.Alert
+}
+
+// digestsEnabled reports whether notifications.slack.digest_window batches notifications
+func (s *Service) digestsEnabled() bool {
+	return s.digestWindow > 0
+}
+
+// bufferDigest adds an alert to the notifier's pending digest
+// A newer alert or recovery of a job replaces its earlier one of the same type, so a job that
+// alerts and recovers within one window is listed in both sections of the digest
+func (s *Service) bufferDigest(n notifier.Notifier, alert cronalert.Alert) {
+	s.digestMu.Lock()
+	defer s.digestMu.Unlock()
+
+	if s.digests == nil {
+		s.digests = make(map[string]*pendingDigest)
+	}
+	pending, ok := s.digests[n.Name()]
+	if !ok {
+		pending = &pendingDigest{notifier: n}
+		s.digests[n.Name()] = pending
+		s.digestOrder = append(s.digestOrder, n.Name())
+	}
+
+	for i, buffered := range pending.alerts {
+		if buffered.CronCode == alert.CronCode && buffered.Type == alert.Type {
+			pending.alerts = append(pending.alerts[:i], pending.alerts[i+1:]...)
+			break
+		}
+	}
+	pending.alerts = append(pending.alerts, alert)
+}
+
+// flushDigests sends the digests collected during the window that just ended
+// Alerts of a failed digest are queued for retry individually
+func (s *Service) flushDigests(now time.Time) {
+	s.digestMu.Lock()
+	digests, order := s.digests, s.digestOrder
+	s.digests, s.digestOrder = nil, nil
+	s.digestMu.Unlock()
+
+	for _, name := range order {
+		pending := digests[name]
+		if len(pending.alerts) == 0 {
+			continue
+		}
+
+		sender := pending.notifier.(notifier.DigestSender)
+		if err := sender.SendDigest(s.ctx, pending.alerts, s.digestWindow, now); err != nil {
+			s.logger.Error("Failed to send notification digest", err, map[string]interface{}{
+				"notifier":    name,
+				"alert_count": len(pending.alerts),
+			})
+			for _, alert := range pending.alerts {
+				s.enqueueRetry(name, alert, now)
+			}
+			continue
+		}
+
+		s.logger.Info("Sent notification digest", map[string]interface{}{
+			"notifier":    name,
+			"alert_count": len(pending.alerts),
+			"window":      s.digestWindow.String(),
+		})
+	}
+}
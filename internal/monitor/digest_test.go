@@ -0,0 +1,133 @@
+package monitor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/analyzer"
+	"github.com/fabio/go-magento-cron-monitor/internal/config"
+	"github.com/fabio/go-magento-cron-monitor/internal/cronalert"
+	"github.com/fabio/go-magento-cron-monitor/internal/logger"
+)
+
+// digestRecorder is a digest notifier that records what it was sent
+type digestRecorder struct {
+	digests [][]cronalert.Alert
+	ctxErrs []error
+}
+
+func (r *digestRecorder) Name() string        { return "recorder" }
+func (r *digestRecorder) CooldownKey() string { return "recorder" }
+
+func (r *digestRecorder) Send(ctx context.Context, alert cronalert.Alert) error {
+	return nil
+}
+
+func (r *digestRecorder) SendDigest(ctx context.Context, alerts []cronalert.Alert, window time.Duration, now time.Time) error {
+	r.digests = append(r.digests, alerts)
+	r.ctxErrs = append(r.ctxErrs, ctx.Err())
+	return ctx.Err()
+}
+
+// newDigestService returns a service with a 5 minute digest window and no other features
+func newDigestService() *Service {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Service{
+		ctx:          ctx,
+		cancel:       cancel,
+		config:       &config.Config{},
+		logger:       &logger.Logger{},
+		digestWindow: 5 * time.Minute,
+	}
+}
+
+func TestBufferDigestKeepsAlertOnRecovery(t *testing.T) {
+	s := newDigestService()
+	n := &digestRecorder{}
+
+	s.bufferDigest(n, cronalert.Alert{CronCode: "sales_export", Type: cronalert.Alerting, Reason: "first"})
+	s.bufferDigest(n, cronalert.Alert{CronCode: "sales_export", Type: cronalert.Alerting, Reason: "second"})
+	s.bufferDigest(n, cronalert.Alert{CronCode: "catalog_index", Type: cronalert.Alerting})
+	s.bufferDigest(n, cronalert.Alert{CronCode: "sales_export", Type: cronalert.NotAlerting})
+
+	got := s.digests[n.Name()].alerts
+	want := []struct {
+		cronCode  string
+		alertType cronalert.Type
+	}{
+		{"sales_export", cronalert.Alerting},
+		{"catalog_index", cronalert.Alerting},
+		{"sales_export", cronalert.NotAlerting},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d buffered alerts, got %d", len(want), len(got))
+	}
+	for i, w := range want {
+		if got[i].CronCode != w.cronCode || got[i].Type != w.alertType {
+			t.Errorf("alert %d = %s %s, want %s %s", i, got[i].CronCode, got[i].Type, w.cronCode, w.alertType)
+		}
+	}
+	if got[0].Reason != "second" {
+		t.Errorf("expected the newer alert to replace the earlier one, got reason %q", got[0].Reason)
+	}
+}
+
+func TestStopFlushesDigestsBeforeCancelling(t *testing.T) {
+	s := newDigestService()
+	n := &digestRecorder{}
+	s.bufferDigest(n, cronalert.Alert{CronCode: "sales_export", Type: cronalert.Alerting})
+
+	s.Stop()
+
+	if len(n.digests) != 1 {
+		t.Fatalf("expected the pending digest to be sent on stop, got %d digests", len(n.digests))
+	}
+	if n.ctxErrs[0] != nil {
+		t.Errorf("expected the digest to be sent with a live context, got %v", n.ctxErrs[0])
+	}
+	if s.ctx.Err() == nil {
+		t.Error("expected the service context to be cancelled after stopping")
+	}
+}
+
+func TestCriticalJobsBypassDigest(t *testing.T) {
+	webhook := newWebhookRecorder(t)
+	svc := newTestService(t, slackSection(webhook.URL)+
+		"    digest_window: 5m\nmonitor:\n  critical_jobs: [\"payment_*\"]\n")
+
+	tests := []struct {
+		jobCode   string
+		wantPosts int
+	}{
+		{"sales_export", 0},
+		{"payment_capture", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.jobCode, func(t *testing.T) {
+			before := len(webhook.posts())
+			svc.analyzer.InitJobState(tt.jobCode)
+			err := svc.handleStateTransition(analyzer.StateTransition{
+				CronCode:  tt.jobCode,
+				FromState: "not_alerting",
+				ToState:   "alerting",
+				Timestamp: time.Now(),
+				Status:    "running",
+				Reason:    "job running longer than max_running_time threshold (30m0s)",
+			}, time.Now(), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := len(webhook.posts()) - before; got != tt.wantPosts {
+				t.Errorf("expected %d immediate notifications, got %d", tt.wantPosts, got)
+			}
+		})
+	}
+
+	// Only the non-critical job waits for the digest
+	pending := svc.digests["slack"]
+	if pending == nil || len(pending.alerts) != 1 || pending.alerts[0].CronCode != "sales_export" {
+		t.Errorf("expected only sales_export in the pending digest, got %+v", pending)
+	}
+}
@@ -0,0 +1,105 @@
+package monitor
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/cronalert"
+	"github.com/fabio/go-magento-cron-monitor/internal/notifier"
+)
+
+// drainTimeout bounds how long a shutdown waits for queued deliveries
+const drainTimeout = 30 * time.Second
+
+// dispatcher delivers notifications on a background worker so slow notifiers don't delay checks
+// A single worker keeps deliveries in the order they were submitted
+type dispatcher struct {
+	queue  chan func(context.Context)
+	done   chan struct{}
+	ctx    context.Context // Passed to deliveries, cancelled with the service or when a drain times out
+	cancel context.CancelFunc
+	mu     sync.Mutex
+	closed bool
+}
+
+// newDispatcher starts a dispatcher holding up to queueSize pending deliveries, sent with a ctx derived from ctx
+func newDispatcher(ctx context.Context, queueSize int) *dispatcher {
+	d := &dispatcher{
+		queue: make(chan func(context.Context), queueSize),
+		done:  make(chan struct{}),
+	}
+	d.ctx, d.cancel = context.WithCancel(ctx)
+
+	go func() {
+		defer close(d.done)
+		for deliver := range d.queue {
+			deliver(d.ctx)
+		}
+	}()
+
+	return d
+}
+
+// submit queues a delivery; it returns false when the queue is full or draining
+func (d *dispatcher) submit(deliver func(context.Context)) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return false
+	}
+	select {
+	case d.queue <- deliver:
+		return true
+	default:
+		return false
+	}
+}
+
+// drain stops accepting deliveries and waits up to timeout for the queued ones to finish
+// After the timeout the remaining deliveries are cancelled, so they fail fast and go to the retry queue;
+// it reports whether everything was delivered in time
+func (d *dispatcher) drain(timeout time.Duration) bool {
+	d.mu.Lock()
+	if !d.closed {
+		d.closed = true
+		close(d.queue)
+	}
+	d.mu.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-d.done:
+		return true
+	case <-timer.C:
+	}
+
+	d.cancel()
+	<-d.done
+	return false
+}
+
+// deliverAsync queues a notification for background delivery, sending it inline when the queue is full
+// or drained on shutdown
+// Failed deliveries are logged and queued for retry like synchronous ones
+func (s *Service) deliverAsync(n notifier.Notifier, alert cronalert.Alert, now time.Time, fields map[string]interface{}) {
+	deliver := func(ctx context.Context) {
+		if err := n.Send(ctx, alert); err != nil {
+			s.logger.Error("Failed to send notification", err, fields)
+			s.enqueueRetry(n.Name(), alert, now)
+			return
+		}
+		s.logger.Info("Sent notification", fields)
+	}
+
+	if !s.dispatcher.submit(deliver) {
+		s.logger.Warn("Notification queue full or draining, sending inline", map[string]interface{}{
+			"cron_code":  alert.CronCode,
+			"notifier":   n.Name(),
+			"queue_size": s.config.Notifications.AsyncQueueSize,
+		})
+		deliver(s.ctx)
+	}
+}
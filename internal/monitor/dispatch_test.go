@@ -0,0 +1,69 @@
+package monitor
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestDispatcherDrainDeliversQueued(t *testing.T) {
+	d := newDispatcher(context.Background(), 10)
+
+	delivered := 0
+	for i := 0; i < 3; i++ {
+		if !d.submit(func(context.Context) { delivered++ }) {
+			t.Fatal("expected the delivery to be queued")
+		}
+	}
+
+	if !d.drain(time.Second) {
+		t.Fatal("expected the queue to drain in time")
+	}
+	if delivered != 3 {
+		t.Errorf("expected 3 deliveries, got %d", delivered)
+	}
+	if d.submit(func(context.Context) {}) {
+		t.Error("expected no deliveries to be accepted after draining")
+	}
+}
+
+func TestDispatcherDrainTimeoutCancelsDeliveries(t *testing.T) {
+	d := newDispatcher(context.Background(), 10)
+
+	var cancelled []bool
+	for i := 0; i < 2; i++ {
+		d.submit(func(ctx context.Context) {
+			// A slow notifier that gives up when its ctx is cancelled
+			select {
+			case <-time.After(time.Minute):
+				cancelled = append(cancelled, false)
+			case <-ctx.Done():
+				cancelled = append(cancelled, true)
+			}
+		})
+	}
+
+	start := time.Now()
+	if d.drain(50 * time.Millisecond) {
+		t.Fatal("expected the drain to time out")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the drain to stop after its timeout, took %s", elapsed)
+	}
+	if len(cancelled) != 2 || !cancelled[0] || !cancelled[1] {
+		t.Errorf("expected both deliveries to see a cancelled ctx, got %v", cancelled)
+	}
+}
+
+func TestDispatcherFollowsParentContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	d := newDispatcher(ctx, 1)
+	cancel()
+
+	done := make(chan error, 1)
+	d.submit(func(ctx context.Context) { done <- ctx.Err() })
+	if err := <-done; err == nil {
+		t.Error("expected deliveries to be cancelled with the service context")
+	}
+	d.drain(time.Second)
+}
@@ -0,0 +1,135 @@
+package monitor
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/cronalert"
+	"github.com/fabio/go-magento-cron-monitor/internal/logger"
+	"github.com/fabio/go-magento-cron-monitor/internal/notifier"
+)
+
+// escalationStep is a rung of the escalation ladder
+type escalationStep struct {
+	After    time.Duration
+	Notifier notifier.Notifier
+}
+
+// escalate re-notifies the next escalation step for jobs that have been alerting long enough
+// Each step fires once per incident; if several were crossed at once only the highest fires
+// Only jobs in the scope of the check are escalated
+func (s *Service) escalate(now time.Time, alertMap map[string]*logger.StuckCronAlert, scope checkScope) {
+	if len(s.escalations) == 0 {
+		return
+	}
+
+	jobCodes := make([]string, 0)
+	for jobCode, state := range s.analyzer.GetJobStates() {
+		if !scope.covers(s.config, state.CronGroup) {
+			continue
+		}
+		if state.LastKnownState == "alerting" && !state.StuckSince.IsZero() && s.snoozedJob(jobCode, now) == nil {
+			jobCodes = append(jobCodes, jobCode)
+		}
+	}
+	sort.Strings(jobCodes)
+
+	for _, jobCode := range jobCodes {
+		state := s.analyzer.GetCronState(jobCode)
+		if state == nil {
+			continue
+		}
+
+		stuckFor := now.Sub(state.StuckSince)
+		level := escalationLevel(s.escalations, stuckFor, state.EscalationLevel)
+		if level == state.EscalationLevel {
+			continue
+		}
+		step := s.escalations[level-1]
+
+		alert := cronalert.Alert{
+			Type:            cronalert.Alerting,
+			CronCode:        jobCode,
+			Status:          state.LastStatus,
+			StuckDuration:   stuckFor,
+			Timestamp:       now,
+			Reason:          fmt.Sprintf("still alerting after %s", stuckFor.Round(time.Second)),
+			Metadata:        s.config.Notifications.Metadata,
+			MagentoVersion:  s.magentoVersion,
+			EscalationLevel: level,
+			Critical:        s.config.IsCriticalJob(jobCode),
+			CronGroup:       state.CronGroup,
+		}
+		if enriched, ok := alertMap[jobCode]; ok {
+			alert.Status = enriched.Status
+			alert.Reason = enriched.Reason
+			alert.RunningTime = enriched.RunningTime
+			alert.ScheduledAt = enriched.ScheduledAt
+			alert.ConsecutiveStuck = enriched.ConsecutiveStuck
+			alert.ErrorMessage = enriched.ErrorMessage
+			alert.Severity = enriched.Severity
+		}
+		alert.Severity = cronalert.DeriveSeverity(alert)
+
+		if err := step.Notifier.Send(s.ctx, alert); err != nil {
+			// Not advancing the level retries this step on the next check
+			s.logger.Error("Failed to send escalation notification", err, map[string]interface{}{
+				"cron_code": jobCode,
+				"level":     level,
+			})
+			continue
+		}
+		state.EscalationLevel = level
+
+		s.logger.Info("Sent escalation notification", map[string]interface{}{
+			"cron_code": jobCode,
+			"level":     level,
+			"stuck_for": stuckFor.String(),
+		})
+	}
+}
+
+// escalationLevel returns the highest step crossed after stuckFor, never below the current level
+func escalationLevel(steps []escalationStep, stuckFor time.Duration, level int) int {
+	for level < len(steps) && stuckFor >= steps[level].After {
+		level++
+	}
+	return level
+}
+
+// escalateScheduler re-notifies the next scheduler escalation step while the scheduler stays inactive
+func (s *Service) escalateScheduler(now time.Time, schedulerAlert *logger.StuckCronAlert) {
+	if len(s.schedulerEscalations) == 0 {
+		return
+	}
+
+	inactiveFor, current, ok := s.analyzer.SchedulerEscalation(now)
+	if !ok {
+		return
+	}
+	level := escalationLevel(s.schedulerEscalations, inactiveFor, current)
+	if level == current {
+		return
+	}
+
+	alert := s.schedulerCronAlert(now, schedulerAlert)
+	alert.StuckDuration = inactiveFor
+	alert.EscalationLevel = level
+	if alert.Reason == "" {
+		alert.Reason = fmt.Sprintf("scheduler still inactive after %s", inactiveFor.Round(time.Second))
+	}
+
+	if err := s.schedulerEscalations[level-1].Notifier.Send(s.ctx, alert); err != nil {
+		s.logger.Error("Failed to send scheduler escalation notification", err, map[string]interface{}{
+			"level": level,
+		})
+		return
+	}
+	s.analyzer.SetSchedulerEscalationLevel(level)
+
+	s.logger.Info("Sent scheduler escalation notification", map[string]interface{}{
+		"level":        level,
+		"inactive_for": inactiveFor.String(),
+	})
+}
@@ -0,0 +1,107 @@
+package monitor
+
+import (
+	"sort"
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/config"
+	"github.com/fabio/go-magento-cron-monitor/internal/database"
+	"github.com/fabio/go-magento-cron-monitor/internal/logger"
+)
+
+// checkScope selects the jobs a check analyzes
+// The zero value is the periodic main check: every job outside the monitor.group_intervals groups
+type checkScope struct {
+	// group limits the check to the jobs of one monitor.group_intervals group
+	group string
+	// all extends the main check to the groups with their own interval (on-demand and one-shot checks)
+	all bool
+}
+
+// main reports whether this is a main check, which also checks the scheduler and the rows of all jobs
+func (c checkScope) main() bool {
+	return c.group == ""
+}
+
+// covers reports whether jobs of a monitor.job_groups group ("" for none) are in scope
+func (c checkScope) covers(cfg *config.Config, group string) bool {
+	if c.group != "" {
+		return group == c.group
+	}
+	if c.all {
+		return true
+	}
+	_, own := cfg.GroupInterval(group)
+	return !own
+}
+
+// schedules returns the schedules of the jobs in scope
+func (c checkScope) schedules(cfg *config.Config, schedules []*database.CronSchedule) []*database.CronSchedule {
+	if c.all || len(cfg.Monitor.GroupIntervals) == 0 {
+		return schedules
+	}
+
+	scoped := make([]*database.CronSchedule, 0, len(schedules))
+	for _, sched := range schedules {
+		if c.covers(cfg, sched.CronGroup) {
+			scoped = append(scoped, sched)
+		}
+	}
+	return scoped
+}
+
+// setLastAlerts remembers the alerts of a check, replacing those of the previous check of the same scope
+// A check of every job replaces the alerts of all scopes
+func (s *Service) setLastAlerts(scope checkScope, alerts []*logger.StuckCronAlert) {
+	s.alertsMu.Lock()
+	defer s.alertsMu.Unlock()
+	if scope.all || s.lastAlerts == nil {
+		s.lastAlerts = make(map[string][]*logger.StuckCronAlert)
+	}
+	s.lastAlerts[scope.group] = alerts
+}
+
+// startGroupChecks starts a check loop for every monitor.group_intervals group
+// The loops run until the service stops; their checks query concurrently with the main check and analyze on checkMu
+func (s *Service) startGroupChecks() {
+	groups := make([]string, 0, len(s.config.Monitor.GroupIntervals))
+	for group := range s.config.Monitor.GroupIntervals {
+		groups = append(groups, group)
+	}
+	sort.Strings(groups)
+
+	for _, group := range groups {
+		interval := s.config.Monitor.GroupIntervals[group]
+		s.logger.Info("Checking cron group on its own interval", map[string]interface{}{
+			"cron_group": group,
+			"interval":   interval.String(),
+		})
+		go s.runGroupChecks(group, interval)
+	}
+}
+
+// runGroupChecks checks the jobs of a group right away and then every interval
+// While the main loop backs off after failed checks, group checks are skipped as well
+func (s *Service) runGroupChecks(group string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if until, ok := s.backingOff(time.Now()); ok {
+			s.logger.Debug("Skipping check (backing off after failures)", map[string]interface{}{
+				"cron_group": group,
+				"next_check": until.Format(time.RFC3339),
+			})
+		} else if err := s.runOnce(checkScope{group: group}); err != nil {
+			s.logger.Error("Check failed", err, map[string]interface{}{
+				"cron_group": group,
+			})
+		}
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
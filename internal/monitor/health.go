@@ -0,0 +1,126 @@
+package monitor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// recordCheck remembers the outcome of a check for the health endpoints
+func (s *Service) recordCheck(err error, now time.Time) {
+	s.healthMu.Lock()
+	defer s.healthMu.Unlock()
+	s.lastCheckTime = now
+	s.lastCheckError = err
+}
+
+// health reports whether the monitor is alive: the last check succeeded and is not older than health_stale_after
+// Until the first check completes the start time counts as the last check, so a slow first check isn't fatal
+func (s *Service) health(now time.Time) error {
+	s.healthMu.RLock()
+	defer s.healthMu.RUnlock()
+
+	if s.lastCheckError != nil {
+		return fmt.Errorf("last check failed: %w", s.lastCheckError)
+	}
+	last := s.lastCheckTime
+	if last.IsZero() {
+		last = s.startedAt
+	}
+	if age := now.Sub(last); age > s.config.Monitor.HealthStaleAfter {
+		return fmt.Errorf("no check completed for %s", age.Round(time.Second))
+	}
+	return nil
+}
+
+// readiness reports whether the monitor is ready: the first check has run and the database answers a ping
+func (s *Service) readiness(ctx context.Context) error {
+	s.healthMu.RLock()
+	checked := !s.lastCheckTime.IsZero()
+	s.healthMu.RUnlock()
+	if !checked {
+		return errors.New("first check has not run yet")
+	}
+
+	ctx, cancel := s.queryContext(ctx)
+	defer cancel()
+	if err := s.db.Ping(ctx); err != nil {
+		return fmt.Errorf("database ping failed: %w", err)
+	}
+	return nil
+}
+
+// startHTTPServer serves /healthz, /readyz, /metrics and /state on monitor.http_addr until the service context is cancelled
+func (s *Service) startHTTPServer() error {
+	if s.config.Monitor.HTTPAddr == "" {
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeProbe(w, s.health(time.Now()))
+	})
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		writeProbe(w, s.readiness(r.Context()))
+	})
+	mux.Handle("/metrics", s.metrics.Handler())
+	mux.HandleFunc("/state", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(s.Snapshot())
+	})
+
+	listener, err := net.Listen("tcp", s.config.Monitor.HTTPAddr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on monitor.http_addr: %w", err)
+	}
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("HTTP server failed", err, nil)
+		}
+	}()
+	go func() {
+		<-s.ctx.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			s.logger.Warn("Failed to shut down HTTP server", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}()
+
+	s.logger.Info("HTTP server listening", map[string]interface{}{
+		"addr": listener.Addr().String(),
+	})
+	return nil
+}
+
+// writeProbe answers a probe with 200 and "ok", or 503 and the reason
+func writeProbe(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintln(w, err.Error())
+		return
+	}
+	fmt.Fprintln(w, "ok")
+}
+
+// recordCheckMetrics updates the per-check metrics after a successful main check
+func (s *Service) recordCheckMetrics(duration time.Duration) {
+	s.metrics.ObserveCheck(duration)
+
+	alerting := 0
+	for _, state := range s.analyzer.GetJobStates() {
+		if state.LastKnownState == "alerting" {
+			alerting++
+		}
+	}
+	s.metrics.SetAlertingJobs(alerting)
+}
@@ -0,0 +1,90 @@
+package monitor
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/config"
+	"github.com/fabio/go-magento-cron-monitor/internal/database"
+	"github.com/fabio/go-magento-cron-monitor/internal/logger"
+)
+
+// stockGroupPatterns are the job code conventions of Magento's own cron groups
+// Groups without an entry, such as those found in core_config_data, match jobs prefixed with "<group>_"
+var stockGroupPatterns = map[string][]string{
+	"index":     {"indexer_*"},
+	"consumers": {"consumers_runner"},
+}
+
+// detectJobGroups maps job codes to cron groups by naming convention
+// The default group, which holds every job no convention matches, is left out
+func detectJobGroups(jobCodes []string, cronGroups []string) map[string][]string {
+	patterns := make(map[string][]string, len(stockGroupPatterns)+len(cronGroups))
+	for group, groupPatterns := range stockGroupPatterns {
+		patterns[group] = groupPatterns
+	}
+	for _, group := range cronGroups {
+		group = strings.ToLower(group)
+		if _, ok := patterns[group]; !ok && group != "default" {
+			patterns[group] = []string{group + "_*"}
+		}
+	}
+
+	// Match groups in name order, like config.JobGroup, so a job matching several resolves the same way
+	matcher := &config.Config{Monitor: config.MonitorConfig{JobGroups: patterns}}
+	detected := make(map[string][]string)
+	for _, jobCode := range jobCodes {
+		if group := matcher.JobGroup(jobCode); group != "" {
+			detected[group] = append(detected[group], jobCode)
+		}
+	}
+	return detected
+}
+
+// mergeJobGroups adds the detected job codes to cfg's monitor.job_groups
+// Jobs the explicit configuration already maps to a group keep that group
+func mergeJobGroups(cfg *config.Config, detected map[string][]string) {
+	if len(detected) == 0 {
+		return
+	}
+
+	merged := make(map[string][]string, len(cfg.Monitor.JobGroups)+len(detected))
+	for group, patterns := range cfg.Monitor.JobGroups {
+		merged[group] = append([]string(nil), patterns...)
+	}
+	for group, jobCodes := range detected {
+		for _, jobCode := range jobCodes {
+			if cfg.JobGroup(jobCode) == "" {
+				merged[group] = append(merged[group], jobCode)
+			}
+		}
+	}
+	cfg.Monitor.JobGroups = merged
+}
+
+// loadJobGroups detects the cron groups of the job codes in cron_schedule
+// Group names come from Magento's stock groups and the groups configured in core_config_data
+func loadJobGroups(ctx context.Context, db *database.Client, log *logger.Logger) (map[string][]string, error) {
+	groupConfig, err := db.GetCronGroupConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	jobCodes, err := db.GetJobCodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cronGroups := make([]string, 0, len(groupConfig))
+	for group := range groupConfig {
+		cronGroups = append(cronGroups, group)
+	}
+	sort.Strings(cronGroups)
+
+	detected := detectJobGroups(jobCodes, cronGroups)
+	log.Info("Detected cron groups from job codes", map[string]interface{}{
+		"configured_groups": cronGroups,
+		"job_groups":        detected,
+	})
+	return detected, nil
+}
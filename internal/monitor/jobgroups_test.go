@@ -0,0 +1,77 @@
+package monitor
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/config"
+)
+
+func TestDetectJobGroups(t *testing.T) {
+	jobCodes := []string{
+		"catalog_event_status_checker",
+		"consumers_runner",
+		"default_job",
+		"indexer_reindex_all_invalid",
+		"indexer_update_all_views",
+		"sales_clean_quotes",
+		"staging_apply_version",
+	}
+
+	tests := []struct {
+		name       string
+		cronGroups []string
+		want       map[string][]string
+	}{
+		{
+			name: "stock groups only",
+			want: map[string][]string{
+				"index":     {"indexer_reindex_all_invalid", "indexer_update_all_views"},
+				"consumers": {"consumers_runner"},
+			},
+		},
+		{
+			name:       "groups from core_config_data",
+			cronGroups: []string{"default", "Staging", "catalog_event", "index"},
+			want: map[string][]string{
+				"index":         {"indexer_reindex_all_invalid", "indexer_update_all_views"},
+				"consumers":     {"consumers_runner"},
+				"staging":       {"staging_apply_version"},
+				"catalog_event": {"catalog_event_status_checker"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectJobGroups(jobCodes, tt.cronGroups); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("detectJobGroups() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeJobGroups(t *testing.T) {
+	explicit := map[string][]string{"reindex": {"indexer_reindex_*"}}
+	cfg := &config.Config{Monitor: config.MonitorConfig{JobGroups: explicit}}
+
+	mergeJobGroups(cfg, map[string][]string{
+		"index":     {"indexer_reindex_all_invalid", "indexer_update_all_views"},
+		"consumers": {"consumers_runner"},
+	})
+
+	want := map[string][]string{
+		"reindex":   {"indexer_reindex_*"},
+		"index":     {"indexer_update_all_views"},
+		"consumers": {"consumers_runner"},
+	}
+	if !reflect.DeepEqual(cfg.Monitor.JobGroups, want) {
+		t.Errorf("job_groups = %v, want %v", cfg.Monitor.JobGroups, want)
+	}
+	if got := cfg.JobGroup("indexer_reindex_all_invalid"); got != "reindex" {
+		t.Errorf("expected the explicit group to win, got %q", got)
+	}
+	if len(explicit["reindex"]) != 1 || len(explicit) != 1 {
+		t.Errorf("expected the explicit mapping to be left untouched, got %v", explicit)
+	}
+}
@@ -0,0 +1,63 @@
+package monitor
+
+import (
+	"sort"
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/analyzer"
+)
+
+// maxLeadTimeSamples bounds the number of incidents kept for lead time statistics
+const maxLeadTimeSamples = 500
+
+// leadTimeSummary summarizes alert lead times of recent incidents
+type leadTimeSummary struct {
+	Count int    `json:"count"`
+	P50   string `json:"p50,omitempty"`
+	P90   string `json:"p90,omitempty"`
+	P99   string `json:"p99,omitempty"`
+	Max   string `json:"max,omitempty"`
+}
+
+// recordLeadTime records the delay between an issue first being detected and its first notification
+func (s *Service) recordLeadTime(state *analyzer.JobState, now time.Time) time.Duration {
+	since := state.IssueSince
+	if since.IsZero() {
+		since = state.StuckSince
+	}
+	lead := now.Sub(since)
+
+	state.IncidentNotified = true
+	state.LastLeadTime = lead
+
+	s.alertsMu.Lock()
+	defer s.alertsMu.Unlock()
+	s.leadTimes = append(s.leadTimes, lead)
+	if len(s.leadTimes) > maxLeadTimeSamples {
+		s.leadTimes = s.leadTimes[len(s.leadTimes)-maxLeadTimeSamples:]
+	}
+	return lead
+}
+
+// leadTimeSummary returns percentiles of the recorded lead times
+func (s *Service) leadTimeSummary() leadTimeSummary {
+	s.alertsMu.RLock()
+	defer s.alertsMu.RUnlock()
+
+	summary := leadTimeSummary{Count: len(s.leadTimes)}
+	if summary.Count == 0 {
+		return summary
+	}
+
+	sorted := append([]time.Duration(nil), s.leadTimes...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	percentile := func(p float64) string {
+		return sorted[int(p*float64(len(sorted)-1))].String()
+	}
+	summary.P50 = percentile(0.50)
+	summary.P90 = percentile(0.90)
+	summary.P99 = percentile(0.99)
+	summary.Max = sorted[len(sorted)-1].String()
+	return summary
+}
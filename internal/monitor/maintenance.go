@@ -0,0 +1,29 @@
+package monitor
+
+import (
+	"time"
+)
+
+// checkMaintenance reports whether a maintenance window is active, logging when one starts or ends
+func (s *Service) checkMaintenance(now time.Time) bool {
+	window, active := s.config.ActiveMaintenanceWindow(now)
+	name := ""
+	if active {
+		name = window.Label()
+	}
+
+	if name != s.maintenanceWindow {
+		if active {
+			s.logger.Info("Maintenance window started, alerts are suppressed", map[string]interface{}{
+				"window": name,
+			})
+		} else {
+			s.logger.Info("Maintenance window ended, alerting resumed", map[string]interface{}{
+				"window": s.maintenanceWindow,
+			})
+		}
+		s.maintenanceWindow = name
+	}
+
+	return active
+}
@@ -0,0 +1,144 @@
+package monitor
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/config"
+	"github.com/fabio/go-magento-cron-monitor/internal/cronalert"
+	"github.com/fabio/go-magento-cron-monitor/internal/notifier"
+)
+
+// Reload hands a new configuration to the monitoring loop, which applies it before the next check
+// The configuration must already be validated, as config.Load does. Job states are kept.
+func (s *Service) Reload(cfg *config.Config) {
+	// A reload the loop hasn't picked up yet is superseded
+	select {
+	case <-s.reloadC:
+	default:
+	}
+	s.reloadC <- cfg
+}
+
+// ReloadFailed records a rejected reload and keeps running with the last configuration that loaded
+// With notifications.notify_reload_failure set, every notifier of that configuration is told
+func (s *Service) ReloadFailed(configFile string, reloadErr error) error {
+	s.logger.Error("Config reload failed, keeping the previous configuration", reloadErr, map[string]interface{}{
+		"config": configFile,
+	})
+
+	s.reloadMu.Lock()
+	s.lastReloadError = reloadErr.Error()
+	loadedAt := s.configLoadedAt
+	s.reloadMu.Unlock()
+
+	// The configuration and notifiers are swapped under checkMu on reload
+	s.checkMu.Lock()
+	cfg, notifiers := s.config, s.notifiers
+	s.checkMu.Unlock()
+	if !cfg.Notifications.NotifyReloadFailure || cfg.Monitor.ObserveOnly {
+		return nil
+	}
+
+	alert := cronalert.Alert{
+		Type:           cronalert.Alerting,
+		CronCode:       "CONFIG",
+		Status:         "reload_failed",
+		Timestamp:      time.Now(),
+		Reason:         fmt.Sprintf("config reload failed, keeping previous config (loaded %s): %v", loadedAt.Format(time.RFC3339), reloadErr),
+		Metadata:       cfg.Notifications.Metadata,
+		MagentoVersion: s.magentoVersion,
+	}
+	alert.Severity = cronalert.DeriveSeverity(alert)
+
+	var errs []error
+	for _, n := range notifiers.Notifiers() {
+		if f, ok := n.(notifier.AlertFilter); ok && !f.Accepts(alert) {
+			continue
+		}
+		if err := n.Send(s.ctx, alert); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", n.Name(), err))
+			continue
+		}
+		s.logger.Info("Sent config reload failure notification", map[string]interface{}{
+			"notifier": n.Name(),
+		})
+	}
+	return errors.Join(errs...)
+}
+
+// applyConfig swaps in a reloaded configuration and rebuilds the notifiers from it
+// Settings that only take effect on startup keep their current values
+func (s *Service) applyConfig(cfg *config.Config) {
+	kept := keepStartupSettings(s.config, cfg)
+	mergeJobGroups(cfg, s.detectedJobGroups)
+	notifiers := buildNotifiers(cfg, s.logger)
+
+	s.checkMu.Lock()
+	// Probes read health_stale_after under healthMu
+	s.healthMu.Lock()
+	s.config = cfg
+	s.healthMu.Unlock()
+	s.analyzer.SetConfig(cfg)
+	s.notifiers = notifiers.registry
+	s.escalations = notifiers.escalations
+	s.schedulerEscalations = notifiers.schedulerEscalations
+	s.summaryClient = notifiers.summaryClient
+	s.summaryWebhooks = notifiers.summaryWebhooks
+	s.checkMu.Unlock()
+
+	s.reloadMu.Lock()
+	s.configLoadedAt = time.Now()
+	s.lastReloadError = ""
+	s.reloadMu.Unlock()
+
+	if len(kept) > 0 {
+		s.logger.Warn("Some settings only take effect after a restart, keeping their current values", map[string]interface{}{
+			"settings": kept,
+		})
+	}
+	s.logger.Info("Configuration reloaded", map[string]interface{}{
+		"interval":       cfg.Monitor.Interval.String(),
+		"notifier_count": notifiers.registry.Len(),
+		"job_count":      len(s.analyzer.GetJobStates()),
+	})
+}
+
+// keepStartupSettings copies the settings that are only read on startup from current into cfg
+// It returns the names of those that the reloaded configuration changed
+func keepStartupSettings(current, cfg *config.Config) []string {
+	settings := []struct {
+		name              string
+		current, reloaded interface{}
+	}{
+		{"database", &current.Database, &cfg.Database},
+		{"logging", &current.Logging, &cfg.Logging},
+		{"telemetry", &current.Telemetry, &cfg.Telemetry},
+		{"magento", &current.Magento, &cfg.Magento},
+		{"cluster", &current.Cluster, &cfg.Cluster},
+		{"state", &current.State, &cfg.State},
+		{"export", &current.Export, &cfg.Export},
+		{"snooze", &current.Snooze, &cfg.Snooze},
+		{"monitor.http_addr", &current.Monitor.HTTPAddr, &cfg.Monitor.HTTPAddr},
+		{"monitor.observe_only", &current.Monitor.ObserveOnly, &cfg.Monitor.ObserveOnly},
+		{"monitor.cleanup_interval", &current.Monitor.CleanupInterval, &cfg.Monitor.CleanupInterval},
+		{"monitor.group_intervals", &current.Monitor.GroupIntervals, &cfg.Monitor.GroupIntervals},
+		{"notifications.async", &current.Notifications.Async, &cfg.Notifications.Async},
+		{"notifications.async_queue_size", &current.Notifications.AsyncQueueSize, &cfg.Notifications.AsyncQueueSize},
+		{"notifications.slack.digest_window", &current.Notifications.Slack.DigestWindow, &cfg.Notifications.Slack.DigestWindow},
+		{"notifications.daily_summary.enabled", &current.Notifications.DailySummary.Enabled, &cfg.Notifications.DailySummary.Enabled},
+	}
+
+	var kept []string
+	for _, setting := range settings {
+		currentValue := reflect.ValueOf(setting.current).Elem()
+		reloadedValue := reflect.ValueOf(setting.reloaded).Elem()
+		if !reflect.DeepEqual(currentValue.Interface(), reloadedValue.Interface()) {
+			reloadedValue.Set(currentValue)
+			kept = append(kept, setting.name)
+		}
+	}
+	return kept
+}
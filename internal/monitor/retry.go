@@ -0,0 +1,106 @@
+package monitor
+
+import (
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/cronalert"
+)
+
+// queuedNotification is a notification that failed to send and is retried on later checks
+type queuedNotification struct {
+	Notifier    string          `json:"notifier"`
+	Alert       cronalert.Alert `json:"alert"`
+	FirstFailed time.Time       `json:"first_failed"`
+	Attempts    int             `json:"attempts"`
+}
+
+// enqueueRetry queues a failed notification, dropping the oldest entry when the queue is full
+func (s *Service) enqueueRetry(notifierName string, alert cronalert.Alert, now time.Time) {
+	cfg := s.config.Notifications.Retry
+	if !cfg.Enabled {
+		return
+	}
+
+	s.retryMu.Lock()
+	defer s.retryMu.Unlock()
+
+	if len(s.retryQueue) >= cfg.MaxQueueSize {
+		dropped := s.retryQueue[0]
+		s.retryQueue = s.retryQueue[1:]
+		s.logger.Warn("Notification retry queue full, dropping oldest notification", map[string]interface{}{
+			"cron_code": dropped.Alert.CronCode,
+			"notifier":  dropped.Notifier,
+			"queue_max": cfg.MaxQueueSize,
+		})
+	}
+
+	s.retryQueue = append(s.retryQueue, queuedNotification{
+		Notifier:    notifierName,
+		Alert:       alert,
+		FirstFailed: now,
+		Attempts:    1,
+	})
+}
+
+// flushRetryQueue retries queued notifications, dropping those older than the maximum age
+func (s *Service) flushRetryQueue(now time.Time) {
+	s.retryMu.Lock()
+	defer s.retryMu.Unlock()
+
+	if len(s.retryQueue) == 0 {
+		return
+	}
+
+	cfg := s.config.Notifications.Retry
+	remaining := s.retryQueue[:0]
+
+	for _, queued := range s.retryQueue {
+		if now.Sub(queued.FirstFailed) > cfg.MaxAge {
+			s.logger.Warn("Dropping queued notification (max age exceeded)", map[string]interface{}{
+				"cron_code": queued.Alert.CronCode,
+				"notifier":  queued.Notifier,
+				"attempts":  queued.Attempts,
+				"max_age":   cfg.MaxAge.String(),
+			})
+			continue
+		}
+
+		var sent bool
+		for _, n := range s.notifiers.Notifiers() {
+			if n.Name() != queued.Notifier {
+				continue
+			}
+			if err := n.Send(s.ctx, queued.Alert); err != nil {
+				queued.Attempts++
+				s.logger.Debug("Queued notification retry failed", map[string]interface{}{
+					"cron_code": queued.Alert.CronCode,
+					"notifier":  queued.Notifier,
+					"attempts":  queued.Attempts,
+					"error":     err.Error(),
+				})
+				break
+			}
+			sent = true
+
+			// Count the delivery towards the job's cooldown
+			if state := s.analyzer.GetCronState(queued.Alert.CronCode); state != nil {
+				s.analyzer.MarkNotified(state, n.CooldownKey(), now)
+			}
+
+			s.logger.Info("Sent queued notification", map[string]interface{}{
+				"cron_code":  queued.Alert.CronCode,
+				"notifier":   queued.Notifier,
+				"alert_type": string(queued.Alert.Type),
+				"attempts":   queued.Attempts + 1,
+				"delayed_by": now.Sub(queued.FirstFailed).String(),
+			})
+			break
+		}
+
+		if !sent {
+			remaining = append(remaining, queued)
+		}
+	}
+
+	s.retryQueue = remaining
+}
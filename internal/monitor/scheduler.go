@@ -0,0 +1,90 @@
+package monitor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/analyzer"
+	"github.com/fabio/go-magento-cron-monitor/internal/config"
+	"github.com/fabio/go-magento-cron-monitor/internal/cronalert"
+	"github.com/fabio/go-magento-cron-monitor/internal/logger"
+	"github.com/fabio/go-magento-cron-monitor/internal/notifier"
+)
+
+// schedulerCronAlert builds the notification payload for a scheduler alert
+func (s *Service) schedulerCronAlert(now time.Time, schedulerAlert *logger.StuckCronAlert) cronalert.Alert {
+	alert := cronalert.Alert{
+		Type:           cronalert.Alerting,
+		CronCode:       "SCHEDULER",
+		Status:         "inactive",
+		Timestamp:      now,
+		Metadata:       s.config.Notifications.Metadata,
+		MagentoVersion: s.magentoVersion,
+	}
+	if schedulerAlert != nil {
+		alert.Status = schedulerAlert.Status
+		alert.Reason = schedulerAlert.Reason
+		alert.ConsecutiveStuck = schedulerAlert.ConsecutiveStuck
+		alert.Severity = schedulerAlert.Severity
+	}
+	alert.Severity = cronalert.DeriveSeverity(alert)
+	return alert
+}
+
+// schedulerRecoveryAlert builds the notification payload for the end of a scheduler outage
+// It carries the scheduler alert's severity so it is routed like the alert it resolves
+func (s *Service) schedulerRecoveryAlert(now time.Time, recovery analyzer.StateTransition) cronalert.Alert {
+	alert := cronalert.Alert{
+		Type:           cronalert.NotAlerting,
+		CronCode:       "SCHEDULER",
+		Status:         recovery.Status,
+		StuckDuration:  recovery.StuckDuration,
+		Timestamp:      now,
+		Metadata:       s.config.Notifications.Metadata,
+		MagentoVersion: s.magentoVersion,
+		Severity:       s.config.AlertSeverity(config.DetectionScheduler),
+	}
+	alert.Severity = cronalert.DeriveSeverity(alert)
+	return alert
+}
+
+// notifyScheduler sends a scheduler alert to every registered notifier
+// Repeats are paced by scheduler_alert_cooldown in the analyzer, independent of job cooldowns
+func (s *Service) notifyScheduler(now time.Time, schedulerAlert *logger.StuckCronAlert) error {
+	return s.sendScheduler(now, s.schedulerCronAlert(now, schedulerAlert))
+}
+
+// notifySchedulerRecovery tells every notifier that sends recoveries that the scheduler is active again
+func (s *Service) notifySchedulerRecovery(now time.Time, recovery analyzer.StateTransition) error {
+	return s.sendScheduler(now, s.schedulerRecoveryAlert(now, recovery))
+}
+
+// sendScheduler delivers a scheduler alert or recovery to the registered notifiers
+func (s *Service) sendScheduler(now time.Time, alert cronalert.Alert) error {
+	var errs []error
+	for _, n := range s.notifiers.Notifiers() {
+		if f, ok := n.(notifier.AlertFilter); ok && !f.Accepts(alert) {
+			continue
+		}
+		if alert.Type == cronalert.NotAlerting && !s.sendsRecovery(n) {
+			s.logger.Debug("Skipping recovery notification (disabled)", map[string]interface{}{
+				"cron_code": alert.CronCode,
+				"notifier":  n.Name(),
+			})
+			continue
+		}
+		if err := n.Send(s.ctx, alert); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", n.Name(), err))
+			s.enqueueRetry(n.Name(), alert, now)
+			continue
+		}
+		s.logger.Info("Sent scheduler notification", map[string]interface{}{
+			"notifier":   n.Name(),
+			"alert_type": string(alert.Type),
+			"reason":     alert.Reason,
+		})
+	}
+
+	return errors.Join(errs...)
+}
@@ -2,14 +2,32 @@ package monitor
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/fabio/go-magento-cron-monitor/internal/analyzer"
 	"github.com/fabio/go-magento-cron-monitor/internal/config"
+	"github.com/fabio/go-magento-cron-monitor/internal/cronalert"
 	"github.com/fabio/go-magento-cron-monitor/internal/database"
+	"github.com/fabio/go-magento-cron-monitor/internal/email"
 	"github.com/fabio/go-magento-cron-monitor/internal/logger"
+	"github.com/fabio/go-magento-cron-monitor/internal/metrics"
+	"github.com/fabio/go-magento-cron-monitor/internal/notifier"
+	"github.com/fabio/go-magento-cron-monitor/internal/pagerduty"
 	"github.com/fabio/go-magento-cron-monitor/internal/slack"
+	"github.com/fabio/go-magento-cron-monitor/internal/snooze"
+	"github.com/fabio/go-magento-cron-monitor/internal/state"
+	"github.com/fabio/go-magento-cron-monitor/internal/teams"
+	"github.com/fabio/go-magento-cron-monitor/internal/telemetry"
+	"github.com/fabio/go-magento-cron-monitor/internal/webhook"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Service manages the monitoring loop
@@ -18,19 +36,214 @@ type Service struct {
 	db          *database.Client
 	logger      *logger.Logger
 	analyzer    *analyzer.Analyzer
-	slackClient *slack.Client
+	notifiers   *notifier.Registry
 	verbosity   int
+	// Version of the monitored Magento installation, attached to alerts
+	magentoVersion string
+	// Job groups detected on startup (magento.detect_job_groups), merged into every applied config
+	detectedJobGroups map[string][]string
 	ctx         context.Context
 	cancel      context.CancelFunc
+	checkMu     sync.Mutex // Prevents periodic and on-demand checks from overlapping
+	// Cluster leadership; only the leader sends notifications (nil when clustering is disabled)
+	leaderLock *database.AdvisoryLock
+	isLeader   bool
+	// Persists job states across restarts (nil when state.file is not set)
+	stateStore *state.Store
+	// Alerts of the most recent check of each scope, by monitor.group_intervals group ("" for the main check)
+	lastAlerts map[string][]*logger.StuckCronAlert
+	alertsMu   sync.RWMutex // Guards lastAlerts and leadTimes, which exports read concurrently
+	// Notifications that failed to send, retried on later checks
+	retryQueue []queuedNotification
+	retryMu    sync.Mutex // Guards retryQueue, which async deliveries append to
+	// Background delivery of notifications (nil unless notifications.async is set)
+	dispatcher *dispatcher
+	// Daily summary delivery (nil unless notifications.daily_summary is enabled)
+	summaryClient   *slack.Client
+	summaryWebhooks []string
+	// Recent incidents, oldest first, for the daily summary
+	incidents []incidentRecord
+	// Failed check backoff, only touched by the periodic check loop
+	consecutiveFailures int
+	backoffInterval     time.Duration
+	backoffUntil        time.Time    // Also read by the group check loops
+	backoffMu           sync.RWMutex // Guards backoffUntil
+	// Alert lead times of recent incidents, oldest first
+	leadTimes []time.Duration
+	// Escalation ladder steps, in ascending order of delay
+	escalations []escalationStep
+	// Escalation ladder steps for the scheduler alert
+	schedulerEscalations []escalationStep
+	// Jobs whose stuck notifications are snoozed (nil when snooze.file is not set)
+	snoozes *snooze.List
+	// Notifications collected for the next digest, by notifier (zero window when digests are disabled)
+	digestWindow time.Duration
+	digests      map[string]*pendingDigest
+	digestOrder  []string
+	digestMu     sync.Mutex // Guards digests, which checks fill and the digest ticker flushes
+	// Label of the active maintenance window, empty outside maintenance
+	maintenanceWindow string
+	// Outcome of the most recent check, for the /healthz and /readyz endpoints
+	startedAt      time.Time
+	lastCheckTime  time.Time
+	lastCheckError error
+	healthMu       sync.RWMutex // Guards lastCheckTime and lastCheckError, which probes read concurrently
+	// Metrics served on /metrics
+	metrics *metrics.Metrics
+	// Reloaded configuration waiting to be applied by the monitoring loop
+	reloadC chan *config.Config
+	// The current configuration is the last one that loaded successfully
+	configLoadedAt  time.Time
+	lastReloadError string     // Validation failure of the last rejected reload, empty once a reload succeeds
+	reloadMu        sync.Mutex // Guards configLoadedAt and lastReloadError, which exports read concurrently
+}
+
+// StateExport is the JSON snapshot of the service state for external tooling,
+// written to export.file and served on /state
+type StateExport struct {
+	GeneratedAt    time.Time                     `json:"generated_at"`
+	MagentoVersion string                        `json:"magento_version,omitempty"`
+	JobStates      map[string]*analyzer.JobState `json:"job_states"`
+	SchedulerState analyzer.SchedulerState       `json:"scheduler_state"`
+	ActiveAlerts   []*logger.StuckCronAlert      `json:"active_alerts"`
+	AlertLeadTimes leadTimeSummary               `json:"alert_lead_times"`
+	// When the configuration in use was loaded, and why the last reload was rejected if it was
+	ConfigLoadedAt  time.Time `json:"config_loaded_at"`
+	LastReloadError string    `json:"last_reload_error,omitempty"`
 }
 
 // NewService creates a new monitor service
 func NewService(cfg *config.Config, db *database.Client, log *logger.Logger, verbosity int) *Service {
 	ctx, cancel := context.WithCancel(context.Background())
 
-	// Create Slack client if enabled
-	var slackClient *slack.Client
-	if cfg.Notifications.Slack.Enabled {
+	// Show notification timestamps in the timezone of the logs (checked when the config was loaded)
+	if location, err := cfg.Logging.Location(); err == nil {
+		slack.SetLocation(location)
+	}
+
+	notifiers := buildNotifiers(cfg, log)
+
+	// Resolve the Magento version, falling back to the configured static value
+	magentoVersion := cfg.Magento.Version
+	if db != nil && cfg.Magento.VersionConfigPath != "" {
+		queryCtx, cancelQuery := context.WithTimeout(ctx, cfg.Monitor.QueryTimeout)
+		version, err := db.GetMagentoVersion(queryCtx, cfg.Magento.VersionConfigPath)
+		cancelQuery()
+		if err != nil {
+			log.Warn("Failed to read Magento version from database", map[string]interface{}{
+				"path":  cfg.Magento.VersionConfigPath,
+				"error": err.Error(),
+			})
+		} else if version != "" {
+			magentoVersion = version
+		}
+	}
+
+	// Map job codes to cron groups before the analyzer and the group checks read monitor.job_groups
+	var detectedJobGroups map[string][]string
+	if db != nil && cfg.Magento.DetectJobGroups {
+		queryCtx, cancelQuery := context.WithTimeout(ctx, cfg.Monitor.QueryTimeout)
+		detected, err := loadJobGroups(queryCtx, db, log)
+		cancelQuery()
+		if err != nil {
+			log.Warn("Failed to detect cron groups from database", map[string]interface{}{
+				"error": err.Error(),
+			})
+		} else {
+			detectedJobGroups = detected
+			mergeJobGroups(cfg, detectedJobGroups)
+		}
+	}
+
+	// Coordinate notifications with other instances through a MySQL advisory lock
+	var leaderLock *database.AdvisoryLock
+	if db != nil && cfg.Cluster.Backend == "mysql" && !cfg.Monitor.ObserveOnly {
+		leaderLock = db.NewAdvisoryLock(cfg.Cluster.LockName)
+		log.Info("Cluster coordination enabled", map[string]interface{}{
+			"backend":   cfg.Cluster.Backend,
+			"lock_name": cfg.Cluster.LockName,
+		})
+	}
+
+	svc := &Service{
+		config:      cfg,
+		db:          db,
+		logger:      log,
+		analyzer:    analyzer.NewAnalyzer(cfg),
+		notifiers:   notifiers.registry,
+		verbosity:   verbosity,
+		ctx:         ctx,
+		cancel:      cancel,
+
+		magentoVersion:    magentoVersion,
+		detectedJobGroups: detectedJobGroups,
+		leaderLock:        leaderLock,
+		escalations:       notifiers.escalations,
+
+		schedulerEscalations: notifiers.schedulerEscalations,
+		summaryClient:        notifiers.summaryClient,
+		summaryWebhooks:      notifiers.summaryWebhooks,
+		digestWindow:         cfg.Notifications.Slack.DigestWindow,
+		metrics:              metrics.New(),
+		reloadC:              make(chan *config.Config, 1),
+		configLoadedAt:       time.Now(),
+	}
+
+	if cfg.Notifications.Async && notifiers.registry.Len() > 0 {
+		svc.dispatcher = newDispatcher(ctx, cfg.Notifications.AsyncQueueSize)
+	}
+
+	// Snooze notifications for jobs listed in the snooze file
+	if cfg.Snooze.File != "" {
+		svc.snoozes = snooze.NewList(cfg.Snooze.File)
+		if err := svc.snoozes.Reload(); err != nil {
+			log.Warn("Failed to load snooze file", map[string]interface{}{
+				"file":  cfg.Snooze.File,
+				"error": err.Error(),
+			})
+		}
+		svc.analyzer.SetSnoozeFunc(func(jobCode string) bool {
+			return svc.snoozedJob(jobCode, time.Now()) != nil
+		})
+	}
+
+	// Restore job states persisted by a previous run
+	if cfg.State.File != "" {
+		store, err := state.NewStore(cfg.State.File, cfg.State.EncryptionKey)
+		if err != nil {
+			log.Warn("State persistence disabled", map[string]interface{}{
+				"error": err.Error(),
+			})
+		} else {
+			svc.stateStore = store
+			svc.restoreState()
+		}
+	}
+
+	return svc
+}
+
+// notifierSet holds the notifiers built from the notification settings
+type notifierSet struct {
+	registry             *notifier.Registry
+	escalations          []escalationStep
+	schedulerEscalations []escalationStep
+	// Daily summary delivery (nil client unless notifications.daily_summary is enabled)
+	summaryClient   *slack.Client
+	summaryWebhooks []string
+}
+
+// buildNotifiers creates the enabled notifiers, escalation steps and daily summary client
+// In observe mode no notifiers are created
+func buildNotifiers(cfg *config.Config, log *logger.Logger) notifierSet {
+	// Register enabled notifiers
+	notifiers := notifier.NewRegistry()
+	var escalations, schedulerEscalations []escalationStep
+	var summaryClient *slack.Client
+	var summaryWebhooks []string
+	if cfg.Monitor.ObserveOnly {
+		log.Info("Observe mode enabled: notifications, escalation and cluster locking are disabled", nil)
+	} else if cfg.Notifications.Slack.Enabled {
 		slackConfig := slack.Config{
 			Enabled:          cfg.Notifications.Slack.Enabled,
 			WebhookURLs:      cfg.Notifications.Slack.WebhookURLs,
@@ -38,8 +251,47 @@ func NewService(cfg *config.Config, db *database.Client, log *logger.Logger, ver
 			SendRecovery:     cfg.Notifications.Slack.SendRecovery,
 			RecoveryCooldown: cfg.Notifications.Slack.RecoveryCooldown,
 			Timeout:          cfg.Notifications.Slack.Timeout,
+			MaxIdleConns:     cfg.Notifications.Slack.MaxIdleConns,
+			DisableKeepAlive: cfg.Notifications.Slack.DisableKeepAlive,
+			MaxMessageBytes:  cfg.Notifications.Slack.MaxMessageBytes,
+			MaxRetries:       *cfg.Notifications.Slack.MaxRetries,
+		}
+		slackClient := slack.New(slackConfig).OnRetry(func(webhook, attempt int, delay time.Duration, err error) {
+			log.Debug("Retrying Slack webhook", map[string]interface{}{
+				"webhook": webhook,
+				"attempt": attempt,
+				"delay":   delay.String(),
+				"error":   err.Error(),
+			})
+		})
+		slackNotifier := notifier.NewSlack(slackClient, cfg.GetSlackWebhookURLs).WithCriticalRoute(cfg.Notifications.Slack.CriticalWebhookURLs)
+		if len(cfg.Notifications.Slack.Routes) > 0 {
+			slackNotifier.WithAlertRoutes(func(alert cronalert.Alert) ([]string, string, bool) {
+				return cfg.MatchSlackRoute(alert.CronCode, alert.CronGroup, alert.Severity)
+			})
+		}
+		notifiers.Register(slackNotifier)
+
+		// Escalation steps reuse the Slack client with their own webhooks
+		for _, step := range cfg.Notifications.Escalation {
+			escalations = append(escalations, escalationStep{
+				After:    step.After,
+				Notifier: notifier.NewSlack(slackClient, notifier.StaticRoute(step.WebhookURLs)),
+			})
+		}
+		for _, step := range cfg.Notifications.SchedulerEscalation {
+			schedulerEscalations = append(schedulerEscalations, escalationStep{
+				After:    step.After,
+				Notifier: notifier.NewSlack(slackClient, notifier.StaticRoute(step.WebhookURLs)),
+			})
+		}
+		if summary := cfg.Notifications.DailySummary; summary.Enabled {
+			summaryClient = slackClient
+			summaryWebhooks = summary.WebhookURLs
+			if len(summaryWebhooks) == 0 {
+				summaryWebhooks = cfg.Notifications.Slack.WebhookURLs
+			}
 		}
-		slackClient = slack.New(slackConfig)
 		log.Info("Slack notifications enabled", map[string]interface{}{
 			"webhook_count":     len(slackConfig.WebhookURLs),
 			"alert_cooldown":    slackConfig.AlertCooldown.String(),
@@ -47,34 +299,276 @@ func NewService(cfg *config.Config, db *database.Client, log *logger.Logger, ver
 			"recovery_cooldown": slackConfig.RecoveryCooldown.String(),
 		})
 	}
+	if !cfg.Monitor.ObserveOnly && cfg.Notifications.Email.Enabled {
+		emailConfig := email.Config{
+			Enabled:      cfg.Notifications.Email.Enabled,
+			Host:         cfg.Notifications.Email.Host,
+			Port:         cfg.Notifications.Email.Port,
+			Username:     cfg.Notifications.Email.Username,
+			Password:     cfg.Notifications.Email.Password,
+			From:         cfg.Notifications.Email.From,
+			To:           cfg.Notifications.Email.To,
+			TLSMode:      cfg.Notifications.Email.TLS,
+			SendRecovery: cfg.Notifications.Email.SendRecovery,
+			Timeout:      cfg.Notifications.Email.Timeout,
+		}
+		notifiers.Register(notifier.NewEmail(email.New(emailConfig)))
+		log.Info("Email notifications enabled", map[string]interface{}{
+			"smtp_host":       emailConfig.Host,
+			"smtp_port":       emailConfig.Port,
+			"tls":             emailConfig.TLSMode,
+			"recipient_count": len(emailConfig.To),
+			"send_recovery":   emailConfig.SendRecovery,
+		})
+	}
+	if !cfg.Monitor.ObserveOnly && cfg.Notifications.Teams.Enabled {
+		teamsConfig := teams.Config{
+			Enabled:          cfg.Notifications.Teams.Enabled,
+			WebhookURLs:      cfg.Notifications.Teams.WebhookURLs,
+			AlertCooldown:    cfg.Notifications.Teams.AlertCooldown,
+			SendRecovery:     cfg.Notifications.Teams.SendRecovery,
+			RecoveryCooldown: cfg.Notifications.Teams.RecoveryCooldown,
+			Timeout:          cfg.Notifications.Teams.Timeout,
+		}
+		notifiers.Register(notifier.NewTeams(teams.New(teamsConfig)))
+		log.Info("Teams notifications enabled", map[string]interface{}{
+			"webhook_count":     len(teamsConfig.WebhookURLs),
+			"alert_cooldown":    teamsConfig.AlertCooldown.String(),
+			"send_recovery":     teamsConfig.SendRecovery,
+			"recovery_cooldown": teamsConfig.RecoveryCooldown.String(),
+		})
+	}
+	if !cfg.Monitor.ObserveOnly && cfg.Notifications.Webhook.Enabled {
+		webhookConfig := webhook.Config{
+			Enabled:      cfg.Notifications.Webhook.Enabled,
+			URLs:         cfg.Notifications.Webhook.URLs,
+			Method:       cfg.Notifications.Webhook.Method,
+			Headers:      cfg.Notifications.Webhook.Headers,
+			Template:     cfg.Notifications.Webhook.Template,
+			SendRecovery: cfg.Notifications.Webhook.SendRecovery,
+			Timeout:      cfg.Notifications.Webhook.Timeout,
+		}
+		// The template was checked when the configuration was loaded
+		if client, err := webhook.New(webhookConfig); err != nil {
+			log.Error("Webhook notifications disabled", err, nil)
+		} else {
+			notifiers.Register(notifier.NewWebhook(client))
+			log.Info("Webhook notifications enabled", map[string]interface{}{
+				"url_count":     len(webhookConfig.URLs),
+				"method":        webhookConfig.Method,
+				"send_recovery": webhookConfig.SendRecovery,
+			})
+		}
+	}
+	if !cfg.Monitor.ObserveOnly && cfg.Notifications.PagerDuty.Enabled {
+		pdConfig := pagerduty.Config{
+			Enabled:     cfg.Notifications.PagerDuty.Enabled,
+			RoutingKey:  cfg.Notifications.PagerDuty.RoutingKey,
+			EventsURL:   cfg.Notifications.PagerDuty.EventsURL,
+			Source:      cfg.Notifications.PagerDuty.Source,
+			MinSeverity: cfg.Notifications.PagerDuty.MinSeverity,
+			Timeout:     cfg.Notifications.PagerDuty.Timeout,
+		}
+		notifiers.Register(notifier.NewPagerDuty(pagerduty.New(pdConfig)))
+		log.Info("PagerDuty notifications enabled", map[string]interface{}{
+			"min_severity": pdConfig.MinSeverity,
+			"source":       pdConfig.Source,
+		})
+	}
 
-	return &Service{
-		config:      cfg,
-		db:          db,
-		logger:      log,
-		analyzer:    analyzer.NewAnalyzer(cfg),
-		slackClient: slackClient,
-		verbosity:   verbosity,
-		ctx:         ctx,
-		cancel:      cancel,
+	return notifierSet{
+		registry:             notifiers,
+		escalations:          escalations,
+		schedulerEscalations: schedulerEscalations,
+		summaryClient:        summaryClient,
+		summaryWebhooks:      summaryWebhooks,
+	}
+}
+
+// persistedService is the serialized form of the service's state across restarts
+type persistedService struct {
+	Analyzer   json.RawMessage      `json:"analyzer"`
+	RetryQueue []queuedNotification `json:"retry_queue,omitempty"`
+	Incidents  []incidentRecord     `json:"incidents,omitempty"`
+}
+
+// restoreState loads persisted job states and queued notifications
+// An unreadable file (e.g. a missing or rotated encryption key) is discarded and monitoring starts fresh
+func (s *Service) restoreState() {
+	data, err := s.stateStore.Load()
+	if err != nil || data == nil {
+		if err != nil {
+			s.logger.Warn("Discarding persisted state, starting fresh", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+		return
+	}
+
+	var persisted persistedService
+	if err = json.Unmarshal(data, &persisted); err == nil {
+		err = s.analyzer.RestoreState(persisted.Analyzer)
+	}
+	if err != nil {
+		s.logger.Warn("Discarding persisted state, starting fresh", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+	s.retryQueue = persisted.RetryQueue
+	s.incidents = persisted.Incidents
+
+	s.logger.Info("Restored persisted state", map[string]interface{}{
+		"job_count":    len(s.analyzer.GetJobStates()),
+		"queued_count": len(s.retryQueue),
+	})
+}
+
+// saveState persists the job states and queued notifications if persistence is enabled
+func (s *Service) saveState() {
+	if s.stateStore == nil {
+		return
+	}
+
+	analyzerState, err := s.analyzer.MarshalState()
+	var data []byte
+	if err == nil {
+		s.retryMu.Lock()
+		data, err = json.Marshal(persistedService{
+			Analyzer:   analyzerState,
+			RetryQueue: s.retryQueue,
+			Incidents:  s.incidents,
+		})
+		s.retryMu.Unlock()
+	}
+	if err == nil {
+		err = s.stateStore.Save(data)
+	}
+	if err != nil {
+		s.logger.Warn("Failed to persist state", map[string]interface{}{
+			"error": err.Error(),
+		})
 	}
 }
 
 // Start begins the monitoring loop
 func (s *Service) Start() error {
-	s.logger.Info("Monitor service started", nil)
+	startFields := map[string]interface{}{}
+	if s.magentoVersion != "" {
+		startFields["magento_version"] = s.magentoVersion
+	}
+	s.logger.Info("Monitor service started", startFields)
+	s.startedAt = time.Now()
+	if err := s.startHTTPServer(); err != nil {
+		return err
+	}
 	s.logger.Info("Monitoring ticker interval", map[string]interface{}{
 		"interval": s.config.Monitor.Interval.String(),
 	})
 
-	ticker := time.NewTicker(s.config.Monitor.Interval)
-	defer ticker.Stop()
+	// With alignment the ticker starts at the next wall-clock boundary of the interval,
+	// with jitter a timer is re-armed with a randomized interval after every tick
+	var ticker *time.Ticker
+	var alignTimer, jitterTimer *time.Timer
+	var tickC, alignC <-chan time.Time
+	startTicker := func() {
+		if ticker != nil {
+			ticker.Stop()
+			ticker, tickC = nil, nil
+		}
+		if jitterTimer != nil {
+			jitterTimer.Stop()
+			jitterTimer, tickC = nil, nil
+		}
+		if s.config.Monitor.AlignToInterval {
+			next := nextBoundary(time.Now(), s.config.Monitor.Interval)
+			if alignTimer != nil {
+				alignTimer.Stop()
+			}
+			alignTimer = time.NewTimer(time.Until(next))
+			alignC = alignTimer.C
+			s.logger.Info("Aligning checks to interval boundaries", map[string]interface{}{
+				"first_aligned_check": next.Format(time.RFC3339),
+			})
+		} else if s.config.Monitor.IntervalJitter > 0 {
+			jitterTimer = time.NewTimer(s.jitteredInterval())
+			tickC = jitterTimer.C
+		} else {
+			ticker = time.NewTicker(s.config.Monitor.Interval)
+			tickC = ticker.C
+		}
+	}
+	startTicker()
+	defer func() {
+		if ticker != nil {
+			ticker.Stop()
+		}
+		if alignTimer != nil {
+			alignTimer.Stop()
+		}
+		if jitterTimer != nil {
+			jitterTimer.Stop()
+		}
+	}()
+
+	// Optional periodic state export (a nil channel never fires)
+	var exportC <-chan time.Time
+	if s.config.Export.File != "" && s.config.Export.Interval > 0 {
+		exportTicker := time.NewTicker(s.config.Export.Interval)
+		defer exportTicker.Stop()
+		exportC = exportTicker.C
+	}
+
+	s.watchSnoozes()
+
+	// Optional cron_schedule cleanup, never in observe mode since it writes to the database
+	var cleanupC <-chan time.Time
+	if s.config.Monitor.CleanupInterval > 0 && !s.config.Monitor.ObserveOnly {
+		cleanupTicker := time.NewTicker(s.config.Monitor.CleanupInterval)
+		defer cleanupTicker.Stop()
+		cleanupC = cleanupTicker.C
+	}
+
+	// Optional daily summary (a nil channel never fires)
+	var summaryTimer *time.Timer
+	var summaryC <-chan time.Time
+	if s.summaryClient != nil {
+		next := s.nextSummaryTime(time.Now())
+		summaryTimer = time.NewTimer(time.Until(next))
+		defer summaryTimer.Stop()
+		summaryC = summaryTimer.C
+		s.logger.Info("Daily summary scheduled", map[string]interface{}{
+			"next_summary": next.Format(time.RFC3339),
+		})
+	}
+
+	// Optional notification digests (a nil channel never fires)
+	var digestC <-chan time.Time
+	if s.digestsEnabled() {
+		digestTicker := time.NewTicker(s.digestWindow)
+		defer digestTicker.Stop()
+		digestC = digestTicker.C
+	}
 
-	// Run initial check immediately
-	if err := s.runCheck(); err != nil {
-		s.logger.Error("Initial check failed", err, nil)
+	// Spread the first checks of instances started at the same time
+	if jitter := s.config.Monitor.IntervalJitter; jitter > 0 {
+		delay := time.Duration(rand.Int63n(int64(jitter)))
+		s.logger.Info("Delaying first check", map[string]interface{}{
+			"delay": delay.Round(time.Millisecond).String(),
+		})
+		select {
+		case <-time.After(delay):
+		case <-s.ctx.Done():
+			return nil
+		}
 	}
 
+	// Run initial check
+	s.runScheduledCheck()
+
+	// Groups with their own interval are checked by loops of their own
+	s.startGroupChecks()
+
 	// Main monitoring loop
 	for {
 		select {
@@ -82,57 +576,252 @@ func (s *Service) Start() error {
 			s.logger.Debug("Monitor service stopping...", nil)
 			return nil
 
-		case <-ticker.C:
-			if err := s.runCheck(); err != nil {
-				s.logger.Error("Check failed", err, nil)
+		case <-alignC:
+			alignC = nil
+			ticker = time.NewTicker(s.config.Monitor.Interval)
+			tickC = ticker.C
+			s.runScheduledCheck()
+
+		case <-tickC:
+			if jitterTimer != nil {
+				jitterTimer.Reset(s.jitteredInterval())
+			}
+			s.runScheduledCheck()
+
+		case <-summaryC:
+			s.sendDailySummary(time.Now())
+			summaryTimer.Reset(time.Until(s.nextSummaryTime(time.Now())))
+
+		case <-digestC:
+			s.flushDigests(time.Now())
+
+		case <-cleanupC:
+			s.cleanupSchedules()
+
+		case <-exportC:
+			if err := s.ExportState(); err != nil {
+				s.logger.Error("State export failed", err, nil)
+			}
+
+		case cfg := <-s.reloadC:
+			interval, jitter := s.config.Monitor.Interval, s.config.Monitor.IntervalJitter
+			s.applyConfig(cfg)
+			if s.config.Monitor.Interval != interval || s.config.Monitor.IntervalJitter != jitter {
+				startTicker()
 			}
 		}
 	}
 }
 
+// nextBoundary returns the next wall-clock multiple of interval after now
+func nextBoundary(now time.Time, interval time.Duration) time.Time {
+	return now.Truncate(interval).Add(interval)
+}
+
+// jitteredInterval returns the check interval randomized by up to ±monitor.interval_jitter
+func (s *Service) jitteredInterval() time.Duration {
+	jitter := s.config.Monitor.IntervalJitter
+	return s.config.Monitor.Interval - jitter + time.Duration(rand.Int63n(int64(2*jitter)+1))
+}
+
 // Stop gracefully stops the monitoring service
 func (s *Service) Stop() {
+	// Deliver queued notifications before the service context they are sent with is cancelled
+	if s.dispatcher != nil && !s.dispatcher.drain(drainTimeout) {
+		s.logger.Warn("Cancelled queued notifications (drain timed out)", map[string]interface{}{
+			"timeout": drainTimeout.String(),
+		})
+	}
+
+	// Send notifications still waiting for their digest window, also with the service context
+	if s.digestsEnabled() {
+		s.flushDigests(time.Now())
+	}
+
 	s.cancel()
+
+	// Persist queued notifications and digests that failed for retry after a restart
+	if s.dispatcher != nil || s.digestsEnabled() {
+		s.checkMu.Lock()
+		s.saveState()
+		s.checkMu.Unlock()
+	}
+
+	// Hand leadership to another instance right away
+	if s.leaderLock != nil {
+		if err := s.leaderLock.Release(); err != nil {
+			s.logger.Warn("Failed to release cluster leadership", map[string]interface{}{
+				"error": err.Error(),
+			})
+		}
+	}
 }
 
-// runCheck performs a single monitoring check
-func (s *Service) runCheck() error {
+// checkLeadership reports whether this instance may send notifications
+// Without clustering every instance is the leader; on lock errors the instance stays a follower
+func (s *Service) checkLeadership() bool {
+	if s.leaderLock == nil {
+		return true
+	}
+
+	leader, err := s.leaderLock.TryAcquire(s.ctx)
+	if err != nil {
+		s.logger.Warn("Failed to check cluster leadership", map[string]interface{}{
+			"error": err.Error(),
+		})
+		leader = false
+	}
+
+	if leader != s.isLeader {
+		if leader {
+			s.logger.Info("Became cluster leader", map[string]interface{}{
+				"lock_name": s.config.Cluster.LockName,
+			})
+		} else {
+			s.logger.Info("Lost cluster leadership", map[string]interface{}{
+				"lock_name": s.config.Cluster.LockName,
+			})
+		}
+		s.isLeader = leader
+	}
+
+	return leader
+}
+
+// RunOnce performs a single monitoring check of every job, including groups with their own interval
+// It is safe to call concurrently with the monitoring loop; overlapping checks query the database
+// concurrently and take turns analyzing and notifying
+func (s *Service) RunOnce() error {
+	return s.runOnce(checkScope{all: true})
+}
+
+// runOnce performs a single monitoring check of the jobs in scope
+// Only main checks count for the health endpoints, so frequent group checks can't hide a failing main check
+func (s *Service) runOnce(scope checkScope) error {
+	err := s.runCheck(scope)
+	if scope.main() {
+		s.recordCheck(err, time.Now())
+	}
+	return err
+}
+
+// Detect runs every detection of a check once and returns the alerts
+// Unlike RunOnce it doesn't log alerts, send notifications or persist state
+func (s *Service) Detect() ([]*logger.StuckCronAlert, error) {
+	s.checkMu.Lock()
+	defer s.checkMu.Unlock()
+	scope := checkScope{all: true}
+	schedules, err := s.fetchSchedules(s.ctx, s.config, scope)
+	if err != nil {
+		return nil, err
+	}
+	_, alerts, _ := s.analyze(s.ctx, scope, schedules)
+	s.setLastAlerts(scope, alerts)
+	return alerts, nil
+}
+
+// currentConfig returns the configuration in use, for code that runs outside checkMu
+// Reloads swap it under healthMu as well as checkMu
+func (s *Service) currentConfig() *config.Config {
+	s.healthMu.RLock()
+	defer s.healthMu.RUnlock()
+	return s.config
+}
+
+// queryContext bounds a database query by monitor.query_timeout
+// It derives from the service context, so shutdown also cancels in-flight queries
+func (s *Service) queryContext(parent context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(parent, s.config.Monitor.QueryTimeout)
+}
+
+// runCheck performs a single monitoring check of the jobs in scope
+// The cron_schedule query runs before taking checkMu, so a slow query of one check doesn't hold up
+// the checks of other groups; analysis and notifications share the job states and take turns
+func (s *Service) runCheck(scope checkScope) error {
 	s.logger.Debug("Running cron check...", nil)
 
+	ctx, span := telemetry.Tracer().Start(s.ctx, "runCheck")
+	defer span.End()
+
 	start := time.Now()
 
-	// Fetch recent cron schedules
-	schedules, err := s.db.GetRecentCronSchedules(s.config.Monitor.Detection.LookbackWindow)
+	schedules, err := s.fetchSchedules(ctx, s.currentConfig(), scope)
 	if err != nil {
-		return fmt.Errorf("failed to fetch cron schedules: %w", err)
+		return err
 	}
 
-	s.logger.Debug("Fetched cron schedules", map[string]interface{}{
-		"count":    len(schedules),
-		"duration": time.Since(start).String(),
-	})
+	s.checkMu.Lock()
+	defer s.checkMu.Unlock()
 
-	// Analyze for stuck crons
-	alerts := s.analyzer.Analyze(schedules)
+	// Alerts are held back during maintenance windows
+	maintenance := s.checkMaintenance(time.Now())
 
-	// Check scheduler health
-	if schedulerAlert := s.analyzer.CheckSchedulerHealth(s.db); schedulerAlert != nil {
-		alerts = append(alerts, schedulerAlert)
-	}
+	schedules, alerts, schedulerAlert := s.analyze(ctx, scope, schedules)
 
 	// Log alerts
 	for _, alert := range alerts {
-		s.logger.LogStuckCron(alert)
+		s.metrics.RecordAlert(alert.Detection, alert.JobCode)
+		if s.config.Monitor.ObserveOnly {
+			s.logger.LogObservedCron(alert)
+		} else {
+			s.logger.LogStuckCron(alert)
+		}
 	}
 
-	// Detect state transitions for Slack notifications
-	if s.slackClient != nil {
+	s.setLastAlerts(scope, alerts)
+
+	// Detect state transitions and send notifications
+	s.notify(ctx, scope, schedules, alerts, schedulerAlert, maintenance)
+
+	// Log summary
+	span.SetAttributes(
+		attribute.Int("schedules.count", len(schedules)),
+		attribute.Int("alerts.count", len(alerts)),
+		attribute.String("check.duration", time.Since(start).String()),
+	)
+	s.logCheckSummary(scope, schedules, alerts, time.Since(start))
+	// Group checks only see part of the jobs, they'd make the per-check series jump between scopes
+	if scope.main() {
+		s.recordCheckMetrics(time.Since(start))
+	}
+
+	// Persist state after notifications so cooldowns survive a restart
+	s.saveState()
+
+	return nil
+}
+
+// notify detects the state transitions of a check and sends, escalates or (in observe mode) logs the notifications
+func (s *Service) notify(ctx context.Context, scope checkScope, schedules []*database.CronSchedule, alerts []*logger.StuckCronAlert, schedulerAlert *logger.StuckCronAlert, maintenance bool) {
+	if s.config.Monitor.ObserveOnly {
+		s.logObservedTransitions(s.analyzer.DetectStateTransitions(schedules), schedulerAlert)
+	} else if s.notifiers.Len() > 0 {
+		_, notifySpan := telemetry.Tracer().Start(ctx, "notify")
+		defer notifySpan.End()
+
 		transitions := s.analyzer.DetectStateTransitions(schedules)
+		notifySpan.SetAttributes(attribute.Int("transitions.count", len(transitions)))
+		s.recordIncidents(time.Now(), transitions)
+
+		// Followers keep their state warm but leave sending to the leader
+		leader := s.checkLeadership()
+		if leader {
+			// Deliver notifications that failed on earlier checks before new ones
+			s.flushRetryQueue(time.Now())
+		} else if len(transitions) > 0 {
+			s.logger.Debug("Skipping notifications (not cluster leader)", map[string]interface{}{
+				"transitions": len(transitions),
+			})
+			transitions = nil
+		}
 		
 		// Create alert lookup map for enriching transitions
 		alertMap := make(map[string]*logger.StuckCronAlert)
 		for _, alert := range alerts {
-			alertMap[alert.JobCode] = alert
+			// Keep the first (highest priority) alert per job
+			if _, exists := alertMap[alert.JobCode]; !exists {
+				alertMap[alert.JobCode] = alert
+			}
 		}
 		
 		for _, transition := range transitions {
@@ -143,21 +832,190 @@ func (s *Service) runCheck() error {
 			}
 			
 			if err := s.handleStateTransition(transition, time.Now(), enrichedAlert); err != nil {
-				s.logger.Error("Failed to send Slack notification", err, map[string]interface{}{
+				s.logger.Error("Failed to send notification", err, map[string]interface{}{
 					"cron_code": transition.CronCode,
 				})
 			}
 		}
+
+		if leader && schedulerAlert != nil {
+			if err := s.notifyScheduler(time.Now(), schedulerAlert); err != nil {
+				s.logger.Error("Failed to send scheduler notification", err, nil)
+			}
+		}
+		if leader && scope.main() {
+			if recovery := s.analyzer.SchedulerRecovery(); recovery != nil {
+				if err := s.notifySchedulerRecovery(time.Now(), *recovery); err != nil {
+					s.logger.Error("Failed to send scheduler recovery notification", err, nil)
+				}
+			}
+		}
+
+		// Escalate jobs and a scheduler that stay stuck
+		if leader && !maintenance {
+			s.escalate(time.Now(), alertMap, scope)
+			if scope.main() {
+				s.escalateScheduler(time.Now(), schedulerAlert)
+			}
+		}
 	}
+}
 
-	// Log summary
-	s.logCheckSummary(schedules, alerts, time.Since(start))
+// fetchSchedules fetches the recent cron schedules with the settings of cfg
+// It doesn't touch the job states, so it doesn't need checkMu
+func (s *Service) fetchSchedules(ctx context.Context, cfg *config.Config, scope checkScope) ([]*database.CronSchedule, error) {
+	start := time.Now()
 
-	return nil
+	_, fetchSpan := telemetry.Tracer().Start(ctx, "fetchSchedules")
+	queryCtx, cancelQuery := context.WithTimeout(ctx, cfg.Monitor.QueryTimeout)
+	schedules, err := s.db.GetRecentCronSchedules(queryCtx, cfg.Monitor.Detection.LookbackWindow, cfg.Monitor.Detection.LookbackField)
+	cancelQuery()
+	if errors.Is(err, context.DeadlineExceeded) {
+		err = fmt.Errorf("query timed out after %s: %w", cfg.Monitor.QueryTimeout, err)
+	}
+	fetchSpan.SetAttributes(attribute.Int("schedules.count", len(schedules)))
+	s.metrics.SetDatabaseUp(err == nil)
+	if err != nil {
+		fetchSpan.RecordError(err)
+		fetchSpan.SetStatus(codes.Error, err.Error())
+		fetchSpan.End()
+		trace.SpanFromContext(ctx).SetStatus(codes.Error, "failed to fetch cron schedules")
+		return nil, fmt.Errorf("failed to fetch cron schedules: %w", err)
+	}
+	fetchSpan.End()
+
+	// Duplicate rows (e.g. a lagging replica) would inflate the count-based checks
+	schedules, duplicates := database.DedupSchedules(schedules)
+	if duplicates > 0 {
+		s.logger.Debug("Dropped duplicate cron schedules", map[string]interface{}{
+			"duplicates": duplicates,
+		})
+	}
+	if scope.main() {
+		s.metrics.SetSchedulesFetched(len(schedules))
+	}
+
+	s.logger.Debug("Fetched cron schedules", map[string]interface{}{
+		"count":    len(schedules),
+		"duration": time.Since(start).String(),
+	})
+	return schedules, nil
+}
+
+// analyze runs every detection over the jobs in scope of the fetched schedules
+// Detections over all rows and the scheduler only run in the main check. The caller holds checkMu.
+// It returns the schedules in scope, all alerts (including the scheduler alert) and the scheduler alert on its own
+func (s *Service) analyze(ctx context.Context, scope checkScope, schedules []*database.CronSchedule) ([]*database.CronSchedule, []*logger.StuckCronAlert, *logger.StuckCronAlert) {
+	// Treat renamed jobs as their canonical job so history and streaks carry over
+	if len(s.config.Monitor.Aliases) > 0 {
+		for _, sched := range schedules {
+			sched.JobCode = s.config.CanonicalJobCode(sched.JobCode)
+		}
+	}
+
+	// Resolve each job's group once per check
+	if len(s.config.Monitor.JobGroups) > 0 {
+		groups := make(map[string]string)
+		for _, sched := range schedules {
+			group, ok := groups[sched.JobCode]
+			if !ok {
+				group = s.config.JobGroup(sched.JobCode)
+				groups[sched.JobCode] = group
+			}
+			sched.CronGroup = group
+		}
+	}
+
+	// Analyze for stuck crons, per-job detections only see the jobs in scope
+	_, analyzeSpan := telemetry.Tracer().Start(ctx, "analyze")
+	scoped := scope.schedules(s.config, schedules)
+	alerts := s.analyzer.Analyze(scoped)
+
+	// Check for jobs running off their schedule
+	alerts = append(alerts, s.analyzer.CheckCadence(scoped)...)
+
+	// The scheduler and the rows of all jobs are checked by the main check
+	var schedulerAlert *logger.StuckCronAlert
+	if scope.main() {
+		// Check scheduler health (one query for the created and upcoming counts)
+		healthStart := time.Now()
+		queryCtx, cancelQuery := s.queryContext(ctx)
+		schedulerAlert = s.analyzer.CheckSchedulerHealth(queryCtx, s.db)
+		cancelQuery()
+		s.logger.Debug("Checked scheduler health", map[string]interface{}{
+			"duration": time.Since(healthStart).String(),
+		})
+		if schedulerAlert != nil {
+			alerts = append(alerts, schedulerAlert)
+		}
+
+		// Check for individual jobs that stopped being scheduled
+		alerts = append(alerts, s.analyzer.CheckJobDropouts(schedules)...)
+
+		// Check for rows with executed_at in the future
+		if suspiciousAlert := s.analyzer.CheckSuspiciousRows(schedules); suspiciousAlert != nil {
+			alerts = append(alerts, suspiciousAlert)
+		}
+
+		// Check for resets and jumps of the schedule_id sequence
+		if sequenceAlert := s.analyzer.CheckScheduleIDSequence(schedules); sequenceAlert != nil {
+			alerts = append(alerts, sequenceAlert)
+		}
+
+		// Check for malformed rows without a scheduled_at
+		if nullAlert := s.analyzer.CheckNullScheduledAt(schedules); nullAlert != nil {
+			alerts = append(alerts, nullAlert)
+		}
+
+		// Check for rows whose timing fields contradict their status
+		if timingAlert := s.analyzer.CheckTimingConsistency(schedules); timingAlert != nil {
+			alerts = append(alerts, timingAlert)
+		}
+		s.logTimingInconsistencies(schedules)
+
+		// Check for an unexpectedly empty result
+		if emptyAlert := s.analyzer.CheckEmptyResult(schedules); emptyAlert != nil {
+			alerts = append(alerts, emptyAlert)
+		}
+
+		// Check expected jobs that were never scheduled or have gone missing
+		queryCtx, cancelQuery = s.queryContext(ctx)
+		alerts = append(alerts, s.analyzer.CheckExpectedJobs(queryCtx, schedules, s.db)...)
+		cancelQuery()
+	}
+	analyzeSpan.SetAttributes(attribute.Int("alerts.count", len(alerts)))
+	analyzeSpan.End()
+
+	for _, alert := range alerts {
+		alert.MagentoVersion = s.magentoVersion
+		alert.CronGroup = s.config.JobGroup(alert.JobCode)
+	}
+
+	return scoped, alerts, schedulerAlert
+}
+
+// logObservedTransitions logs the notifications observe mode would have sent
+func (s *Service) logObservedTransitions(transitions []analyzer.StateTransition, schedulerAlert *logger.StuckCronAlert) {
+	if schedulerAlert != nil {
+		s.logger.Info("[OBSERVE] Would send notification", map[string]interface{}{
+			"cron_code": schedulerAlert.JobCode,
+			"to_state":  "alerting",
+			"reason":    schedulerAlert.Reason,
+		})
+	}
+
+	for _, transition := range transitions {
+		s.logger.Info("[OBSERVE] Would send notification", map[string]interface{}{
+			"cron_code":  transition.CronCode,
+			"from_state": transition.FromState,
+			"to_state":   transition.ToState,
+			"reason":     transition.Reason,
+		})
+	}
 }
 
 // logCheckSummary logs a summary of the check results
-func (s *Service) logCheckSummary(schedules []*database.CronSchedule, alerts []*logger.StuckCronAlert, duration time.Duration) {
+func (s *Service) logCheckSummary(scope checkScope, schedules []*database.CronSchedule, alerts []*logger.StuckCronAlert, duration time.Duration) {
 	// Count by status
 	statusCounts := make(map[string]int)
 	for _, sched := range schedules {
@@ -177,6 +1035,22 @@ func (s *Service) logCheckSummary(schedules []*database.CronSchedule, alerts []*
 		"duration":      duration.String(),
 	}
 
+	if scope.group != "" {
+		fields["cron_group"] = scope.group
+	}
+	if s.maintenanceWindow != "" {
+		fields["maintenance_window"] = s.maintenanceWindow
+	}
+	if suspicious := s.analyzer.CountSuspiciousRows(schedules); suspicious > 0 {
+		fields["suspicious_rows"] = suspicious
+	}
+	if nullScheduled := analyzer.CountNullScheduledAt(schedules); nullScheduled > 0 {
+		fields["null_scheduled_at_rows"] = nullScheduled
+	}
+	if inconsistent := analyzer.CountInconsistentRows(analyzer.TimingInconsistencies(schedules)); inconsistent > 0 {
+		fields["inconsistent_timing_rows"] = inconsistent
+	}
+
 	for status, count := range statusCounts {
 		fields[fmt.Sprintf("status_%s", status)] = count
 	}
@@ -189,6 +1063,27 @@ func (s *Service) logCheckSummary(schedules []*database.CronSchedule, alerts []*
 
 	// Log detailed job states at debug level
 	s.logJobStates()
+
+	// Report stuck cron entries held back by logging.alert_rate_limit
+	s.logger.FlushSuppressedAlerts()
+}
+
+// maxInconsistencySamples limits the schedule_ids logged per kind of timing inconsistency
+const maxInconsistencySamples = 5
+
+// logTimingInconsistencies logs sample schedule_ids of rows with inconsistent timing fields (debug level)
+func (s *Service) logTimingInconsistencies(schedules []*database.CronSchedule) {
+	for kind, ids := range analyzer.TimingInconsistencies(schedules) {
+		samples := ids
+		if len(samples) > maxInconsistencySamples {
+			samples = samples[:maxInconsistencySamples]
+		}
+		s.logger.Debug("Inconsistent schedule timing", map[string]interface{}{
+			"kind":         kind,
+			"count":        len(ids),
+			"schedule_ids": samples,
+		})
+	}
 }
 
 // logJobStates logs current job states (debug level)
@@ -204,6 +1099,7 @@ func (s *Service) logJobStates() {
 		if state.ConsecutiveStuck > 0 || state.ErrorStreak > 0 || state.MissedStreak > 0 {
 			s.logger.Debug("Job state", map[string]interface{}{
 				"job_code":          jobCode,
+				"cron_group":        state.CronGroup,
 				"consecutive_stuck": state.ConsecutiveStuck,
 				"error_streak":      state.ErrorStreak,
 				"missed_streak":     state.MissedStreak,
@@ -213,48 +1109,122 @@ func (s *Service) logJobStates() {
 	}
 }
 
-// handleStateTransition processes state transitions and sends Slack notifications
+// Snapshot returns the current job states, scheduler state and the alerts of the most recent check
+func (s *Service) Snapshot() StateExport {
+	s.alertsMu.RLock()
+	scopes := make([]string, 0, len(s.lastAlerts))
+	for scope := range s.lastAlerts {
+		scopes = append(scopes, scope)
+	}
+	sort.Strings(scopes)
+	activeAlerts := []*logger.StuckCronAlert{}
+	for _, scope := range scopes {
+		activeAlerts = append(activeAlerts, s.lastAlerts[scope]...)
+	}
+	s.alertsMu.RUnlock()
+
+	s.reloadMu.Lock()
+	configLoadedAt, lastReloadError := s.configLoadedAt, s.lastReloadError
+	s.reloadMu.Unlock()
+
+	return StateExport{
+		GeneratedAt:     time.Now(),
+		MagentoVersion:  s.magentoVersion,
+		JobStates:       s.analyzer.GetJobStates(),
+		SchedulerState:  s.analyzer.GetSchedulerState(),
+		ActiveAlerts:    activeAlerts,
+		AlertLeadTimes:  s.leadTimeSummary(),
+		ConfigLoadedAt:  configLoadedAt,
+		LastReloadError: lastReloadError,
+	}
+}
+
+// ExportState atomically writes a JSON snapshot of job states, scheduler state and the
+// alerts of the most recent check to export.file
+func (s *Service) ExportState() error {
+	// The configuration is swapped under checkMu on reload
+	s.checkMu.Lock()
+	file := s.config.Export.File
+	s.checkMu.Unlock()
+	if file == "" {
+		return fmt.Errorf("export.file is not configured")
+	}
+
+	data, err := json.MarshalIndent(s.Snapshot(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state export: %w", err)
+	}
+
+	if err := state.WriteFileAtomic(file, data, 0644); err != nil {
+		return fmt.Errorf("failed to write state export: %w", err)
+	}
+
+	s.logger.Debug("Exported state", map[string]interface{}{
+		"file":      file,
+		"job_count": len(s.analyzer.GetJobStates()),
+	})
+	return nil
+}
+
+// SendTestAlert runs a synthetic transition through the full notification path
+// (routing, cooldowns and formatting) using the service's real configuration
+func (s *Service) SendTestAlert(transition analyzer.StateTransition, alert *logger.StuckCronAlert) error {
+	if s.config.Monitor.ObserveOnly {
+		return fmt.Errorf("notifications are disabled in observe mode")
+	}
+	if s.notifiers.Len() == 0 {
+		return fmt.Errorf("no notifiers are enabled in the configuration")
+	}
+
+	// Test alerts report delivery errors, so they are always sent synchronously and undigested
+	if s.dispatcher != nil {
+		s.dispatcher.drain(drainTimeout)
+		s.dispatcher = nil
+	}
+	s.digestWindow = 0
+
+	state := s.analyzer.InitJobState(transition.CronCode)
+	state.CronGroup = s.config.JobGroup(transition.CronCode)
+	now := time.Now()
+	if err := s.handleStateTransition(transition, now, alert); err != nil {
+		return err
+	}
+
+	// Every notifier may skip it, e.g. with recoveries disabled or a severity filter
+	for _, notified := range s.analyzer.GetCronState(transition.CronCode).LastNotified {
+		if notified.Equal(now) {
+			return nil
+		}
+	}
+	return fmt.Errorf("no notifier sent the %s notification (see the debug log for why each skipped it)", transition.ToState)
+}
+
+// handleStateTransition processes state transitions and dispatches them to every registered notifier
 func (s *Service) handleStateTransition(transition analyzer.StateTransition, now time.Time, enrichedAlert *logger.StuckCronAlert) error {
 	state := s.analyzer.GetCronState(transition.CronCode)
 	if state == nil {
 		return fmt.Errorf("cron state not found: %s", transition.CronCode)
 	}
 
-	// Determine cooldown based on transition type
+	// Determine cooldown based on transition type (job overrides take precedence)
+	cooldowns := s.config.GetCooldownConfig(transition.CronCode)
 	var cooldown time.Duration
-	var alertType slack.AlertType
+	var alertType cronalert.Type
 
 	if transition.ToState == "alerting" {
 		// Cron became alerting
-		cooldown = s.config.Notifications.Slack.AlertCooldown
-		alertType = slack.AlertTypeAlerting
+		cooldown = cooldowns.AlertCooldown
+		alertType = cronalert.Alerting
 	} else if transition.ToState == "not_alerting" {
 		// Cron recovered
-		if !s.config.Notifications.Slack.SendRecovery {
-			s.logger.Debug("Skipping recovery notification (disabled)", map[string]interface{}{
-				"cron_code": transition.CronCode,
-			})
-			return nil
-		}
-		cooldown = s.config.Notifications.Slack.RecoveryCooldown
-		alertType = slack.AlertTypeNotAlerting
+		cooldown = cooldowns.RecoveryCooldown
+		alertType = cronalert.NotAlerting
 	} else {
 		return nil
 	}
 
-	// Check cooldown
-	if !state.LastSlackAlert.IsZero() && now.Sub(state.LastSlackAlert) < cooldown {
-		s.logger.Debug("Skipping Slack notification (cooldown active)", map[string]interface{}{
-			"cron_code":       transition.CronCode,
-			"alert_type":      string(alertType),
-			"cooldown":        cooldown.String(),
-			"time_since_last": now.Sub(state.LastSlackAlert).String(),
-		})
-		return nil
-	}
-
-	// Create Slack alert
-	slackAlert := slack.CronAlert{
+	// Create the notification payload
+	slackAlert := cronalert.Alert{
 		Type:          alertType,
 		CronCode:      transition.CronCode,
 		Status:        transition.Status,
@@ -270,6 +1240,11 @@ func (s *Service) handleStateTransition(transition analyzer.StateTransition, now
 		PendingCount:     transition.PendingCount,
 		ErrorCount:       transition.ErrorCount,
 		MissedCount:      transition.MissedCount,
+		CompletionTime:   transition.CompletionTime,
+		Metadata:         s.config.Notifications.Metadata,
+		MagentoVersion:   s.magentoVersion,
+		Critical:         s.config.IsCriticalJob(transition.CronCode),
+		CronGroup:        state.CronGroup,
 	}
 	
 	// Enrich with detailed alert data if available (overrides transition data)
@@ -295,20 +1270,121 @@ func (s *Service) handleStateTransition(transition analyzer.StateTransition, now
 		if enrichedAlert.MissedCount > 0 {
 			slackAlert.MissedCount = enrichedAlert.MissedCount
 		}
+		if enrichedAlert.ErrorMessage != "" {
+			slackAlert.ErrorMessage = enrichedAlert.ErrorMessage
+		}
+		if enrichedAlert.Status != "" {
+			slackAlert.Status = enrichedAlert.Status
+		}
+		slackAlert.Severity = enrichedAlert.Severity
 	}
 
-	// Send notification
-	if err := s.slackClient.SendAlert(slackAlert); err != nil {
-		return err
+	// Recoveries are routed like the alert they resolve
+	if alertType == cronalert.Alerting || state.AlertSeverity == "" {
+		slackAlert.Severity = cronalert.DeriveSeverity(slackAlert)
+	} else {
+		slackAlert.Severity = state.AlertSeverity
+	}
+	if alertType == cronalert.Alerting {
+		state.AlertSeverity = slackAlert.Severity
 	}
 
-	// Update last alert time
-	state.LastSlackAlert = now
+	// Dispatch to every notifier, each with its own cooldown
+	var errs []error
+	for _, n := range s.notifiers.Notifiers() {
+		if f, ok := n.(notifier.AlertFilter); ok && !f.Accepts(slackAlert) {
+			continue
+		}
+		if alertType == cronalert.NotAlerting && !s.sendsRecovery(n) {
+			s.logger.Debug("Skipping recovery notification (disabled)", map[string]interface{}{
+				"cron_code": transition.CronCode,
+				"notifier":  n.Name(),
+			})
+			continue
+		}
+
+		cooldown := cooldown
+		if p, ok := n.(notifier.CooldownProvider); ok {
+			cooldown = s.notifierCooldown(p, transition.CronCode, alertType)
+		}
 
-	s.logger.Info("Sent Slack notification", map[string]interface{}{
-		"cron_code":  transition.CronCode,
-		"alert_type": string(alertType),
+		key := n.CooldownKey()
+		if last, ok := state.LastNotified[key]; ok && now.Sub(last) < cooldown {
+			s.logger.Debug("Skipping notification (cooldown active)", map[string]interface{}{
+				"cron_code":       transition.CronCode,
+				"notifier":        n.Name(),
+				"alert_type":      string(alertType),
+				"cooldown":        cooldown.String(),
+				"time_since_last": now.Sub(last).String(),
+			})
+			continue
+		}
+
+		fields := map[string]interface{}{
+			"cron_code":  transition.CronCode,
+			"notifier":   n.Name(),
+			"alert_type": string(alertType),
+		}
+
+		// Digested notifications count towards the cooldown from when they are collected
+		// Critical jobs page immediately, so they bypass the digest
+		if _, ok := n.(notifier.DigestSender); ok && s.digestsEnabled() && !slackAlert.Critical {
+			s.analyzer.MarkNotified(state, key, now)
+			if alertType == cronalert.Alerting && !state.IncidentNotified {
+				fields["lead_time"] = s.recordLeadTime(state, now).String()
+			}
+			s.bufferDigest(n, slackAlert)
+			s.logger.Debug("Collected notification for digest", fields)
+			continue
+		}
+
+		// Async deliveries count towards the cooldown from when they are queued
+		if s.dispatcher != nil {
+			s.analyzer.MarkNotified(state, key, now)
+			if alertType == cronalert.Alerting && !state.IncidentNotified {
+				fields["lead_time"] = s.recordLeadTime(state, now).String()
+			}
+			s.deliverAsync(n, slackAlert, now, fields)
+			continue
+		}
+
+		if err := n.Send(s.ctx, slackAlert); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", n.Name(), err))
+			s.enqueueRetry(n.Name(), slackAlert, now)
+			continue
+		}
+
+		// Update last alert time
+		s.analyzer.MarkNotified(state, key, now)
+
+		if alertType == cronalert.Alerting && !state.IncidentNotified {
+			// First notification of this incident: record how long detection took
+			lead := s.recordLeadTime(state, now)
+			fields["lead_time"] = lead.String()
+		}
+		s.logger.Info("Sent notification", fields)
+	}
+
+	return errors.Join(errs...)
+}
+
+// notifierCooldown returns a job's cooldown for a notifier with its own default cooldowns
+func (s *Service) notifierCooldown(p notifier.CooldownProvider, jobCode string, alertType cronalert.Type) time.Duration {
+	alertCooldown, recoveryCooldown := p.Cooldowns()
+	cooldowns := s.config.GetCooldownConfigWithDefaults(jobCode, config.CooldownConfig{
+		AlertCooldown:    alertCooldown,
+		RecoveryCooldown: recoveryCooldown,
 	})
+	if alertType == cronalert.Alerting {
+		return cooldowns.AlertCooldown
+	}
+	return cooldowns.RecoveryCooldown
+}
 
-	return nil
+// sendsRecovery reports whether a notifier delivers recovery notifications
+func (s *Service) sendsRecovery(n notifier.Notifier) bool {
+	if f, ok := n.(notifier.RecoveryFilter); ok {
+		return f.SendsRecovery()
+	}
+	return s.config.Notifications.Slack.SendRecovery
 }
@@ -0,0 +1,206 @@
+package monitor
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/analyzer"
+	"github.com/fabio/go-magento-cron-monitor/internal/config"
+	"github.com/fabio/go-magento-cron-monitor/internal/database"
+	"github.com/fabio/go-magento-cron-monitor/internal/logger"
+)
+
+// webhookRecorder is a Slack webhook that records the bodies posted to it
+type webhookRecorder struct {
+	*httptest.Server
+	mu     sync.Mutex
+	bodies []string
+}
+
+func newWebhookRecorder(t *testing.T) *webhookRecorder {
+	t.Helper()
+
+	r := &webhookRecorder{}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		r.bodies = append(r.bodies, string(body))
+		r.mu.Unlock()
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+// posts returns the bodies posted so far
+func (r *webhookRecorder) posts() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string(nil), r.bodies...)
+}
+
+// newTestService loads a config with the given sections, plus database and log file settings, into a service without a database
+func newTestService(t *testing.T, sections string) *Service {
+	t.Helper()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	content := "database:\n  host: localhost\n  user: test\n  name: magento\n" +
+		"logging:\n  file: " + filepath.Join(dir, "monitor.log") + "\n" + sections
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log, err := logger.New(cfg.Logging, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { log.Close() })
+
+	return NewService(cfg, nil, log, 0)
+}
+
+// slackSection enables Slack notifications to url, with recoveries
+func slackSection(url string) string {
+	return "notifications:\n  slack:\n    enabled: true\n    send_recovery: true\n    webhook_urls: [\"" + url + "\"]\n"
+}
+
+func TestSendTestAlertRecoveryRequiresDelivery(t *testing.T) {
+	tests := []struct {
+		name         string
+		sendRecovery bool
+		wantErr      bool
+		wantPosts    int
+	}{
+		{"recoveries enabled", true, false, 1},
+		{"recoveries disabled", false, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhook := newWebhookRecorder(t)
+			sections := slackSection(webhook.URL)
+			if !tt.sendRecovery {
+				sections = strings.Replace(sections, "send_recovery: true", "send_recovery: false", 1)
+			}
+			svc := newTestService(t, sections)
+
+			err := svc.SendTestAlert(analyzer.StateTransition{
+				CronCode:  "sales_export",
+				FromState: "alerting",
+				ToState:   "not_alerting",
+				Timestamp: time.Now(),
+				Status:    "success",
+			}, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SendTestAlert() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := len(webhook.posts()); got != tt.wantPosts {
+				t.Errorf("expected %d webhook posts, got %d", tt.wantPosts, got)
+			}
+		})
+	}
+}
+
+// absentRows returns successful runs of jobCode created every 5 minutes, the newest 30 minutes ago
+func absentRows(jobCode string) []*database.CronSchedule {
+	var rows []*database.CronSchedule
+	for i := 0; i < 4; i++ {
+		created := time.Now().Add(-30*time.Minute - time.Duration(i)*5*time.Minute)
+		rows = append(rows, &database.CronSchedule{
+			ScheduleID: i + 1,
+			JobCode:    jobCode,
+			Status:     "success",
+			CreatedAt:  created,
+		})
+	}
+	return rows
+}
+
+func TestNotifyMissingJob(t *testing.T) {
+	webhook := newWebhookRecorder(t)
+	svc := newTestService(t, slackSection(webhook.URL)+
+		"monitor:\n  detection:\n    threshold_checks: 1\n  expected_jobs:\n    - job_code: sales_clean_quotes\n      interval: 10m\n")
+
+	// The job has rows, but none created within its interval, and no rows of its own in the check's scope
+	alerts := svc.analyzer.CheckExpectedJobs(context.Background(), absentRows("sales_clean_quotes"), nil)
+	if len(alerts) != 1 {
+		t.Fatalf("expected an absent alert, got %d", len(alerts))
+	}
+	svc.notify(context.Background(), checkScope{}, nil, alerts, nil, false)
+
+	posts := webhook.posts()
+	if len(posts) != 1 {
+		t.Fatalf("expected one Slack notification, got %d", len(posts))
+	}
+	if !strings.Contains(posts[0], "sales_clean_quotes") || !strings.Contains(posts[0], "silently stopped being scheduled") {
+		t.Errorf("expected the missing job alert, got %s", posts[0])
+	}
+	if state := svc.analyzer.GetCronState("sales_clean_quotes"); state.LastKnownState != "alerting" {
+		t.Errorf("expected the job to be alerting, got %q", state.LastKnownState)
+	}
+
+	// While it stays missing, the next check doesn't notify again
+	svc.notify(context.Background(), checkScope{}, nil, svc.analyzer.CheckExpectedJobs(context.Background(), absentRows("sales_clean_quotes"), nil), nil, false)
+	if got := len(webhook.posts()); got != 1 {
+		t.Errorf("expected no further notification while the job stays missing, got %d", got)
+	}
+}
+
+func TestReloadFailed(t *testing.T) {
+	tests := []struct {
+		name      string
+		notify    bool
+		wantPosts int
+	}{
+		{"notification enabled", true, 1},
+		{"notification disabled", false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			webhook := newWebhookRecorder(t)
+			svc := newTestService(t, slackSection(webhook.URL)+"  notify_reload_failure: "+strconv.FormatBool(tt.notify)+"\n")
+			loadedAt := svc.Snapshot().ConfigLoadedAt
+
+			if err := svc.ReloadFailed("config.yaml", errors.New("monitor.interval must be positive")); err != nil {
+				t.Fatal(err)
+			}
+
+			posts := webhook.posts()
+			if len(posts) != tt.wantPosts {
+				t.Fatalf("expected %d notifications, got %d", tt.wantPosts, len(posts))
+			}
+			if tt.notify && !strings.Contains(posts[0], "keeping previous config") {
+				t.Errorf("expected the reload failure notification, got %s", posts[0])
+			}
+
+			// The last good configuration stays in use
+			snapshot := svc.Snapshot()
+			if snapshot.LastReloadError != "monitor.interval must be positive" {
+				t.Errorf("expected the reload error in the snapshot, got %q", snapshot.LastReloadError)
+			}
+			if !snapshot.ConfigLoadedAt.Equal(loadedAt) {
+				t.Errorf("expected config_loaded_at to stay %s, got %s", loadedAt, snapshot.ConfigLoadedAt)
+			}
+
+			// A successful reload clears the error
+			svc.applyConfig(svc.config)
+			if snapshot := svc.Snapshot(); snapshot.LastReloadError != "" || !snapshot.ConfigLoadedAt.After(loadedAt) {
+				t.Errorf("expected a successful reload to clear the error and update config_loaded_at, got %+v", snapshot)
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package monitor
+
+import (
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/snooze"
+)
+
+// snoozedJob returns the active snooze covering a job, or nil
+func (s *Service) snoozedJob(jobCode string, now time.Time) *snooze.Snooze {
+	if s.snoozes == nil {
+		return nil
+	}
+	return s.snoozes.Match(jobCode, now)
+}
+
+// watchSnoozes reloads the snooze file whenever it changes
+func (s *Service) watchSnoozes() {
+	if s.snoozes == nil {
+		return
+	}
+
+	err := s.snoozes.Watch(s.ctx, func(err error) {
+		if err != nil {
+			s.logger.Warn("Failed to reload snooze file, keeping previous snoozes", map[string]interface{}{
+				"file":  s.snoozes.File(),
+				"error": err.Error(),
+			})
+			return
+		}
+		s.logActiveSnoozes("Reloaded snooze file")
+	})
+	if err != nil {
+		s.logger.Warn("Snooze file changes will not be picked up", map[string]interface{}{
+			"file":  s.snoozes.File(),
+			"error": err.Error(),
+		})
+	}
+	s.logActiveSnoozes("Loaded snooze file")
+}
+
+// logActiveSnoozes logs the snoozes currently in effect
+func (s *Service) logActiveSnoozes(msg string) {
+	active := s.snoozes.Active(time.Now())
+	jobs := make([]string, 0, len(active))
+	for _, snoozed := range active {
+		jobs = append(jobs, snoozed.Job+" until "+snoozed.Until.Format(time.RFC3339))
+	}
+	s.logger.Info(msg, map[string]interface{}{
+		"file":           s.snoozes.File(),
+		"active_snoozes": jobs,
+	})
+}
@@ -0,0 +1,158 @@
+package monitor
+
+import (
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/analyzer"
+	"github.com/fabio/go-magento-cron-monitor/internal/config"
+	"github.com/fabio/go-magento-cron-monitor/internal/slack"
+)
+
+// summaryPeriod is the period covered by the daily summary
+const summaryPeriod = 24 * time.Hour
+
+// incidentHistoryAge is how long finished incidents are kept for the daily summary
+const incidentHistoryAge = 2 * summaryPeriod
+
+// schedulerIncidentCode marks scheduler inactivity in the incident history
+const schedulerIncidentCode = "SCHEDULER"
+
+// incidentRecord is an alerting period of a job, or of the scheduler, kept for the daily summary
+type incidentRecord struct {
+	JobCode string    `json:"job_code"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end,omitempty"` // Zero while still alerting
+}
+
+// recordIncidents updates the incident history from state transitions and the scheduler state
+func (s *Service) recordIncidents(now time.Time, transitions []analyzer.StateTransition) {
+	for _, transition := range transitions {
+		switch transition.ToState {
+		case "alerting":
+			s.incidents = append(s.incidents, incidentRecord{JobCode: transition.CronCode, Start: now})
+		case "not_alerting":
+			s.closeIncident(transition.CronCode, now)
+		}
+	}
+
+	inactiveFor, _, inactive := s.analyzer.SchedulerEscalation(now)
+	open := s.openIncident(schedulerIncidentCode) != nil
+	if inactive && !open {
+		s.incidents = append(s.incidents, incidentRecord{JobCode: schedulerIncidentCode, Start: now.Add(-inactiveFor)})
+	} else if !inactive && open {
+		s.closeIncident(schedulerIncidentCode, now)
+	}
+
+	// Drop incidents that ended before any summary could still cover them
+	kept := s.incidents[:0]
+	for _, incident := range s.incidents {
+		if incident.End.IsZero() || now.Sub(incident.End) < incidentHistoryAge {
+			kept = append(kept, incident)
+		}
+	}
+	s.incidents = kept
+}
+
+// openIncident returns the open incident of a job, or nil
+func (s *Service) openIncident(jobCode string) *incidentRecord {
+	for i := len(s.incidents) - 1; i >= 0; i-- {
+		if s.incidents[i].JobCode == jobCode && s.incidents[i].End.IsZero() {
+			return &s.incidents[i]
+		}
+	}
+	return nil
+}
+
+// closeIncident marks the open incident of a job as ended
+func (s *Service) closeIncident(jobCode string, now time.Time) {
+	if incident := s.openIncident(jobCode); incident != nil {
+		incident.End = now
+	}
+}
+
+// buildDailySummary summarizes the incident history of the period ending at end
+func (s *Service) buildDailySummary(end time.Time) slack.DailySummary {
+	start := end.Add(-summaryPeriod)
+	summary := slack.DailySummary{
+		PeriodStart:    start,
+		PeriodEnd:      end,
+		Metadata:       s.config.Notifications.Metadata,
+		MagentoVersion: s.magentoVersion,
+	}
+
+	jobs := make(map[string]bool)
+	for _, incident := range s.incidents {
+		incidentEnd := incident.End
+		if incidentEnd.IsZero() {
+			incidentEnd = end
+		}
+
+		if incident.JobCode == schedulerIncidentCode {
+			// Count the part of each scheduler outage that falls in the period
+			if incidentEnd.After(start) && incident.Start.Before(end) {
+				from := incident.Start
+				if from.Before(start) {
+					from = start
+				}
+				summary.SchedulerOutages++
+				summary.SchedulerDown += incidentEnd.Sub(from)
+			}
+			continue
+		}
+
+		if incident.End.IsZero() {
+			summary.StillAlerting++
+		}
+		if incident.Start.Before(start) || !incident.Start.Before(end) {
+			continue
+		}
+		summary.Incidents++
+		jobs[incident.JobCode] = true
+		if duration := incidentEnd.Sub(incident.Start); duration > summary.LongestStuck {
+			summary.LongestStuck = duration
+			summary.LongestJob = incident.JobCode
+		}
+	}
+	summary.JobsAffected = len(jobs)
+
+	return summary
+}
+
+// sendDailySummary sends the digest of the last 24 hours
+func (s *Service) sendDailySummary(now time.Time) {
+	s.checkMu.Lock()
+	defer s.checkMu.Unlock()
+
+	// Slack notifications may have been disabled by a config reload
+	if s.summaryClient == nil {
+		s.logger.Debug("Skipping daily summary (Slack notifications disabled)", nil)
+		return
+	}
+
+	// With clustering only the leader reports, like for incident notifications
+	if s.leaderLock != nil && !s.isLeader {
+		s.logger.Debug("Skipping daily summary (not cluster leader)", nil)
+		return
+	}
+
+	summary := s.buildDailySummary(now)
+	if err := s.summaryClient.SendMessageTo(s.ctx, slack.FormatDailySummary(summary), s.summaryWebhooks); err != nil {
+		s.logger.Error("Failed to send daily summary", err, nil)
+		return
+	}
+
+	s.logger.Info("Sent daily summary", map[string]interface{}{
+		"incidents":         summary.Incidents,
+		"jobs_affected":     summary.JobsAffected,
+		"longest_stuck":     summary.LongestStuck.String(),
+		"scheduler_outages": summary.SchedulerOutages,
+	})
+}
+
+// nextSummaryTime returns when the next daily summary is due
+func (s *Service) nextSummaryTime(now time.Time) time.Time {
+	cfg := s.config.Notifications.DailySummary
+	// The time was checked when the config was loaded
+	next, _ := config.NextDailyTime(cfg.Time, cfg.Timezone, now)
+	return next
+}
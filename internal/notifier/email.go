@@ -0,0 +1,38 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/cronalert"
+	"github.com/fabio/go-magento-cron-monitor/internal/email"
+)
+
+// EmailNotifier sends alerts by email
+type EmailNotifier struct {
+	client *email.Client
+}
+
+// NewEmail creates an email notifier
+func NewEmail(client *email.Client) *EmailNotifier {
+	return &EmailNotifier{client: client}
+}
+
+// Name returns the notifier name
+func (n *EmailNotifier) Name() string {
+	return "email"
+}
+
+// CooldownKey returns the cooldown bucket for email notifications
+func (n *EmailNotifier) CooldownKey() string {
+	return "email"
+}
+
+// SendsRecovery reports whether recovery emails are enabled
+func (n *EmailNotifier) SendsRecovery() bool {
+	return n.client.GetConfig().SendRecovery
+}
+
+// Send emails the alert to the configured recipients
+func (n *EmailNotifier) Send(ctx context.Context, alert cronalert.Alert) error {
+	return n.client.SendAlert(ctx, alert)
+}
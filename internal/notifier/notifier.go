@@ -0,0 +1,69 @@
+package notifier
+
+import (
+	"context"
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/cronalert"
+)
+
+// Notifier delivers cron alerts to a single destination
+type Notifier interface {
+	// Name identifies the notifier in logs
+	Name() string
+	// CooldownKey identifies the cooldown bucket; notifiers sharing a key share cooldowns
+	CooldownKey() string
+	// Send delivers the alert
+	Send(ctx context.Context, alert cronalert.Alert) error
+}
+
+// Registry holds the enabled notifiers in registration order
+type Registry struct {
+	notifiers []Notifier
+}
+
+// NewRegistry creates an empty notifier registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds a notifier to the registry
+func (r *Registry) Register(n Notifier) {
+	r.notifiers = append(r.notifiers, n)
+}
+
+// Notifiers returns the registered notifiers
+func (r *Registry) Notifiers() []Notifier {
+	return r.notifiers
+}
+
+// Len returns the number of registered notifiers
+func (r *Registry) Len() int {
+	return len(r.notifiers)
+}
+
+// RecoveryFilter is implemented by notifiers with their own recovery setting
+// Notifiers without it follow notifications.slack.send_recovery
+type RecoveryFilter interface {
+	// SendsRecovery reports whether recovery notifications should be delivered
+	SendsRecovery() bool
+}
+
+// AlertFilter is implemented by notifiers that only handle some alerts
+type AlertFilter interface {
+	// Accepts reports whether the alert should be delivered by this notifier
+	Accepts(alert cronalert.Alert) bool
+}
+
+// CooldownProvider is implemented by notifiers with their own default cooldowns
+// Job overrides and critical_jobs still apply on top of them
+type CooldownProvider interface {
+	// Cooldowns returns the default alert and recovery cooldowns
+	Cooldowns() (alert, recovery time.Duration)
+}
+
+// DigestSender is implemented by notifiers that can combine several alerts into one message
+type DigestSender interface {
+	// SendDigest delivers the alerts collected over window as a digest
+	SendDigest(ctx context.Context, alerts []cronalert.Alert, window time.Duration, now time.Time) error
+}
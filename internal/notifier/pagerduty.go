@@ -0,0 +1,43 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/cronalert"
+	"github.com/fabio/go-magento-cron-monitor/internal/pagerduty"
+)
+
+// PagerDutyNotifier pages through the PagerDuty Events API v2
+type PagerDutyNotifier struct {
+	client *pagerduty.Client
+}
+
+// NewPagerDuty creates a PagerDuty notifier
+func NewPagerDuty(client *pagerduty.Client) *PagerDutyNotifier {
+	return &PagerDutyNotifier{client: client}
+}
+
+// Name returns the notifier name
+func (n *PagerDutyNotifier) Name() string {
+	return "pagerduty"
+}
+
+// CooldownKey returns the cooldown bucket for PagerDuty events
+func (n *PagerDutyNotifier) CooldownKey() string {
+	return "pagerduty"
+}
+
+// Accepts reports whether the alert reaches the configured minimum severity
+func (n *PagerDutyNotifier) Accepts(alert cronalert.Alert) bool {
+	return n.client.Accepts(alert)
+}
+
+// SendsRecovery always returns true: recoveries resolve the incident opened by the alert
+func (n *PagerDutyNotifier) SendsRecovery() bool {
+	return true
+}
+
+// Send triggers or resolves the job's incident
+func (n *PagerDutyNotifier) Send(ctx context.Context, alert cronalert.Alert) error {
+	return n.client.SendAlert(ctx, alert)
+}
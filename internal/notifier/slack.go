@@ -0,0 +1,117 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/cronalert"
+	"github.com/fabio/go-magento-cron-monitor/internal/slack"
+)
+
+// RouteFunc resolves the webhook URLs to use at a given time
+type RouteFunc func(now time.Time) ([]string, string)
+
+// AlertRouteFunc resolves the webhook URLs for an alert; ok is false when no route matches
+type AlertRouteFunc func(alert cronalert.Alert) (webhookURLs []string, name string, ok bool)
+
+// SlackNotifier sends alerts to Slack webhooks chosen by alert and time-of-day routing
+type SlackNotifier struct {
+	client *slack.Client
+	routes RouteFunc
+	// Routes by job, group or severity, tried before the time-of-day routes
+	alertRoutes AlertRouteFunc
+	// Extra webhooks for alerts of critical jobs
+	criticalURLs []string
+}
+
+// NewSlack creates a Slack notifier
+func NewSlack(client *slack.Client, routes RouteFunc) *SlackNotifier {
+	return &SlackNotifier{client: client, routes: routes}
+}
+
+// WithCriticalRoute also sends alerts of critical jobs to the given webhooks
+func (n *SlackNotifier) WithCriticalRoute(webhookURLs []string) *SlackNotifier {
+	n.criticalURLs = webhookURLs
+	return n
+}
+
+// WithAlertRoutes routes alerts matching an alert route to that route's webhooks
+// Alerts without a matching route fall back to the time-of-day routes
+func (n *SlackNotifier) WithAlertRoutes(routes AlertRouteFunc) *SlackNotifier {
+	n.alertRoutes = routes
+	return n
+}
+
+// Name returns the notifier name
+func (n *SlackNotifier) Name() string {
+	return "slack"
+}
+
+// CooldownKey returns the cooldown bucket for Slack notifications
+func (n *SlackNotifier) CooldownKey() string {
+	return "slack"
+}
+
+// StaticRoute returns a RouteFunc that always uses the given webhooks
+func StaticRoute(webhookURLs []string) RouteFunc {
+	return func(time.Time) ([]string, string) {
+		return webhookURLs, "static"
+	}
+}
+
+// Send delivers the alert to the webhooks of its matching alert route, or else of its timestamp
+func (n *SlackNotifier) Send(ctx context.Context, alert cronalert.Alert) error {
+	return n.client.SendAlertTo(ctx, alert, n.webhooksFor(alert))
+}
+
+// SendDigest delivers alerts collected over window as digest messages, one per destination,
+// so every alert still reaches the webhooks it would have been routed to on its own
+func (n *SlackNotifier) SendDigest(ctx context.Context, alerts []cronalert.Alert, window time.Duration, now time.Time) error {
+	var order []string
+	groups := make(map[string][]cronalert.Alert)
+	webhooks := make(map[string][]string)
+	for _, alert := range alerts {
+		urls := n.webhooksFor(alert)
+		key := strings.Join(urls, "\n")
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+			webhooks[key] = urls
+		}
+		groups[key] = append(groups[key], alert)
+	}
+
+	var errs []error
+	for _, key := range order {
+		group := groups[key]
+		var err error
+		if len(group) == 1 {
+			// A lone alert keeps its detailed message
+			err = n.client.SendAlertTo(ctx, group[0], webhooks[key])
+		} else {
+			err = n.client.SendMessageTo(ctx, slack.FormatDigest(group, window, now), webhooks[key])
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// webhooksFor resolves the webhooks of an alert: its alert route, else the time-of-day route,
+// plus the critical webhooks for alerts of critical jobs
+func (n *SlackNotifier) webhooksFor(alert cronalert.Alert) []string {
+	var webhookURLs []string
+	matched := false
+	if n.alertRoutes != nil {
+		webhookURLs, _, matched = n.alertRoutes(alert)
+	}
+	if !matched {
+		webhookURLs, _ = n.routes(alert.Timestamp)
+	}
+	if alert.Critical && alert.Type == cronalert.Alerting && len(n.criticalURLs) > 0 {
+		webhookURLs = append(append([]string{}, webhookURLs...), n.criticalURLs...)
+	}
+	return webhookURLs
+}
@@ -0,0 +1,45 @@
+package notifier
+
+import (
+	"context"
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/cronalert"
+	"github.com/fabio/go-magento-cron-monitor/internal/teams"
+)
+
+// TeamsNotifier sends alerts to Microsoft Teams webhooks
+type TeamsNotifier struct {
+	client *teams.Client
+}
+
+// NewTeams creates a Teams notifier
+func NewTeams(client *teams.Client) *TeamsNotifier {
+	return &TeamsNotifier{client: client}
+}
+
+// Name returns the notifier name
+func (n *TeamsNotifier) Name() string {
+	return "teams"
+}
+
+// CooldownKey returns the cooldown bucket for Teams notifications
+func (n *TeamsNotifier) CooldownKey() string {
+	return "teams"
+}
+
+// SendsRecovery reports whether recovery notifications are enabled for Teams
+func (n *TeamsNotifier) SendsRecovery() bool {
+	return n.client.GetConfig().SendRecovery
+}
+
+// Cooldowns returns the Teams alert and recovery cooldowns
+func (n *TeamsNotifier) Cooldowns() (time.Duration, time.Duration) {
+	cfg := n.client.GetConfig()
+	return cfg.AlertCooldown, cfg.RecoveryCooldown
+}
+
+// Send delivers the alert to the configured Teams webhooks
+func (n *TeamsNotifier) Send(ctx context.Context, alert cronalert.Alert) error {
+	return n.client.SendAlert(ctx, alert)
+}
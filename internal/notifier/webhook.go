@@ -0,0 +1,38 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/cronalert"
+	"github.com/fabio/go-magento-cron-monitor/internal/webhook"
+)
+
+// WebhookNotifier sends alerts as templated JSON to generic webhooks
+type WebhookNotifier struct {
+	client *webhook.Client
+}
+
+// NewWebhook creates a generic webhook notifier
+func NewWebhook(client *webhook.Client) *WebhookNotifier {
+	return &WebhookNotifier{client: client}
+}
+
+// Name returns the notifier name
+func (n *WebhookNotifier) Name() string {
+	return "webhook"
+}
+
+// CooldownKey returns the cooldown bucket for webhook notifications
+func (n *WebhookNotifier) CooldownKey() string {
+	return "webhook"
+}
+
+// SendsRecovery reports whether recovery notifications are enabled for the webhook
+func (n *WebhookNotifier) SendsRecovery() bool {
+	return n.client.GetConfig().SendRecovery
+}
+
+// Send delivers the rendered alert to the configured URLs
+func (n *WebhookNotifier) Send(ctx context.Context, alert cronalert.Alert) error {
+	return n.client.SendAlert(ctx, alert)
+}
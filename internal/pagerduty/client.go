@@ -0,0 +1,230 @@
+package pagerduty
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/cronalert"
+	"github.com/fabio/go-magento-cron-monitor/internal/httpclient"
+)
+
+// DefaultEventsURL is the PagerDuty Events API v2 endpoint
+const DefaultEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+// Event severities, from most to least severe
+const (
+	SeverityCritical = "critical"
+	SeverityError    = "error"
+	SeverityWarning  = "warning"
+	SeverityInfo     = "info"
+)
+
+// severityRank orders severities; higher is more severe
+var severityRank = map[string]int{
+	SeverityInfo:     1,
+	SeverityWarning:  2,
+	SeverityError:    3,
+	SeverityCritical: 4,
+}
+
+// ValidSeverity reports whether s is a PagerDuty event severity
+func ValidSeverity(s string) bool {
+	_, ok := severityRank[s]
+	return ok
+}
+
+// Config represents PagerDuty notification configuration
+type Config struct {
+	Enabled     bool          `yaml:"enabled"`
+	RoutingKey  string        `yaml:"routing_key"`
+	EventsURL   string        `yaml:"events_url"`
+	Source      string        `yaml:"source"`       // Affected system shown in PagerDuty
+	MinSeverity string        `yaml:"min_severity"` // Alerts below this severity don't page
+	Timeout     time.Duration `yaml:"timeout"`
+}
+
+// defaultRetryDelay is the wait before an event rejected with a server error is sent again
+const defaultRetryDelay = 2 * time.Second
+
+// Client sends cron alerts to the PagerDuty Events API v2
+type Client struct {
+	config     Config
+	httpClient *http.Client
+	retryDelay time.Duration
+}
+
+// New creates a new PagerDuty client
+func New(config Config) *Client {
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second
+	}
+	if config.EventsURL == "" {
+		config.EventsURL = DefaultEventsURL
+	}
+	if config.MinSeverity == "" {
+		config.MinSeverity = SeverityCritical
+	}
+
+	return &Client{
+		config:     config,
+		httpClient: httpclient.New(httpclient.Config{Timeout: config.Timeout}),
+		retryDelay: defaultRetryDelay,
+	}
+}
+
+// event is a PagerDuty Events API v2 request body
+type event struct {
+	RoutingKey  string        `json:"routing_key"`
+	EventAction string        `json:"event_action"` // trigger or resolve
+	DedupKey    string        `json:"dedup_key"`
+	Payload     *eventPayload `json:"payload,omitempty"`
+}
+
+// eventPayload describes the incident of a trigger event
+type eventPayload struct {
+	Summary       string                 `json:"summary"`
+	Source        string                 `json:"source"`
+	Severity      string                 `json:"severity"`
+	Timestamp     string                 `json:"timestamp,omitempty"`
+	Component     string                 `json:"component,omitempty"`
+	Group         string                 `json:"group,omitempty"`
+	Class         string                 `json:"class,omitempty"`
+	CustomDetails map[string]interface{} `json:"custom_details,omitempty"`
+}
+
+// Severity derives the event severity of an alert
+// Critical alerts (critical jobs, the scheduler, orphaned runs by default) page as critical,
+// info alerts as info and everything else as error
+func Severity(alert cronalert.Alert) string {
+	switch cronalert.DeriveSeverity(alert) {
+	case cronalert.SeverityCritical:
+		return SeverityCritical
+	case cronalert.SeverityInfo:
+		return SeverityInfo
+	}
+	return SeverityError
+}
+
+// Accepts reports whether the alert is severe enough to page
+func (c *Client) Accepts(alert cronalert.Alert) bool {
+	return severityRank[Severity(alert)] >= severityRank[c.config.MinSeverity]
+}
+
+// SendAlert triggers an incident for an alerting alert and resolves it on recovery
+// The cron code is the dedup key, so repeated alerts update the same incident
+func (c *Client) SendAlert(ctx context.Context, alert cronalert.Alert) error {
+	if !c.config.Enabled {
+		return nil
+	}
+
+	if err := alert.Type.Validate(); err != nil {
+		return err
+	}
+
+	ev := event{
+		RoutingKey:  c.config.RoutingKey,
+		EventAction: "resolve",
+		DedupKey:    alert.CronCode,
+	}
+	if alert.Type == cronalert.Alerting {
+		ev.EventAction = "trigger"
+		ev.Payload = c.payload(alert)
+	}
+
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return fmt.Errorf("failed to marshal pagerduty event: %w", err)
+	}
+
+	// Retry once on server errors, after a short delay unless ctx is cancelled first
+	status, err := c.post(ctx, payload)
+	if err == nil && status >= 500 {
+		select {
+		case <-time.After(c.retryDelay):
+		case <-ctx.Done():
+			return fmt.Errorf("pagerduty returned status %d, retry cancelled: %w", status, ctx.Err())
+		}
+		status, err = c.post(ctx, payload)
+	}
+	if err != nil {
+		return err
+	}
+	if status != http.StatusAccepted {
+		return fmt.Errorf("pagerduty returned non-accepted status: %d", status)
+	}
+
+	return nil
+}
+
+// payload builds the incident details of a trigger event
+func (c *Client) payload(alert cronalert.Alert) *eventPayload {
+	summary := fmt.Sprintf("Cron job %s is alerting: %s", alert.CronCode, alert.Reason)
+	if alert.Reason == "" && len(alert.Reasons) > 0 {
+		summary = fmt.Sprintf("Cron job %s is alerting: %s", alert.CronCode, alert.Reasons[0])
+	}
+	// PagerDuty rejects summaries over 1024 characters
+	if len(summary) > 1024 {
+		summary = summary[:1021] + "..."
+	}
+
+	details := map[string]interface{}{
+		"consecutive_issues": alert.ConsecutiveStuck,
+	}
+	if len(alert.Reasons) > 0 {
+		details["reasons"] = alert.Reasons
+	}
+	if !alert.LastExecution.IsZero() {
+		details["last_execution"] = alert.LastExecution.UTC().Format(time.RFC3339)
+	}
+	if alert.ScheduledAt != nil && !alert.ScheduledAt.IsZero() {
+		details["scheduled_at"] = alert.ScheduledAt.UTC().Format(time.RFC3339)
+	}
+	if alert.RunningTime != nil {
+		details["running_time"] = alert.RunningTime.String()
+	}
+	if alert.ErrorMessage != "" {
+		details["error_message"] = alert.ErrorMessage
+	}
+	if alert.MagentoVersion != "" {
+		details["magento_version"] = alert.MagentoVersion
+	}
+	if len(alert.Metadata) > 0 {
+		details["metadata"] = alert.Metadata
+	}
+
+	return &eventPayload{
+		Summary:       summary,
+		Source:        c.config.Source,
+		Severity:      Severity(alert),
+		Timestamp:     alert.Timestamp.UTC().Format(time.RFC3339),
+		Component:     alert.CronCode,
+		Group:         alert.CronGroup,
+		Class:         alert.Status,
+		CustomDetails: details,
+	}
+}
+
+// post sends an event and returns the response status
+func (c *Client) post(ctx context.Context, payload []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.EventsURL, bytes.NewBuffer(payload))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create pagerduty request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("pagerduty request failed: %w", err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// GetConfig returns the client configuration
+func (c *Client) GetConfig() Config {
+	return c.config
+}
@@ -0,0 +1,106 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/cronalert"
+)
+
+// eventRecorder is a fake Events API that answers with the given statuses in turn, then 202
+type eventRecorder struct {
+	mu       sync.Mutex
+	statuses []int
+	events   []event
+}
+
+func (r *eventRecorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	var ev event
+	if err := json.NewDecoder(req.Body).Decode(&ev); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = append(r.events, ev)
+	status := http.StatusAccepted
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func newTestClient(t *testing.T, recorder *eventRecorder) *Client {
+	t.Helper()
+	server := httptest.NewServer(recorder)
+	t.Cleanup(server.Close)
+
+	c := New(Config{Enabled: true, RoutingKey: "key", EventsURL: server.URL})
+	c.retryDelay = 10 * time.Millisecond
+	return c
+}
+
+func TestSendAlertResolvesSchedulerIncident(t *testing.T) {
+	recorder := &eventRecorder{}
+	c := newTestClient(t, recorder)
+
+	alert := cronalert.Alert{Type: cronalert.Alerting, CronCode: "SCHEDULER", Status: "inactive", Reason: "no jobs created", Timestamp: time.Now()}
+	if err := c.SendAlert(context.Background(), alert); err != nil {
+		t.Fatal(err)
+	}
+	alert.Type = cronalert.NotAlerting
+	if err := c.SendAlert(context.Background(), alert); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(recorder.events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(recorder.events))
+	}
+	trigger, resolve := recorder.events[0], recorder.events[1]
+	if trigger.EventAction != "trigger" || trigger.DedupKey != "SCHEDULER" || trigger.Payload == nil {
+		t.Errorf("expected a trigger event for SCHEDULER, got %+v", trigger)
+	}
+	if resolve.EventAction != "resolve" || resolve.DedupKey != "SCHEDULER" || resolve.Payload != nil {
+		t.Errorf("expected a resolve event for SCHEDULER without payload, got %+v", resolve)
+	}
+}
+
+func TestSendAlertRetriesServerErrors(t *testing.T) {
+	recorder := &eventRecorder{statuses: []int{http.StatusServiceUnavailable}}
+	c := newTestClient(t, recorder)
+
+	alert := cronalert.Alert{Type: cronalert.NotAlerting, CronCode: "sales_export", Timestamp: time.Now()}
+	if err := c.SendAlert(context.Background(), alert); err != nil {
+		t.Fatalf("expected the retry to succeed, got %v", err)
+	}
+	if len(recorder.events) != 2 {
+		t.Errorf("expected the event to be sent twice, got %d", len(recorder.events))
+	}
+}
+
+func TestSendAlertRetryHonorsContext(t *testing.T) {
+	recorder := &eventRecorder{statuses: []int{http.StatusInternalServerError}}
+	c := newTestClient(t, recorder)
+	c.retryDelay = time.Minute
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	alert := cronalert.Alert{Type: cronalert.NotAlerting, CronCode: "sales_export", Timestamp: time.Now()}
+	if err := c.SendAlert(ctx, alert); err == nil {
+		t.Fatal("expected an error when ctx is done before the retry")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the retry wait to stop with ctx, took %s", elapsed)
+	}
+	if len(recorder.events) != 1 {
+		t.Errorf("expected no retry after ctx was done, got %d events", len(recorder.events))
+	}
+}
@@ -6,7 +6,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
-	"syscall"
+	"time"
 )
 
 // PIDFile manages a PID file for preventing multiple instances
@@ -30,8 +30,7 @@ func (p *PIDFile) Create() error {
 	if err := p.write(); err != nil {
 		// If write fails, try fallback location
 		if p.path != GetDefaultPath("") {
-			fallbackPath := filepath.Join("/tmp", filepath.Base(p.path))
-			p.path = fallbackPath
+			p.path = FallbackPath(p.path)
 			if err := p.write(); err != nil {
 				return fmt.Errorf("failed to write PID file: %w", err)
 			}
@@ -96,8 +95,72 @@ func (p *PIDFile) Remove() error {
 	return os.Remove(p.path)
 }
 
+// Path returns the location of the PID file
+func (p *PIDFile) Path() string {
+	return p.path
+}
+
+// ReadPID returns the PID recorded in the file
+func (p *PIDFile) ReadPID() (int, error) {
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, fmt.Errorf("no PID file at %s, is the monitor running?", p.path)
+		}
+		return 0, fmt.Errorf("failed to read PID file: %w", err)
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return 0, fmt.Errorf("invalid PID file %s", p.path)
+	}
+	return pid, nil
+}
+
+// Stop terminates the process recorded in the PID file and waits up to timeout for it to exit
+// On Unix it sends SIGTERM; Windows can't signal another process, so it is killed
+// The PID file is removed once the process is gone, including a stale file of a process that already exited
+func (p *PIDFile) Stop(timeout time.Duration) (int, error) {
+	pid, err := p.ReadPID()
+	if err != nil {
+		return 0, err
+	}
+
+	if !isProcessRunning(pid) {
+		os.Remove(p.path)
+		return pid, fmt.Errorf("process %d is not running, removed stale PID file %s", pid, p.path)
+	}
+
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return pid, fmt.Errorf("failed to find process %d: %w", pid, err)
+	}
+	if err := terminate(process); err != nil {
+		return pid, fmt.Errorf("failed to signal process %d: %w", pid, err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for isProcessRunning(pid) {
+		if time.Now().After(deadline) {
+			return pid, fmt.Errorf("process %d did not exit within %s", pid, timeout)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// The monitor removes its PID file on a graceful exit; this covers one that couldn't
+	if err := os.Remove(p.path); err != nil && !os.IsNotExist(err) {
+		return pid, fmt.Errorf("failed to remove PID file: %w", err)
+	}
+	return pid, nil
+}
+
+// FallbackPath returns the temp directory location Create falls back to when path is not writable
+func FallbackPath(path string) string {
+	return filepath.Join(tempDir(), filepath.Base(path))
+}
+
 // GetDefaultPath determines the best PID file location
-// Priority: 1) config directory, 2) /var/run, 3) /tmp
+// Priority: 1) config directory, 2) /var/run (%ProgramData% on Windows), 3) /tmp (%TEMP% on Windows)
 func GetDefaultPath(configPath string) string {
 	pidFileName := "go-magento-cron-monitor.pid"
 
@@ -110,14 +173,13 @@ func GetDefaultPath(configPath string) string {
 		}
 	}
 
-	// 2. Try /var/run
-	runDir := "/var/run"
-	if isWritable(runDir) {
+	// 2. Try the system run directory
+	if runDir := runDir(); runDir != "" && isWritable(runDir) {
 		return filepath.Join(runDir, pidFileName)
 	}
 
-	// 3. Fallback to /tmp
-	return filepath.Join("/tmp", pidFileName)
+	// 3. Fallback to the temp directory
+	return filepath.Join(tempDir(), pidFileName)
 }
 
 // isWritable tests if a directory is writable
@@ -132,15 +194,3 @@ func isWritable(path string) bool {
 	os.Remove(testFile)
 	return true
 }
-
-// isProcessRunning checks if a process with given PID exists
-func isProcessRunning(pid int) bool {
-	process, err := os.FindProcess(pid)
-	if err != nil {
-		return false
-	}
-
-	// Send signal 0 to check if process exists
-	err = process.Signal(syscall.Signal(0))
-	return err == nil
-}
@@ -0,0 +1,35 @@
+//go:build !windows
+
+package pidfile
+
+import (
+	"os"
+	"syscall"
+)
+
+// runDir returns the system directory for PID files
+func runDir() string {
+	return "/var/run"
+}
+
+// tempDir returns the directory PID files fall back to
+func tempDir() string {
+	return "/tmp"
+}
+
+// isProcessRunning checks if a process with given PID exists
+func isProcessRunning(pid int) bool {
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+
+	// Send signal 0 to check if process exists
+	err = process.Signal(syscall.Signal(0))
+	return err == nil
+}
+
+// terminate asks a process to shut down gracefully
+func terminate(process *os.Process) error {
+	return process.Signal(syscall.SIGTERM)
+}
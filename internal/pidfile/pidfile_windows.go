@@ -0,0 +1,47 @@
+//go:build windows
+
+package pidfile
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+const (
+	// processQueryLimitedInformation is the least access right that allows GetExitCodeProcess
+	processQueryLimitedInformation = 0x1000
+	// stillActive is the exit code GetExitCodeProcess reports for a running process
+	stillActive = 259
+)
+
+// runDir returns the system directory for PID files, %ProgramData%
+func runDir() string {
+	return os.Getenv("ProgramData")
+}
+
+// tempDir returns the directory PID files fall back to, %TEMP%
+func tempDir() string {
+	return os.TempDir()
+}
+
+// isProcessRunning checks if a process with given PID exists and hasn't exited
+func isProcessRunning(pid int) bool {
+	handle, err := syscall.OpenProcess(processQueryLimitedInformation, false, uint32(pid))
+	if err != nil {
+		// The process exists but belongs to another user
+		return errors.Is(err, syscall.ERROR_ACCESS_DENIED)
+	}
+	defer syscall.CloseHandle(handle)
+
+	var exitCode uint32
+	if err := syscall.GetExitCodeProcess(handle, &exitCode); err != nil {
+		return false
+	}
+	return exitCode == stillActive
+}
+
+// terminate kills a process; Windows has no signal to ask another process to shut down
+func terminate(process *os.Process) error {
+	return process.Kill()
+}
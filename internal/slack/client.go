@@ -2,10 +2,15 @@ package slack
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/cronalert"
+	"github.com/fabio/go-magento-cron-monitor/internal/httpclient"
 )
 
 // Config represents Slack notification configuration
@@ -16,12 +21,27 @@ type Config struct {
 	SendRecovery     bool          `yaml:"send_recovery"`
 	RecoveryCooldown time.Duration `yaml:"recovery_cooldown"`
 	Timeout          time.Duration `yaml:"timeout"`
+	MaxIdleConns     int           `yaml:"max_idle_conns"`
+	DisableKeepAlive bool          `yaml:"disable_keepalive"`
+	MaxMessageBytes  int           `yaml:"max_message_bytes"`
+	MaxRetries       int           `yaml:"max_retries"` // Retries per webhook on 429, 5xx and network errors
 }
 
+// Backoff between retries of a failed webhook request
+const (
+	retryInitialDelay = 1 * time.Second
+	retryMaxDelay     = 30 * time.Second
+	maxRetryAfter     = 60 * time.Second // Upper bound for a Retry-After header
+)
+
+// RetryFunc is called after a failed webhook request, before waiting delay for the next attempt
+type RetryFunc func(webhook, attempt int, delay time.Duration, err error)
+
 // Client handles Slack webhook notifications
 type Client struct {
 	config     Config
 	httpClient *http.Client
+	onRetry    RetryFunc
 }
 
 // New creates a new Slack client
@@ -32,24 +52,53 @@ func New(config Config) *Client {
 
 	return &Client{
 		config: config,
-		httpClient: &http.Client{
-			Timeout: config.Timeout,
-		},
+		httpClient: httpclient.New(httpclient.Config{
+			Timeout:          config.Timeout,
+			MaxIdleConns:     config.MaxIdleConns,
+			DisableKeepAlive: config.DisableKeepAlive,
+		}),
 	}
 }
 
+// OnRetry registers a callback invoked before each retry, e.g. for logging
+func (c *Client) OnRetry(fn RetryFunc) *Client {
+	c.onRetry = fn
+	return c
+}
+
 // SendAlert sends a cron alert to all configured Slack webhooks
-func (c *Client) SendAlert(alert CronAlert) error {
+func (c *Client) SendAlert(ctx context.Context, alert cronalert.Alert) error {
+	return c.SendAlertTo(ctx, alert, c.config.WebhookURLs)
+}
+
+// SendAlertTo sends a cron alert to the given Slack webhooks
+func (c *Client) SendAlertTo(ctx context.Context, alert cronalert.Alert, webhookURLs []string) error {
 	if !c.config.Enabled {
 		return nil
 	}
 
-	if len(c.config.WebhookURLs) == 0 {
+	if len(webhookURLs) == 0 {
 		return fmt.Errorf("no slack webhook URLs configured")
 	}
 
-	// Format the message once
-	message := FormatAlert(alert)
+	// Format the message once, truncating it to fit the size limit
+	message, err := FormatAlertWithLimit(alert, c.config.MaxMessageBytes)
+	if err != nil {
+		return err
+	}
+
+	return c.SendMessageTo(ctx, message, webhookURLs)
+}
+
+// SendMessageTo sends an already formatted message to the given Slack webhooks
+func (c *Client) SendMessageTo(ctx context.Context, message Message, webhookURLs []string) error {
+	if !c.config.Enabled {
+		return nil
+	}
+
+	if len(webhookURLs) == 0 {
+		return fmt.Errorf("no slack webhook URLs configured")
+	}
 
 	// Marshal to JSON once
 	payload, err := json.Marshal(message)
@@ -61,30 +110,16 @@ func (c *Client) SendAlert(alert CronAlert) error {
 	var lastError error
 	successCount := 0
 
-	for i, webhookURL := range c.config.WebhookURLs {
+	for i, webhookURL := range webhookURLs {
 		if webhookURL == "" {
 			continue
 		}
 
-		// Send to Slack
-		resp, err := c.httpClient.Post(
-			webhookURL,
-			"application/json",
-			bytes.NewBuffer(payload),
-		)
-		if err != nil {
-			lastError = fmt.Errorf("webhook %d failed: %w", i+1, err)
-			continue
-		}
-
-		// Check response
-		if resp.StatusCode != http.StatusOK {
-			resp.Body.Close()
-			lastError = fmt.Errorf("webhook %d returned non-OK status: %d", i+1, resp.StatusCode)
+		if err := c.post(ctx, webhookURL, i+1, payload); err != nil {
+			lastError = err
 			continue
 		}
 
-		resp.Body.Close()
 		successCount++
 	}
 
@@ -96,6 +131,81 @@ func (c *Client) SendAlert(alert CronAlert) error {
 	return nil
 }
 
+// post sends the payload to one webhook, retrying rate limits, server errors and network errors
+// up to MaxRetries times; 429 responses wait for Retry-After, others back off exponentially
+// Waiting for a retry stops when ctx is done
+func (c *Client) post(ctx context.Context, webhookURL string, webhook int, payload []byte) error {
+	delay := retryInitialDelay
+	for attempt := 1; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewBuffer(payload))
+		if err != nil {
+			return fmt.Errorf("webhook %d: failed to create request: %w", webhook, err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			err = fmt.Errorf("webhook %d failed: %w", webhook, err)
+		} else {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+			err = fmt.Errorf("webhook %d returned non-OK status: %d", webhook, resp.StatusCode)
+		}
+
+		retryable := resp == nil || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		if !retryable || attempt > c.config.MaxRetries {
+			if attempt > 1 {
+				return fmt.Errorf("%w (after %d attempts)", err, attempt)
+			}
+			return err
+		}
+
+		wait := delay
+		if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				wait = retryAfter
+			}
+		}
+		if c.onRetry != nil {
+			c.onRetry(webhook, attempt, wait, err)
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return fmt.Errorf("%w (retry cancelled after %d attempts: %v)", err, attempt, ctx.Err())
+		}
+
+		delay *= 2
+		if delay > retryMaxDelay {
+			delay = retryMaxDelay
+		}
+	}
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date, capped at maxRetryAfter
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	var wait time.Duration
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		wait = time.Duration(seconds) * time.Second
+	} else if at, err := http.ParseTime(value); err == nil {
+		wait = time.Until(at)
+		if wait < 0 {
+			wait = 0
+		}
+	} else {
+		return 0, false
+	}
+	if wait > maxRetryAfter {
+		wait = maxRetryAfter
+	}
+	return wait, true
+}
+
 // GetConfig returns the client configuration
 func (c *Client) GetConfig() Config {
 	return c.config
@@ -0,0 +1,56 @@
+package slack
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPostRetriesRateLimits(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	c := New(Config{Enabled: true, MaxRetries: 2})
+	if err := c.post(context.Background(), server.URL, 1, []byte("{}")); err != nil {
+		t.Fatalf("expected the retry to succeed, got %v", err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("expected 2 requests, got %d", n)
+	}
+}
+
+func TestPostRetryWaitHonorsContext(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	c := New(Config{Enabled: true, MaxRetries: 5})
+	start := time.Now()
+	if err := c.post(ctx, server.URL, 1, []byte("{}")); err == nil {
+		t.Fatal("expected an error when ctx is done during the backoff")
+	}
+	// Without ctx the backoff would take 1+2+4+8+16 seconds
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("expected the backoff to stop with ctx, took %s", elapsed)
+	}
+	if n := atomic.LoadInt32(&requests); n != 1 {
+		t.Errorf("expected no retry after ctx was done, got %d requests", n)
+	}
+}
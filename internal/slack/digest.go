@@ -0,0 +1,113 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/cronalert"
+)
+
+// Digest limits keep the message within Slack's block and text limits
+const (
+	maxDigestEntries   = 50  // Entries listed per section before summarizing the rest
+	maxDigestReasonLen = 200 // Reason characters per entry
+)
+
+// FormatDigest formats the alerts collected during a digest window as a single message
+// Alerting and recovered jobs are listed in separate sections, in the order they were collected
+func FormatDigest(alerts []cronalert.Alert, window time.Duration, now time.Time) Message {
+	var alerting, recovered []cronalert.Alert
+	for _, alert := range alerts {
+		if alert.Type == cronalert.Alerting {
+			alerting = append(alerting, alert)
+		} else {
+			recovered = append(recovered, alert)
+		}
+	}
+
+	headline := fmt.Sprintf("%d alerting, %d no longer alerting", len(alerting), len(recovered))
+	blocks := []Block{
+		{
+			Type: "header",
+			Text: &TextObject{
+				Type: "plain_text",
+				Text: "📋 Cron Alert Digest",
+			},
+		},
+		{
+			Type: "section",
+			Text: &TextObject{
+				Type: "mrkdwn",
+				Text: fmt.Sprintf("*Last %s:* %s", FormatDuration(window), headline),
+			},
+		},
+	}
+
+	if len(alerting) > 0 {
+		blocks = append(blocks, digestSections(fmt.Sprintf("🚨 Alerting (%d)", len(alerting)), alerting, alertingDigestLine)...)
+	}
+	if len(recovered) > 0 {
+		blocks = append(blocks, digestSections(fmt.Sprintf("✅ No Longer Alerting (%d)", len(recovered)), recovered, recoveredDigestLine)...)
+	}
+
+	// Metadata is instance-wide, so any alert carries the same values
+	var contextAlert cronalert.Alert
+	if len(alerts) > 0 {
+		contextAlert = alerts[0]
+	}
+	contextAlert.EscalationLevel = 0
+	contextAlert.Truncated = false
+	blocks = append(blocks, Block{
+		Type:     "context",
+		Elements: contextElements(contextAlert, fmt.Sprintf("🕒 Digest sent at %s", FormatTime(now))),
+	})
+
+	return Message{
+		Text:   fmt.Sprintf("📋 Cron alert digest: %s", headline),
+		Blocks: blocks,
+	}
+}
+
+// alertingDigestLine renders one alerting job of a digest
+func alertingDigestLine(alert cronalert.Alert) string {
+	reason := alert.Reason
+	if len(alert.Reasons) > 0 {
+		reason = strings.Join(alert.Reasons, "; ")
+	}
+	line := fmt.Sprintf("• %s — %s (%d consecutive)",
+		inlineCode(alert.CronCode, maxSummaryCodeLen), plainText(reason, maxDigestReasonLen), alert.ConsecutiveStuck)
+	if alert.Critical {
+		line += " 🔥"
+	}
+	return line
+}
+
+// recoveredDigestLine renders one recovered job of a digest
+func recoveredDigestLine(alert cronalert.Alert) string {
+	return fmt.Sprintf("• %s — was alerting for %s", inlineCode(alert.CronCode, maxSummaryCodeLen), FormatDuration(alert.StuckDuration))
+}
+
+// digestSections renders a titled list, split over as many sections as the section text limit requires
+func digestSections(title string, alerts []cronalert.Alert, line func(cronalert.Alert) string) []Block {
+	lines := make([]string, 0, len(alerts))
+	for i, alert := range alerts {
+		if i == maxDigestEntries {
+			lines = append(lines, fmt.Sprintf("…and %d more", len(alerts)-maxDigestEntries))
+			break
+		}
+		lines = append(lines, line(alert))
+	}
+
+	var blocks []Block
+	text := "*" + title + "*"
+	for _, l := range lines {
+		if len(text)+1+len(l) > maxSectionTextLen {
+			blocks = append(blocks, Block{Type: "section", Text: &TextObject{Type: "mrkdwn", Text: text}})
+			text = l
+			continue
+		}
+		text += "\n" + l
+	}
+	return append(blocks, Block{Type: "section", Text: &TextObject{Type: "mrkdwn", Text: text}})
+}
@@ -1,98 +1,214 @@
 package slack
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/cronalert"
 )
 
-// FormatAlert formats a CronAlert into a Slack message
-func FormatAlert(alert CronAlert) Message {
-	if alert.Type == AlertTypeAlerting {
-		return formatAlertingMessage(alert)
+// displayLocation is the timezone notification timestamps are shown in
+var displayLocation = time.UTC
+
+// SetLocation sets the timezone notification timestamps are shown in (default: UTC)
+// It is meant to be called on startup, before notifications are formatted
+func SetLocation(loc *time.Location) {
+	displayLocation = loc
+}
+
+// FormatTime formats a notification timestamp, e.g. "2025-10-22 06:56:00 UTC"
+func FormatTime(t time.Time) string {
+	return t.In(displayLocation).Format("2006-01-02 15:04:05 MST")
+}
+
+// FormatAlert formats a cronalert.Alert into a Slack message
+// An unknown alert type is rejected rather than rendered as one of the known messages
+func FormatAlert(alert cronalert.Alert) (Message, error) {
+	if err := alert.Type.Validate(); err != nil {
+		return Message{}, err
+	}
+	if alert.Type == cronalert.Alerting {
+		return formatAlertingMessage(alert), nil
 	}
-	return formatNotAlertingMessage(alert)
+	return formatNotAlertingMessage(alert), nil
+}
+
+// truncatedSuffix is appended to sections shortened to fit the message size limit
+const truncatedSuffix = "… (truncated)"
+
+// FormatAlertWithLimit formats a cronalert.Alert, truncating the least important sections
+// until the encoded message fits in maxBytes (0 = no limit)
+// The header and reason are always preserved; an error is returned if even the minimal message is too large
+func FormatAlertWithLimit(alert cronalert.Alert, maxBytes int) (Message, error) {
+	message, err := FormatAlert(alert)
+	if err != nil || maxBytes <= 0 {
+		return message, err
+	}
+
+	size, err := messageSize(message)
+	if err != nil {
+		return message, err
+	}
+
+	// Shorten the error message first, then drop it entirely
+	for size > maxBytes && alert.ErrorMessage != "" {
+		runes := []rune(alert.ErrorMessage)
+		keep := len(runes) - (size - maxBytes) - len([]rune(truncatedSuffix))
+		if keep <= 0 {
+			alert.ErrorMessage = ""
+		} else {
+			alert.ErrorMessage = string(runes[:keep]) + truncatedSuffix
+		}
+		alert.Truncated = true
+
+		message, _ = FormatAlert(alert) // The type was validated above
+		if size, err = messageSize(message); err != nil {
+			return message, err
+		}
+	}
+
+	if size > maxBytes {
+		return message, fmt.Errorf("slack message is %d bytes, exceeds limit of %d bytes even after truncation", size, maxBytes)
+	}
+
+	return message, nil
+}
+
+// messageSize returns the encoded size of a message in bytes
+func messageSize(message Message) (int, error) {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal slack message: %w", err)
+	}
+	return len(payload), nil
 }
 
 // formatAlertingMessage creates a detailed alerting cron alert message
-func formatAlertingMessage(alert CronAlert) Message {
-	timestamp := alert.Timestamp.UTC().Format("2006-01-02 15:04:05 UTC")
+func formatAlertingMessage(alert cronalert.Alert) Message {
+	timestamp := FormatTime(alert.Timestamp)
 	lastExec := "Never"
 	if !alert.LastExecution.IsZero() {
-		lastExec = alert.LastExecution.UTC().Format("2006-01-02 15:04:05 UTC")
+		lastExec = FormatTime(alert.LastExecution)
 	}
 	
 	scheduledAt := "N/A"
 	if alert.ScheduledAt != nil && !alert.ScheduledAt.IsZero() {
-		scheduledAt = alert.ScheduledAt.UTC().Format("2006-01-02 15:04:05 UTC")
+		scheduledAt = FormatTime(*alert.ScheduledAt)
 	}
 	
 	runningTime := "N/A"
 	if alert.RunningTime != nil {
-		runningTime = formatDuration(*alert.RunningTime)
+		runningTime = FormatDuration(*alert.RunningTime)
 	}
 
-	return Message{
-		Text: fmt.Sprintf("🚨 Cron job `%s` is alerting!", alert.CronCode),
-		Blocks: []Block{
-			{
-				Type: "header",
-				Text: &TextObject{
-					Type: "plain_text",
-					Text: "🚨 Cron Job Alert",
-				},
-			},
-			{
-				Type: "section",
-				Fields: []TextObject{
-					{Type: "mrkdwn", Text: fmt.Sprintf("*Cron Job:*\n`%s`", alert.CronCode)},
-					{Type: "mrkdwn", Text: "*Monitor Status:*\n🔴 Alerting"},
-					{Type: "mrkdwn", Text: fmt.Sprintf("*Consecutive Issues:*\n%d", alert.ConsecutiveStuck)},
-				},
-			},
-			{
-				Type: "section",
-				Text: &TextObject{
-					Type: "mrkdwn",
-					Text: "*⏱️ Timing Details:*",
-				},
+	icon := cronalert.SeverityIcon(alert.Severity)
+	header := icon + " Cron Job Alert"
+	summary := fmt.Sprintf("%s Cron job %s is alerting!", icon, inlineCode(alert.CronCode, maxSummaryCodeLen))
+	if alert.Critical {
+		header = "🔥 Critical Cron Job Alert"
+		summary = fmt.Sprintf("🔥 Critical cron job %s is alerting!", inlineCode(alert.CronCode, maxSummaryCodeLen))
+	}
+
+	jobFields := []TextObject{
+		{Type: "mrkdwn", Text: "*Cron Job:*\n" + inlineCode(alert.CronCode, maxFieldTextLen-len("*Cron Job:*\n"))},
+		{Type: "mrkdwn", Text: "*Monitor Status:*\n🔴 Alerting"},
+		{Type: "mrkdwn", Text: fmt.Sprintf("*Consecutive Issues:*\n%d", alert.ConsecutiveStuck)},
+	}
+	if alert.Severity != "" {
+		jobFields = append(jobFields, TextObject{Type: "mrkdwn", Text: fmt.Sprintf("*Severity:*\n%s %s", cronalert.SeverityIcon(alert.Severity), alert.Severity)})
+	}
+	if alert.CronGroup != "" {
+		jobFields = append(jobFields, cronGroupField(alert.CronGroup))
+	}
+
+	blocks := []Block{
+		{
+			Type: "header",
+			Text: &TextObject{
+				Type: "plain_text",
+				Text: header,
 			},
-			{
-				Type: "section",
-				Fields: []TextObject{
-					{Type: "mrkdwn", Text: fmt.Sprintf("*Scheduled At:*\n%s", scheduledAt)},
-					{Type: "mrkdwn", Text: fmt.Sprintf("*Last Execution:*\n%s", lastExec)},
-					{Type: "mrkdwn", Text: fmt.Sprintf("*Running Time:*\n%s", runningTime)},
-				},
+		},
+		{
+			Type:   "section",
+			Fields: jobFields,
+		},
+		{
+			Type: "section",
+			Text: &TextObject{
+				Type: "mrkdwn",
+				Text: "*⏱️ Timing Details:*",
 			},
-			{
-				Type: "section",
-				Text: &TextObject{
-					Type: "mrkdwn",
-					Text: fmt.Sprintf("*🔍 Problem Details:*\n%s", alert.Reason),
-				},
+		},
+		{
+			Type: "section",
+			Fields: []TextObject{
+				{Type: "mrkdwn", Text: fmt.Sprintf("*Scheduled At:*\n%s", scheduledAt)},
+				{Type: "mrkdwn", Text: fmt.Sprintf("*Last Execution:*\n%s", lastExec)},
+				{Type: "mrkdwn", Text: fmt.Sprintf("*Running Time:*\n%s", runningTime)},
 			},
-			{
-				Type: "context",
-				Elements: []TextObject{
-					{Type: "mrkdwn", Text: fmt.Sprintf("🕒 Alerted at %s", timestamp)},
-				},
+		},
+		{
+			Type: "section",
+			Text: &TextObject{
+				Type: "mrkdwn",
+				Text: problemDetails(alert),
 			},
 		},
 	}
+
+	if alert.ErrorMessage != "" {
+		blocks = append(blocks, Block{
+			Type: "section",
+			Text: &TextObject{
+				Type: "mrkdwn",
+				Text: "*❗ Error Message:*\n" + codeBlock(alert.ErrorMessage, maxSectionTextLen-len("*❗ Error Message:*\n")),
+			},
+		})
+	}
+
+	blocks = append(blocks, Block{
+		Type:     "context",
+		Elements: contextElements(alert, fmt.Sprintf("🕒 Alerted at %s", timestamp)),
+	})
+
+	return Message{
+		Text:   summary,
+		Blocks: blocks,
+	}
 }
 
 // formatNotAlertingMessage creates a Slack message for a cron job that's no longer alerting
-func formatNotAlertingMessage(alert CronAlert) Message {
-	timestamp := alert.Timestamp.UTC().Format("2006-01-02 15:04:05 UTC")
-	duration := formatDuration(alert.StuckDuration)
+func formatNotAlertingMessage(alert cronalert.Alert) Message {
+	timestamp := FormatTime(alert.Timestamp)
+	duration := FormatDuration(alert.StuckDuration)
 	
 	lastExec := "Never"
 	if !alert.LastExecution.IsZero() {
-		lastExec = alert.LastExecution.UTC().Format("2006-01-02 15:04:05 UTC")
+		lastExec = FormatTime(alert.LastExecution)
+	}
+
+	jobFields := []TextObject{
+		{Type: "mrkdwn", Text: "*Cron Job:*\n" + inlineCode(alert.CronCode, maxFieldTextLen-len("*Cron Job:*\n"))},
+		{Type: "mrkdwn", Text: "*Monitor Status:*\n🟢 Not Alerting"},
+	}
+	if alert.CronGroup != "" {
+		jobFields = append(jobFields, cronGroupField(alert.CronGroup))
+	}
+
+	fields := []TextObject{
+		{Type: "mrkdwn", Text: fmt.Sprintf("*Was Alerting For:*\n%s ⏱️", duration)},
+		{Type: "mrkdwn", Text: fmt.Sprintf("*Last Successful Execution:*\n%s", lastExec)},
+	}
+	if alert.CompletionTime != nil {
+		fields = append(fields, TextObject{Type: "mrkdwn", Text: fmt.Sprintf("*Completed In:*\n%s", FormatDuration(*alert.CompletionTime))})
 	}
 
 	return Message{
-		Text: fmt.Sprintf("✅ Cron job `%s` is no longer alerting!", alert.CronCode),
+		Text: fmt.Sprintf("✅ Cron job %s is no longer alerting!", inlineCode(alert.CronCode, maxSummaryCodeLen)),
 		Blocks: []Block{
 			{
 				Type: "header",
@@ -102,31 +218,89 @@ func formatNotAlertingMessage(alert CronAlert) Message {
 				},
 			},
 			{
-				Type: "section",
-				Fields: []TextObject{
-					{Type: "mrkdwn", Text: fmt.Sprintf("*Cron Job:*\n`%s`", alert.CronCode)},
-					{Type: "mrkdwn", Text: "*Monitor Status:*\n🟢 Not Alerting"},
-				},
+				Type:   "section",
+				Fields: jobFields,
 			},
 			{
-				Type: "section",
-				Fields: []TextObject{
-					{Type: "mrkdwn", Text: fmt.Sprintf("*Was Alerting For:*\n%s ⏱️", duration)},
-					{Type: "mrkdwn", Text: fmt.Sprintf("*Last Successful Execution:*\n%s", lastExec)},
-				},
+				Type:   "section",
+				Fields: fields,
 			},
 			{
 				Type: "context",
-				Elements: []TextObject{
-					{Type: "mrkdwn", Text: fmt.Sprintf("🕒 No longer alerting at %s", timestamp)},
-				},
+				Elements: contextElements(alert, fmt.Sprintf("🕒 No longer alerting at %s", timestamp)),
 			},
 		},
 	}
 }
 
-// formatDuration formats a duration in human-readable format
-func formatDuration(d time.Duration) string {
+// cronGroupField renders the job's group as a section field
+func cronGroupField(group string) TextObject {
+	return TextObject{Type: "mrkdwn", Text: "*Cron Group:*\n" + plainText(group, maxFieldTextLen-len("*Cron Group:*\n"))}
+}
+
+// problemDetails renders the alert reason, or a bulleted list when the alert carries several reasons
+func problemDetails(alert cronalert.Alert) string {
+	const title = "*🔍 Problem Details:*\n"
+	budget := maxSectionTextLen - len(title)
+
+	switch len(alert.Reasons) {
+	case 0:
+		return title + plainText(alert.Reason, budget)
+	case 1:
+		return title + plainText(alert.Reasons[0], budget)
+	}
+
+	// Share the section limit evenly so every reason stays visible
+	perReason := budget/len(alert.Reasons) - len("• \n")
+	lines := make([]string, len(alert.Reasons))
+	for i, reason := range alert.Reasons {
+		lines[i] = "• " + plainText(reason, perReason)
+	}
+	return title + strings.Join(lines, "\n")
+}
+
+// contextElements builds the context line elements, appending static metadata if configured
+func contextElements(alert cronalert.Alert, timestampText string) []TextObject {
+	elements := []TextObject{
+		{Type: "mrkdwn", Text: timestampText},
+	}
+
+	if len(alert.Metadata) > 0 {
+		elements = append(elements, metadataElement(alert.Metadata))
+	}
+
+	if alert.MagentoVersion != "" {
+		elements = append(elements, TextObject{Type: "mrkdwn", Text: fmt.Sprintf("🛒 Magento %s", alert.MagentoVersion)})
+	}
+
+	if alert.EscalationLevel > 0 {
+		elements = append(elements, TextObject{Type: "mrkdwn", Text: fmt.Sprintf("📣 Escalation level %d: still alerting after %s", alert.EscalationLevel, FormatDuration(alert.StuckDuration))})
+	}
+
+	if alert.Truncated {
+		elements = append(elements, TextObject{Type: "mrkdwn", Text: "✂️ Some details were truncated to fit Slack's message size limit"})
+	}
+
+	return elements
+}
+
+// metadataElement renders static metadata as a context element, sorted by key
+func metadataElement(metadata map[string]string) TextObject {
+	keys := make([]string, 0, len(metadata))
+	for k := range metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s: %s", plainText(k, maxFieldTextLen), plainText(metadata[k], maxFieldTextLen)))
+	}
+	return TextObject{Type: "mrkdwn", Text: fmt.Sprintf("🏷️ %s", strings.Join(pairs, " · "))}
+}
+
+// FormatDuration formats a duration in human-readable format
+func FormatDuration(d time.Duration) string {
 	if d < time.Minute {
 		return fmt.Sprintf("%d seconds", int(d.Seconds()))
 	}
@@ -0,0 +1,52 @@
+package slack
+
+import "strings"
+
+// Slack Block Kit text limits (in characters)
+const (
+	maxFieldTextLen   = 2000 // Section field text
+	maxSectionTextLen = 3000 // Section text
+	maxSummaryCodeLen = 80   // Job code in the notification preview text
+)
+
+// ellipsis marks text shortened to fit a Slack limit
+const ellipsis = "…"
+
+// mrkdwnEscaper escapes the characters Slack requires to be encoded in mrkdwn text
+var mrkdwnEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// backtickReplacer replaces backticks, which would terminate inline code and code blocks
+var backtickReplacer = strings.NewReplacer("`", "ˋ")
+
+// formatReplacer neutralizes bold, strikethrough and code markers in plain values
+// Underscores are left alone: Slack doesn't italicize inside words such as max_running_time
+var formatReplacer = strings.NewReplacer("*", "∗", "~", "∼", "`", "ˋ")
+
+// truncateText shortens s to at most maxLen characters, marking the cut with an ellipsis
+// A cut through an escaped entity such as &amp; drops the partial entity
+func truncateText(s string, maxLen int) string {
+	runes := []rune(s)
+	if len(runes) <= maxLen {
+		return s
+	}
+	cut := string(runes[:maxLen-len([]rune(ellipsis))])
+	if amp := strings.LastIndex(cut, "&"); amp > strings.LastIndex(cut, ";") {
+		cut = cut[:amp]
+	}
+	return cut + ellipsis
+}
+
+// inlineCode renders a value as mrkdwn inline code, at most maxLen characters long including the backticks
+func inlineCode(s string, maxLen int) string {
+	return "`" + truncateText(mrkdwnEscaper.Replace(backtickReplacer.Replace(s)), maxLen-2) + "`"
+}
+
+// codeBlock renders a value as a mrkdwn code block, at most maxLen characters long including the fences
+func codeBlock(s string, maxLen int) string {
+	return "```" + truncateText(mrkdwnEscaper.Replace(backtickReplacer.Replace(s)), maxLen-6) + "```"
+}
+
+// plainText escapes a value for mrkdwn so it is rendered literally, at most maxLen characters long
+func plainText(s string, maxLen int) string {
+	return truncateText(mrkdwnEscaper.Replace(formatReplacer.Replace(s)), maxLen)
+}
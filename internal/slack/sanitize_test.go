@@ -0,0 +1,111 @@
+package slack
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/cronalert"
+)
+
+func TestTruncateText(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		maxLen int
+		want   string
+	}{
+		{"fits", "sales_export", 20, "sales_export"},
+		{"exact length", "sales_export", 12, "sales_export"},
+		{"cut with ellipsis", "sales_export", 6, "sales…"},
+		{"counts characters, not bytes", "ééééé", 4, "ééé…"},
+		{"drops a partial entity", "a &amp; b", 5, "a …"},
+		{"keeps a whole entity", "a &amp; b", 8, "a &amp;…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := truncateText(tt.input, tt.maxLen); got != tt.want {
+				t.Errorf("truncateText(%q, %d) = %q, want %q", tt.input, tt.maxLen, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInlineCodeEscaping(t *testing.T) {
+	got := inlineCode("job`<!channel>&co", 80)
+	want := "`jobˋ&lt;!channel&gt;&amp;co`"
+	if got != want {
+		t.Errorf("inlineCode = %q, want %q", got, want)
+	}
+}
+
+func TestCodeBlockEscaping(t *testing.T) {
+	got := codeBlock("```\nSQLSTATE[HY000] <b>", 100)
+	want := "```ˋˋˋ\nSQLSTATE[HY000] &lt;b&gt;```"
+	if got != want {
+		t.Errorf("codeBlock = %q, want %q", got, want)
+	}
+}
+
+func TestPlainTextEscaping(t *testing.T) {
+	got := plainText("*bold* ~strike~ `code` <@U123> max_running_time", 100)
+	want := "∗bold∗ ∼strike∼ ˋcodeˋ &lt;@U123&gt; max_running_time"
+	if got != want {
+		t.Errorf("plainText = %q, want %q", got, want)
+	}
+}
+
+func TestCodeBlockTruncatesLongMessage(t *testing.T) {
+	message := strings.Repeat("x", 2990) + "<&>`"
+
+	got := codeBlock(message, maxSectionTextLen)
+	if n := utf8.RuneCountInString(got); n > maxSectionTextLen {
+		t.Fatalf("expected at most %d characters, got %d", maxSectionTextLen, n)
+	}
+	if !strings.HasPrefix(got, "```") || !strings.HasSuffix(got, ellipsis+"```") {
+		t.Errorf("expected a closed code block ending in an ellipsis, got ...%q", got[len(got)-20:])
+	}
+	if strings.Count(got, "```") != 2 {
+		t.Errorf("expected only the opening and closing fences, got %d", strings.Count(got, "```"))
+	}
+}
+
+func TestFormatAlertLimitsLongValues(t *testing.T) {
+	long := strings.Repeat("`<&>", 750)
+	runningTime := 45 * time.Minute
+	alert := cronalert.Alert{
+		Type:             cronalert.Alerting,
+		CronCode:         strings.Repeat("sales_export_", 250),
+		CronGroup:        long,
+		Status:           "running",
+		LastExecution:    time.Now(),
+		Timestamp:        time.Now(),
+		RunningTime:      &runningTime,
+		Reason:           long,
+		ErrorMessage:     long,
+		ConsecutiveStuck: 2,
+	}
+
+	message, err := FormatAlert(alert)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, block := range message.Blocks {
+		if block.Text != nil {
+			if n := utf8.RuneCountInString(block.Text.Text); n > maxSectionTextLen {
+				t.Errorf("section text of %d characters exceeds %d", n, maxSectionTextLen)
+			}
+			if strings.Contains(block.Text.Text, "<") {
+				t.Errorf("expected < to be escaped in section text")
+			}
+		}
+		for _, field := range block.Fields {
+			if n := utf8.RuneCountInString(field.Text); n > maxFieldTextLen {
+				t.Errorf("field text of %d characters exceeds %d", n, maxFieldTextLen)
+			}
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package slack
+
+import (
+	"fmt"
+	"time"
+)
+
+// DailySummary is a digest of the monitor's incidents over a reporting period
+type DailySummary struct {
+	PeriodStart time.Time
+	PeriodEnd   time.Time
+
+	Incidents        int           // Incidents that started in the period
+	JobsAffected     int           // Distinct jobs with incidents
+	LongestStuck     time.Duration // Longest incident of the period
+	LongestJob       string        // Job of the longest incident
+	StillAlerting    int           // Jobs still alerting at the end of the period
+	SchedulerOutages int           // Scheduler inactivity incidents in the period
+	SchedulerDown    time.Duration // Total scheduler inactivity in the period
+
+	// Metadata holds static instance-wide key/values (e.g. region, cluster)
+	Metadata map[string]string
+	// MagentoVersion is the version of the monitored Magento installation, if known
+	MagentoVersion string
+}
+
+// FormatDailySummary formats a daily digest message
+func FormatDailySummary(summary DailySummary) Message {
+	headline := "No incidents"
+	if summary.Incidents > 0 {
+		headline = fmt.Sprintf("%d %s across %d %s, longest stuck %s (%s)",
+			summary.Incidents, plural(summary.Incidents, "incident", "incidents"),
+			summary.JobsAffected, plural(summary.JobsAffected, "job", "jobs"),
+			FormatDuration(summary.LongestStuck), inlineCode(summary.LongestJob, maxSummaryCodeLen))
+	}
+
+	scheduler := "🟢 Healthy"
+	if summary.SchedulerOutages > 0 {
+		scheduler = fmt.Sprintf("🔴 Inactive %d %s, %s in total",
+			summary.SchedulerOutages, plural(summary.SchedulerOutages, "time", "times"), FormatDuration(summary.SchedulerDown))
+	}
+
+	period := fmt.Sprintf("🕒 %s – %s",
+		summary.PeriodStart.In(displayLocation).Format("2006-01-02 15:04 MST"), summary.PeriodEnd.In(displayLocation).Format("2006-01-02 15:04 MST"))
+	elements := []TextObject{{Type: "mrkdwn", Text: period}}
+	if len(summary.Metadata) > 0 {
+		elements = append(elements, metadataElement(summary.Metadata))
+	}
+	if summary.MagentoVersion != "" {
+		elements = append(elements, TextObject{Type: "mrkdwn", Text: fmt.Sprintf("🛒 Magento %s", summary.MagentoVersion)})
+	}
+
+	return Message{
+		Text: fmt.Sprintf("📊 Daily cron summary: %s", headline),
+		Blocks: []Block{
+			{
+				Type: "header",
+				Text: &TextObject{
+					Type: "plain_text",
+					Text: "📊 Daily Cron Summary",
+				},
+			},
+			{
+				Type: "section",
+				Text: &TextObject{
+					Type: "mrkdwn",
+					Text: "*Last 24 hours:* " + headline,
+				},
+			},
+			{
+				Type: "section",
+				Fields: []TextObject{
+					{Type: "mrkdwn", Text: fmt.Sprintf("*Incidents:*\n%d", summary.Incidents)},
+					{Type: "mrkdwn", Text: fmt.Sprintf("*Jobs Affected:*\n%d", summary.JobsAffected)},
+					{Type: "mrkdwn", Text: fmt.Sprintf("*Still Alerting:*\n%d", summary.StillAlerting)},
+					{Type: "mrkdwn", Text: "*Scheduler:*\n" + scheduler},
+				},
+			},
+			{
+				Type:     "context",
+				Elements: elements,
+			},
+		},
+	}
+}
+
+// plural picks the singular or plural form for n
+func plural(n int, singular, pluralForm string) string {
+	if n == 1 {
+		return singular
+	}
+	return pluralForm
+}
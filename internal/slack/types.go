@@ -1,34 +1,5 @@
 package slack
 
-import "time"
-
-// AlertType represents the type of alert
-type AlertType string
-
-const (
-	AlertTypeAlerting    AlertType = "alerting"
-	AlertTypeNotAlerting AlertType = "not_alerting"
-)
-
-// CronAlert represents a cron job alert for Slack
-type CronAlert struct {
-	Type          AlertType
-	CronCode      string        // e.g., "indexer_reindex_all_invalid"
-	Status        string        // e.g., "pending", "running", "missed"
-	LastExecution time.Time
-	StuckDuration time.Duration // For recovery notifications
-	Timestamp     time.Time
-	
-	// Enhanced fields for detailed alerts
-	RunningTime      *time.Duration
-	ScheduledAt      *time.Time
-	Reason           string
-	ConsecutiveStuck int
-	PendingCount     int
-	ErrorCount       int
-	MissedCount      int
-}
-
 // Message represents a Slack message with blocks
 type Message struct {
 	Text   string  `json:"text"`
@@ -0,0 +1,152 @@
+package snooze
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"gopkg.in/yaml.v3"
+)
+
+// Snooze suppresses notifications for jobs matching a pattern until it expires
+type Snooze struct {
+	Job    string    `yaml:"job"`    // Job code or glob pattern, e.g. indexer_*
+	Until  time.Time `yaml:"until"`  // RFC 3339 expiry time
+	Reason string    `yaml:"reason"` // Optional note for other operators
+}
+
+// snoozeFile is the on-disk layout of the snooze file
+type snoozeFile struct {
+	Snoozes []Snooze `yaml:"snoozes"`
+}
+
+// Load reads and validates a snooze file; a missing file means no snoozes
+func Load(file string) ([]Snooze, error) {
+	data, err := os.ReadFile(file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snooze file: %w", err)
+	}
+
+	var parsed snoozeFile
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse snooze file: %w", err)
+	}
+
+	for i, s := range parsed.Snoozes {
+		if s.Job == "" {
+			return nil, fmt.Errorf("snoozes[%d]: job is required", i)
+		}
+		if _, err := path.Match(s.Job, ""); err != nil {
+			return nil, fmt.Errorf("snoozes[%d]: invalid job pattern %q: %w", i, s.Job, err)
+		}
+		if s.Until.IsZero() {
+			return nil, fmt.Errorf("snoozes[%d]: until is required", i)
+		}
+	}
+
+	return parsed.Snoozes, nil
+}
+
+// List holds the snoozes of a file and keeps them current while watched
+type List struct {
+	file    string
+	mu      sync.RWMutex
+	snoozes []Snooze
+}
+
+// NewList creates a snooze list for the given file, which is read by Reload
+func NewList(file string) *List {
+	return &List{file: file}
+}
+
+// File returns the path of the snooze file
+func (l *List) File() string {
+	return l.file
+}
+
+// Reload re-reads the snooze file, keeping the previous snoozes if it is invalid
+func (l *List) Reload() error {
+	snoozes, err := Load(l.file)
+	if err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	l.snoozes = snoozes
+	l.mu.Unlock()
+	return nil
+}
+
+// Active returns the snoozes that have not expired, soonest expiry first
+func (l *List) Active(now time.Time) []Snooze {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	active := make([]Snooze, 0)
+	for _, s := range l.snoozes {
+		if now.Before(s.Until) {
+			active = append(active, s)
+		}
+	}
+	sort.SliceStable(active, func(i, j int) bool { return active[i].Until.Before(active[j].Until) })
+	return active
+}
+
+// Match returns the active snooze covering a job code, or nil
+func (l *List) Match(jobCode string, now time.Time) *Snooze {
+	for _, s := range l.Active(now) {
+		if matched, _ := path.Match(s.Job, jobCode); matched {
+			return &s
+		}
+	}
+	return nil
+}
+
+// Watch reloads the list whenever the file changes until ctx is cancelled
+// The directory is watched so files replaced by editors or config management are picked up
+func (l *List) Watch(ctx context.Context, onReload func(error)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	if err := watcher.Add(filepath.Dir(l.file)); err != nil {
+		watcher.Close()
+		return fmt.Errorf("failed to watch snooze file directory: %w", err)
+	}
+
+	go func() {
+		defer watcher.Close()
+		target := filepath.Clean(l.file)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Clean(event.Name) != target || event.Op == fsnotify.Chmod {
+					continue
+				}
+				onReload(l.Reload())
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				onReload(err)
+			}
+		}
+	}()
+
+	return nil
+}
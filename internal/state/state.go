@@ -0,0 +1,116 @@
+package state
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ErrUndecryptable is returned when a state file can't be decrypted with the configured key
+var ErrUndecryptable = errors.New("state file cannot be decrypted with the configured key")
+
+// Store reads and writes the persisted monitor state, optionally encrypted with AES-GCM
+type Store struct {
+	path string
+	aead cipher.AEAD // nil when encryption is disabled
+}
+
+// NewStore creates a state store; an empty key stores the state as plain JSON
+func NewStore(path, encryptionKey string) (*Store, error) {
+	store := &Store{path: path}
+	if encryptionKey == "" {
+		return store, nil
+	}
+
+	// Derive a 256-bit key so any passphrase length works
+	key := sha256.Sum256([]byte(encryptionKey))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+	store.aead = aead
+
+	return store, nil
+}
+
+// Load returns the stored state, or nil if no state file exists
+func (s *Store) Load() ([]byte, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+
+	if s.aead == nil {
+		return data, nil
+	}
+
+	nonceSize := s.aead.NonceSize()
+	if len(data) < nonceSize {
+		return nil, ErrUndecryptable
+	}
+	plaintext, err := s.aead.Open(nil, data[:nonceSize], data[nonceSize:], nil)
+	if err != nil {
+		return nil, ErrUndecryptable
+	}
+
+	return plaintext, nil
+}
+
+// Save writes the state atomically with owner-only permissions
+func (s *Store) Save(data []byte) error {
+	if s.aead != nil {
+		nonce := make([]byte, s.aead.NonceSize())
+		if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+			return fmt.Errorf("failed to generate nonce: %w", err)
+		}
+		data = s.aead.Seal(nonce, nonce, data, nil)
+	}
+
+	return WriteFileAtomic(s.path, data, 0600)
+}
+
+// WriteFileAtomic writes data to a temp file next to path and renames it into place,
+// so readers never see a partially written file
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to set file permissions: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace file: %w", err)
+	}
+
+	return nil
+}
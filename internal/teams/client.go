@@ -0,0 +1,106 @@
+package teams
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/cronalert"
+	"github.com/fabio/go-magento-cron-monitor/internal/httpclient"
+)
+
+// Config represents Microsoft Teams notification configuration
+type Config struct {
+	Enabled          bool          `yaml:"enabled"`
+	WebhookURLs      []string      `yaml:"webhook_urls"`
+	AlertCooldown    time.Duration `yaml:"alert_cooldown"`
+	SendRecovery     bool          `yaml:"send_recovery"`
+	RecoveryCooldown time.Duration `yaml:"recovery_cooldown"`
+	Timeout          time.Duration `yaml:"timeout"`
+}
+
+// Client handles Teams incoming-webhook notifications
+type Client struct {
+	config     Config
+	httpClient *http.Client
+}
+
+// New creates a new Teams client
+func New(config Config) *Client {
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second
+	}
+
+	return &Client{
+		config:     config,
+		httpClient: httpclient.New(httpclient.Config{Timeout: config.Timeout}),
+	}
+}
+
+// SendAlert sends a cron alert to all configured Teams webhooks
+func (c *Client) SendAlert(ctx context.Context, alert cronalert.Alert) error {
+	if !c.config.Enabled {
+		return nil
+	}
+
+	if len(c.config.WebhookURLs) == 0 {
+		return fmt.Errorf("no teams webhook URLs configured")
+	}
+
+	message, err := FormatAlert(alert)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal teams message: %w", err)
+	}
+
+	// Send to all webhooks
+	var lastError error
+	successCount := 0
+
+	for i, webhookURL := range c.config.WebhookURLs {
+		if webhookURL == "" {
+			continue
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewBuffer(payload))
+		if err != nil {
+			lastError = fmt.Errorf("webhook %d: failed to create request: %w", i+1, err)
+			continue
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			lastError = fmt.Errorf("webhook %d failed: %w", i+1, err)
+			continue
+		}
+		resp.Body.Close()
+
+		// Workflow webhooks answer 202 Accepted, legacy connectors 200 OK
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			lastError = fmt.Errorf("webhook %d returned non-OK status: %d", i+1, resp.StatusCode)
+			continue
+		}
+
+		successCount++
+	}
+
+	// If all webhooks failed, return the last error
+	if successCount == 0 && lastError != nil {
+		return lastError
+	}
+
+	return nil
+}
+
+// GetConfig returns the client configuration
+func (c *Client) GetConfig() Config {
+	return c.config
+}
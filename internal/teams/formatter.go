@@ -0,0 +1,176 @@
+package teams
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/cronalert"
+	"github.com/fabio/go-magento-cron-monitor/internal/slack"
+)
+
+// FormatAlert formats a cron alert into a Teams message with an Adaptive Card
+// Alerting cards use the red "attention" style, recovery cards the green "good" style
+func FormatAlert(alert cronalert.Alert) (Message, error) {
+	if err := alert.Type.Validate(); err != nil {
+		return Message{}, err
+	}
+
+	var body []Element
+	if alert.Type == cronalert.Alerting {
+		body = alertingBody(alert)
+	} else {
+		body = notAlertingBody(alert)
+	}
+
+	return Message{
+		Type: "message",
+		Attachments: []Attachment{{
+			ContentType: "application/vnd.microsoft.card.adaptive",
+			Content: Card{
+				Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+				Type:    "AdaptiveCard",
+				Version: "1.4",
+				Body:    body,
+				MSTeams: &CardOpts{Width: "Full"},
+			},
+		}},
+	}, nil
+}
+
+// alertingBody builds the card body of an alerting notification
+func alertingBody(alert cronalert.Alert) []Element {
+	title := cronalert.SeverityIcon(alert.Severity) + " Cron Job Alert"
+	if alert.Critical {
+		title = "🔥 Critical Cron Job Alert"
+	}
+
+	scheduledAt := "N/A"
+	if alert.ScheduledAt != nil && !alert.ScheduledAt.IsZero() {
+		scheduledAt = formatTime(*alert.ScheduledAt)
+	}
+	runningTime := "N/A"
+	if alert.RunningTime != nil {
+		runningTime = slack.FormatDuration(*alert.RunningTime)
+	}
+
+	facts := jobFacts(alert, "🔴 Alerting")
+	facts = append(facts,
+		Fact{"Consecutive Issues", fmt.Sprintf("%d", alert.ConsecutiveStuck)},
+		Fact{"Scheduled At", scheduledAt},
+		Fact{"Last Execution", lastExecution(alert)},
+		Fact{"Running Time", runningTime},
+	)
+
+	body := []Element{
+		header(title, "attention"),
+		{Type: "FactSet", Facts: facts},
+		{Type: "TextBlock", Text: "Problem Details", Weight: "Bolder", Spacing: "Medium"},
+		{Type: "TextBlock", Text: problemDetails(alert), Wrap: true},
+	}
+
+	if alert.ErrorMessage != "" {
+		body = append(body,
+			Element{Type: "TextBlock", Text: "Error Message", Weight: "Bolder", Spacing: "Medium"},
+			Element{Type: "TextBlock", Text: alert.ErrorMessage, Wrap: true, Color: "Attention"},
+		)
+	}
+
+	return append(body, contextBlock(alert, "Alerted at "+formatTime(alert.Timestamp)))
+}
+
+// notAlertingBody builds the card body of a recovery notification
+func notAlertingBody(alert cronalert.Alert) []Element {
+	facts := jobFacts(alert, "🟢 Not Alerting")
+	facts = append(facts,
+		Fact{"Was Alerting For", slack.FormatDuration(alert.StuckDuration)},
+		Fact{"Last Successful Execution", lastExecution(alert)},
+	)
+	if alert.CompletionTime != nil {
+		facts = append(facts, Fact{"Completed In", slack.FormatDuration(*alert.CompletionTime)})
+	}
+
+	return []Element{
+		header("✅ Cron Job No Longer Alerting", "good"),
+		{Type: "FactSet", Facts: facts},
+		contextBlock(alert, "No longer alerting at "+formatTime(alert.Timestamp)),
+	}
+}
+
+// header renders the card title in a full-width container of the given style
+func header(title, style string) Element {
+	return Element{
+		Type:  "Container",
+		Style: style,
+		Bleed: true,
+		Items: []Element{{Type: "TextBlock", Text: title, Size: "Large", Weight: "Bolder", Wrap: true}},
+	}
+}
+
+// jobFacts returns the facts identifying the job
+func jobFacts(alert cronalert.Alert, status string) []Fact {
+	facts := []Fact{
+		{"Cron Job", alert.CronCode},
+		{"Monitor Status", status},
+	}
+	if alert.CronGroup != "" {
+		facts = append(facts, Fact{"Cron Group", alert.CronGroup})
+	}
+	return facts
+}
+
+// problemDetails renders the alert reason, or a bulleted list when the alert carries several reasons
+func problemDetails(alert cronalert.Alert) string {
+	switch len(alert.Reasons) {
+	case 0:
+		return alert.Reason
+	case 1:
+		return alert.Reasons[0]
+	}
+
+	lines := make([]string, len(alert.Reasons))
+	for i, reason := range alert.Reasons {
+		lines[i] = "- " + reason
+	}
+	return strings.Join(lines, "\r")
+}
+
+// contextBlock renders the trailing context line: timestamp, metadata, Magento version and escalation level
+func contextBlock(alert cronalert.Alert, timestampText string) Element {
+	parts := []string{timestampText}
+
+	if len(alert.Metadata) > 0 {
+		keys := make([]string, 0, len(alert.Metadata))
+		for k := range alert.Metadata {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			parts = append(parts, fmt.Sprintf("%s: %s", k, alert.Metadata[k]))
+		}
+	}
+
+	if alert.MagentoVersion != "" {
+		parts = append(parts, "Magento "+alert.MagentoVersion)
+	}
+
+	if alert.EscalationLevel > 0 {
+		parts = append(parts, fmt.Sprintf("Escalation level %d: still alerting after %s", alert.EscalationLevel, slack.FormatDuration(alert.StuckDuration)))
+	}
+
+	return Element{Type: "TextBlock", Text: strings.Join(parts, " · "), Size: "Small", IsSubtle: true, Wrap: true, Spacing: "Medium"}
+}
+
+// lastExecution formats the alert's last execution time
+func lastExecution(alert cronalert.Alert) string {
+	if alert.LastExecution.IsZero() {
+		return "Never"
+	}
+	return formatTime(alert.LastExecution)
+}
+
+// formatTime formats a timestamp in the timezone of logging.timezone, like the Slack messages
+func formatTime(t time.Time) string {
+	return slack.FormatTime(t)
+}
@@ -0,0 +1,49 @@
+package teams
+
+// Message is an incoming-webhook message carrying a single Adaptive Card
+type Message struct {
+	Type        string       `json:"type"`
+	Attachments []Attachment `json:"attachments"`
+}
+
+// Attachment wraps a card in a message
+type Attachment struct {
+	ContentType string `json:"contentType"`
+	Content     Card   `json:"content"`
+}
+
+// Card is an Adaptive Card
+type Card struct {
+	Schema  string    `json:"$schema"`
+	Type    string    `json:"type"`
+	Version string    `json:"version"`
+	Body    []Element `json:"body"`
+	MSTeams *CardOpts `json:"msteams,omitempty"`
+}
+
+// CardOpts holds Teams-specific card options
+type CardOpts struct {
+	Width string `json:"width,omitempty"`
+}
+
+// Element is an Adaptive Card body element (Container, TextBlock or FactSet)
+type Element struct {
+	Type     string    `json:"type"`
+	Style    string    `json:"style,omitempty"`
+	Bleed    bool      `json:"bleed,omitempty"`
+	Items    []Element `json:"items,omitempty"`
+	Text     string    `json:"text,omitempty"`
+	Size     string    `json:"size,omitempty"`
+	Weight   string    `json:"weight,omitempty"`
+	Color    string    `json:"color,omitempty"`
+	Wrap     bool      `json:"wrap,omitempty"`
+	IsSubtle bool      `json:"isSubtle,omitempty"`
+	Spacing  string    `json:"spacing,omitempty"`
+	Facts    []Fact    `json:"facts,omitempty"`
+}
+
+// Fact is a title/value pair in a FactSet
+type Fact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
@@ -0,0 +1,182 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/fabio/go-magento-cron-monitor/internal/cronalert"
+	"github.com/fabio/go-magento-cron-monitor/internal/httpclient"
+	"github.com/fabio/go-magento-cron-monitor/internal/slack"
+)
+
+// Config represents generic webhook notification configuration
+type Config struct {
+	Enabled      bool              `yaml:"enabled"`
+	URLs         []string          `yaml:"urls"`
+	Method       string            `yaml:"method"`
+	Headers      map[string]string `yaml:"headers"`
+	Template     string            `yaml:"template"` // text/template rendering the JSON body; empty sends the alert as JSON
+	SendRecovery bool              `yaml:"send_recovery"`
+	Timeout      time.Duration     `yaml:"timeout"`
+}
+
+// Client sends cron alerts as templated JSON requests
+type Client struct {
+	config     Config
+	template   *template.Template
+	httpClient *http.Client
+}
+
+// funcs are the helpers available in body templates
+var funcs = template.FuncMap{
+	// json encodes a value as JSON, e.g. {"content": {{json .Reason}}}
+	"json": func(v interface{}) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+	"duration": slack.FormatDuration,
+}
+
+// ParseTemplate parses a body template with the webhook helper functions
+func ParseTemplate(text string) (*template.Template, error) {
+	return template.New("webhook").Funcs(funcs).Option("missingkey=error").Parse(text)
+}
+
+// CheckTemplate parses a body template and renders it for a sample alert,
+// catching unknown fields as well as syntax errors
+func CheckTemplate(text string) error {
+	tmpl, err := ParseTemplate(text)
+	if err != nil {
+		return err
+	}
+	running := time.Minute
+	sample := cronalert.Alert{
+		Type:        cronalert.Alerting,
+		CronCode:    "sample_job",
+		Timestamp:   time.Now(),
+		RunningTime: &running,
+	}
+	return tmpl.Execute(io.Discard, sample)
+}
+
+// New creates a new webhook client
+func New(config Config) (*Client, error) {
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second
+	}
+	if config.Method == "" {
+		config.Method = http.MethodPost
+	}
+
+	client := &Client{
+		config:     config,
+		httpClient: httpclient.New(httpclient.Config{Timeout: config.Timeout}),
+	}
+	if config.Template != "" {
+		tmpl, err := ParseTemplate(config.Template)
+		if err != nil {
+			return nil, fmt.Errorf("invalid webhook template: %w", err)
+		}
+		client.template = tmpl
+	}
+
+	return client, nil
+}
+
+// Render renders the request body for an alert
+func (c *Client) Render(alert cronalert.Alert) ([]byte, error) {
+	if c.template == nil {
+		body, err := json.Marshal(alert)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal alert: %w", err)
+		}
+		return body, nil
+	}
+
+	var buf bytes.Buffer
+	if err := c.template.Execute(&buf, alert); err != nil {
+		return nil, fmt.Errorf("failed to render webhook template: %w", err)
+	}
+	if !json.Valid(buf.Bytes()) {
+		return nil, fmt.Errorf("webhook template rendered invalid JSON: %s", buf.String())
+	}
+	return buf.Bytes(), nil
+}
+
+// SendAlert renders the alert and sends it to all configured URLs
+func (c *Client) SendAlert(ctx context.Context, alert cronalert.Alert) error {
+	if !c.config.Enabled {
+		return nil
+	}
+
+	if len(c.config.URLs) == 0 {
+		return fmt.Errorf("no webhook URLs configured")
+	}
+
+	if err := alert.Type.Validate(); err != nil {
+		return err
+	}
+
+	body, err := c.Render(alert)
+	if err != nil {
+		return err
+	}
+
+	// Send to all URLs
+	var lastError error
+	successCount := 0
+
+	for i, url := range c.config.URLs {
+		if url == "" {
+			continue
+		}
+
+		if err := c.send(ctx, url, body); err != nil {
+			lastError = fmt.Errorf("webhook %d failed: %w", i+1, err)
+			continue
+		}
+		successCount++
+	}
+
+	// If all webhooks failed, return the last error
+	if successCount == 0 && lastError != nil {
+		return lastError
+	}
+
+	return nil
+}
+
+// send performs one request and checks for a 2xx response
+func (c *Client) send(ctx context.Context, url string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, strings.ToUpper(c.config.Method), url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range c.config.Headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("non-2xx status: %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// GetConfig returns the client configuration
+func (c *Client) GetConfig() Config {
+	return c.config
+}